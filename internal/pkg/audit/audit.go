@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+type Event struct {
+	ID             string             `json:"id"`
+	ActorID        string             `json:"actor_id"`
+	ImpersonatorID string             `json:"impersonator_id,omitempty"`
+	TargetID       string             `json:"target_id"`
+	Operation      string             `json:"operation"`
+	RequestID      string             `json:"request_id"`
+	SourceIP       string             `json:"source_ip"`
+	Metadata       map[string]any     `json:"metadata,omitempty"`
+	Changes        map[string]*Change `json:"changes,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+type Recorder interface {
+	Record(ctx context.Context, event *Event) error
+}
+
+// Filter narrows a listing of events. Empty fields match everything; the
+// time range is half-open, [From, To).
+type Filter struct {
+	ActorID    string
+	TargetID   string
+	Operation  string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	BeforeTime time.Time
+	BeforeID   string
+}
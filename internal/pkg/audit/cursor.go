@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+type pageCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"i"`
+}
+
+// EncodePageToken returns the token that continues a newest-first listing
+// after event.
+func EncodePageToken(event *Event) string {
+	data, _ := json.Marshal(pageCursor{CreatedAt: event.CreatedAt.UTC(), ID: event.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ApplyPageToken sets the keyset position of filter from token. An empty
+// token leaves the filter at the first page.
+func ApplyPageToken(filter *Filter, token string) error {
+	if token == "" {
+		return nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalidPageToken
+	}
+	cursor := &pageCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil || cursor.ID == "" {
+		return ErrInvalidPageToken
+	}
+
+	filter.BeforeTime = cursor.CreatedAt
+	filter.BeforeID = cursor.ID
+	return nil
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+)
+
+// Redacted replaces the value of sensitive fields in a diff. The change is
+// still recorded, only its content is not.
+const Redacted = "[REDACTED]"
+
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Diff compares two snapshots of the same record by their JSON fields and
+// returns the fields that differ. Either side may be nil, for creates and
+// deletes. Fields named in redact keep their place in the diff with both
+// values replaced by Redacted.
+func Diff(before, after any, redact ...string) (map[string]*Change, error) {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]*Change{}
+	for name, value := range beforeFields {
+		if other, ok := afterFields[name]; !ok || !reflect.DeepEqual(value, other) {
+			changes[name] = &Change{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = &Change{After: value}
+		}
+	}
+
+	for name, change := range changes {
+		if !slices.Contains(redact, name) {
+			continue
+		}
+		if change.Before != nil {
+			change.Before = Redacted
+		}
+		if change.After != nil {
+			change.After = Redacted
+		}
+	}
+
+	return changes, nil
+}
+
+func fields(v any) (map[string]any, error) {
+	if v == nil || reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil() {
+		return map[string]any{}, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,44 @@
+// Package blob stores small binary objects under slash-separated keys.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrNotFound = errors.New("blob: not found")
+
+type Object struct {
+	Data        []byte
+	ContentType string
+	ModTime     time.Time
+	ETag        string
+}
+
+// BlobStore is implemented by FilesystemStore and S3Store. Objects are held
+// in memory whole, so it suits small objects such as images.
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// Get returns ErrNotFound when no object is stored under key.
+	Get(ctx context.Context, key string) (*Object, error)
+	// DeletePrefix removes every object whose key starts with prefix. It
+	// succeeds when nothing matches.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// ValidateKey rejects keys that could escape a store's root: empty
+// segments, dot segments and backslashes.
+func ValidateKey(key string) error {
+	if key == "" || strings.Contains(key, "\\") {
+		return fmt.Errorf("blob: invalid key %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("blob: invalid key %q", key)
+		}
+	}
+	return nil
+}
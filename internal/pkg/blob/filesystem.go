@@ -0,0 +1,98 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FilesystemStore keeps each object in a file under root. The content type
+// is sniffed from the bytes on read, so it suits formats with a signature.
+type FilesystemStore struct {
+	root string
+}
+
+func NewFilesystemStore(root string) (*FilesystemStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("blob: create root: %w", err)
+	}
+	return &FilesystemStore{root: root}, nil
+}
+
+// Put writes to a temporary file and renames it into place, so readers
+// never see a partly written object.
+func (s *FilesystemStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FilesystemStore) Get(ctx context.Context, key string) (*Object, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	path := s.path(key)
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && info.IsDir()) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Object{
+		Data:        data,
+		ContentType: http.DetectContentType(data),
+		ModTime:     info.ModTime(),
+		ETag:        strconv.Quote(strconv.FormatInt(info.ModTime().UnixNano(), 36) + "-" + strconv.FormatInt(info.Size(), 36)),
+	}, nil
+}
+
+// DeletePrefix only accepts prefixes that end on a segment boundary, which
+// map to a directory.
+func (s *FilesystemStore) DeletePrefix(ctx context.Context, prefix string) error {
+	trimmed := strings.TrimSuffix(prefix, "/")
+	if trimmed == prefix {
+		return fmt.Errorf("blob: prefix %q must end with /", prefix)
+	}
+	if err := ValidateKey(trimmed); err != nil {
+		return err
+	}
+	return os.RemoveAll(s.path(trimmed))
+}
+
+func (s *FilesystemStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
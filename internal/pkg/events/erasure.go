@@ -0,0 +1,38 @@
+package events
+
+import "time"
+
+const (
+	UserErasureRequestedTopic = "user.erasure.requested"
+	UserErasureConfirmedTopic = "user.erasure.confirmed"
+)
+
+// Services taking part in erasure. Each one confirms once it has deleted or
+// anonymized everything it holds about the user.
+const (
+	ErasureServiceUser = "user"
+	ErasureServiceAuth = "auth"
+)
+
+type UserErasureRequestedEvent struct {
+	RequestID  string    `json:"request_id"`
+	UserID     string    `json:"user_id"`
+	TenantID   string    `json:"tenant_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e *UserErasureRequestedEvent) ID() string {
+	return e.UserID
+}
+
+type UserErasureConfirmedEvent struct {
+	RequestID  string    `json:"request_id"`
+	UserID     string    `json:"user_id"`
+	Service    string    `json:"service"`
+	TenantID   string    `json:"tenant_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e *UserErasureConfirmedEvent) ID() string {
+	return e.UserID
+}
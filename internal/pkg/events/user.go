@@ -0,0 +1,48 @@
+package events
+
+import "time"
+
+const (
+	UserStatusChangedTopic = "user.status.changed"
+	UserLifecycleTopic     = "user.lifecycle"
+)
+
+const (
+	UserLifecycleDeleted  = "deleted"
+	UserLifecycleRestored = "restored"
+	UserLifecyclePurged   = "purged"
+)
+
+type UserStatusChangedEvent struct {
+	EventID    string    `json:"event_id"`
+	UserID     string    `json:"user_id"`
+	OldStatus  string    `json:"old_status"`
+	NewStatus  string    `json:"new_status"`
+	Reason     string    `json:"reason"`
+	ActorID    string    `json:"actor_id"`
+	TenantID   string    `json:"tenant_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ID keys the message by user so every status change for one user lands on
+// the same partition and is consumed in order.
+func (e *UserStatusChangedEvent) ID() string {
+	return e.UserID
+}
+
+// UserLifecycleEvent reports soft deletion, restoration and the final hard
+// purge of a user. Status carries the account status to reapply on restore.
+// Events name the tenant the user belongs to; consumers act in it.
+type UserLifecycleEvent struct {
+	EventID    string    `json:"event_id"`
+	UserID     string    `json:"user_id"`
+	Type       string    `json:"type"`
+	Status     string    `json:"status,omitempty"`
+	ActorID    string    `json:"actor_id,omitempty"`
+	TenantID   string    `json:"tenant_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e *UserLifecycleEvent) ID() string {
+	return e.UserID
+}
@@ -0,0 +1,214 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	impersonatedWriteOperation = "impersonated_write"
+	stepUpDomain               = "auth.achilles"
+	stepUpReason               = "STEP_UP_REQUIRED"
+)
+
+// MethodRule says who may call a method. AnonymousTenant lets callers
+// without a token name the tenant in x-tenant-id; it is meant for sign-in
+// methods, which check the caller's credentials inside that tenant.
+type MethodRule struct {
+	Authenticated   bool
+	Permission      string
+	Mutating        bool
+	ACR             string
+	MaxAuthAge      time.Duration
+	AnonymousTenant bool
+}
+
+func (r MethodRule) requiresToken() bool {
+	return r.Authenticated || r.Permission != "" || r.ACR != "" || r.MaxAuthAge > 0
+}
+
+type claimsContextKey struct{}
+
+// AccountChecker reports whether a user's account may still act, so that a
+// suspension also stops access tokens issued before it.
+type AccountChecker interface {
+	IsActive(ctx context.Context, userID string) (bool, error)
+}
+
+type AuthInterceptor struct {
+	jwtUtil  jwtutils.JwtUtil
+	rules    map[string]MethodRule
+	recorder audit.Recorder
+	accounts AccountChecker
+}
+
+func NewAuthInterceptor(jwtUtil jwtutils.JwtUtil, rules map[string]MethodRule, recorder audit.Recorder, accounts AccountChecker) *AuthInterceptor {
+	return &AuthInterceptor{
+		jwtUtil:  jwtUtil,
+		rules:    rules,
+		recorder: recorder,
+		accounts: accounts,
+	}
+}
+
+func ClaimsFromContext(ctx context.Context) (*jwtutils.JWTClaims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*jwtutils.JWTClaims)
+	return claims, ok
+}
+
+func ContextWithClaims(ctx context.Context, claims *jwtutils.JWTClaims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+// Unary validates the bearer token when one is sent, resolves the tenant,
+// checks that the token's user is still active and enforces the rule for
+// the called method. Methods without a rule accept anonymous callers. A
+// service token satisfies Authenticated but holds no permissions.
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, claims, err := i.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, req)
+		if err == nil && i.rules[info.FullMethod].Mutating && claims != nil && claims.IsImpersonation() {
+			i.recordImpersonatedWrite(ctx, info.FullMethod, claims)
+		}
+		return resp, err
+	}
+}
+
+// Stream applies the same rules as Unary once, when the stream opens.
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, claims, err := i.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		err = handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		if err == nil && i.rules[info.FullMethod].Mutating && claims != nil && claims.IsImpersonation() {
+			i.recordImpersonatedWrite(ctx, info.FullMethod, claims)
+		}
+		return err
+	}
+}
+
+func (i *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, *jwtutils.JWTClaims, error) {
+	rule := i.rules[method]
+
+	var claims *jwtutils.JWTClaims
+	if token := bearerTokenFromContext(ctx); token != "" {
+		validated, err := i.jwtUtil.ValidateToken(token)
+		if err != nil || (validated.TokenType != "access" && !validated.IsService()) {
+			return nil, nil, status.Error(codes.Unauthenticated, "invalid access token")
+		}
+		claims = validated
+		ctx = ContextWithClaims(ctx, claims)
+	}
+
+	tenantID, err := resolveTenant(ctx, claims, rule.AnonymousTenant)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx = tenant.WithTenant(ctx, tenantID)
+
+	if claims != nil && !claims.IsService() {
+		active, err := i.accounts.IsActive(ctx, claims.UserID)
+		if err != nil {
+			return nil, nil, status.Error(codes.Unavailable, "cannot check account status")
+		}
+		if !active {
+			return nil, nil, status.Error(codes.Unauthenticated, "account is not active")
+		}
+	}
+
+	if rule.requiresToken() && claims == nil {
+		return nil, nil, status.Error(codes.Unauthenticated, "missing access token")
+	}
+	if rule.Permission != "" && (claims.IsImpersonation() || !claims.HasPermission(rule.Permission)) {
+		return nil, nil, status.Error(codes.PermissionDenied, "missing permission "+rule.Permission)
+	}
+	if err := checkStepUp(rule, claims); err != nil {
+		return nil, nil, err
+	}
+	return ctx, claims, nil
+}
+
+// contextStream hands stream handlers the context carrying the caller's
+// claims.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func (i *AuthInterceptor) recordImpersonatedWrite(ctx context.Context, method string, claims *jwtutils.JWTClaims) {
+	if i.recorder == nil {
+		return
+	}
+
+	i.recorder.Record(ctx, &audit.Event{
+		ID:             uuid.New().String(),
+		ActorID:        claims.UserID,
+		ImpersonatorID: claims.Actor.Subject,
+		TargetID:       claims.UserID,
+		Operation:      impersonatedWriteOperation,
+		RequestID:      RequestIDFromContext(ctx),
+		SourceIP:       SourceIPFromContext(ctx),
+		Metadata: map[string]any{
+			"method":   method,
+			"token_id": claims.ID,
+		},
+	})
+}
+
+// checkStepUp rejects callers whose token is weaker or older than the rule
+// demands. The error carries the required acr and max_age so clients know
+// which Reauthenticate call will satisfy it.
+func checkStepUp(rule MethodRule, claims *jwtutils.JWTClaims) error {
+	if rule.ACR == "" && rule.MaxAuthAge == 0 {
+		return nil
+	}
+
+	acrOK := rule.ACR == "" || jwtutils.ACRSatisfies(claims.ACR, rule.ACR)
+	ageOK := rule.MaxAuthAge == 0 || (claims.AuthTime != nil && time.Since(claims.AuthTime.Time) <= rule.MaxAuthAge)
+	if acrOK && ageOK {
+		return nil
+	}
+
+	requiredACR := rule.ACR
+	if requiredACR == "" {
+		requiredACR = jwtutils.ACRPassword
+	}
+	maxAge := strconv.FormatInt(int64(rule.MaxAuthAge.Seconds()), 10)
+
+	st := status.New(codes.Unauthenticated, fmt.Sprintf("step-up authentication required: acr=%s max_age=%ss", requiredACR, maxAge))
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: stepUpReason,
+		Domain: stepUpDomain,
+		Metadata: map[string]string{
+			"required_acr": requiredACR,
+			"max_age":      maxAge,
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,84 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeAccountChecker map[string]bool
+
+func (c fakeAccountChecker) IsActive(ctx context.Context, userID string) (bool, error) {
+	return c[userID], nil
+}
+
+func TestAuthorizeRejectsInactiveAccounts(t *testing.T) {
+	jwtUtil := jwtutils.NewJwtUtil(&jwtutils.JwtConfig{
+		AccessTokenDuration: 15,
+		SecretKey:           "test-secret",
+		Issuer:              "achilles",
+	})
+	interceptor := NewAuthInterceptor(jwtUtil, map[string]MethodRule{
+		"/test/Method": {Authenticated: true},
+	}, nil, fakeAccountChecker{"active": true, "suspended": false})
+
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, "Bearer "+token))
+	}
+
+	tests := []struct {
+		name  string
+		token func() (string, error)
+		want  codes.Code
+	}{
+		{
+			name: "active user",
+			token: func() (string, error) {
+				token, _, err := jwtUtil.GenerateAccessToken("active", "", nil)
+				return token, err
+			},
+			want: codes.OK,
+		},
+		{
+			name: "suspended user",
+			token: func() (string, error) {
+				token, _, err := jwtUtil.GenerateAccessToken("suspended", "", nil)
+				return token, err
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "service",
+			token: func() (string, error) {
+				token, _, err := jwtUtil.GenerateServiceToken("auth", serviceTokenDuration)
+				return token, err
+			},
+			want: codes.OK,
+		},
+		{
+			name: "refresh token",
+			token: func() (string, error) {
+				return jwtUtil.GenerateRefreshToken("active", "")
+			},
+			want: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.token()
+			if err != nil {
+				t.Fatalf("generate token: %v", err)
+			}
+
+			_, _, err = interceptor.authorize(withToken(token), "/test/Method")
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("authorize code = %v, want %v (err %v)", got, tt.want, err)
+			}
+		})
+	}
+}
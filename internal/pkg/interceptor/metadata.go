@@ -0,0 +1,73 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	authorizationHeader = "authorization"
+	requestIDHeader     = "x-request-id"
+	forwardedForHeader  = "x-forwarded-for"
+	tenantHeader        = "x-tenant-id"
+	bearerPrefix        = "bearer "
+)
+
+func RequestIDFromContext(ctx context.Context) string {
+	return firstMetadataValue(ctx, requestIDHeader)
+}
+
+func SourceIPFromContext(ctx context.Context) string {
+	if forwarded := firstMetadataValue(ctx, forwardedForHeader); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	return peerIP(ctx)
+}
+
+// peerIP returns the address of the connection the call arrived on. Unlike
+// SourceIPFromContext it ignores headers, which the caller controls.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// ForwardAuthorization copies the caller's authorization header to the
+// outgoing context so a downstream service authorizes the same principal.
+func ForwardAuthorization(ctx context.Context) context.Context {
+	value := firstMetadataValue(ctx, authorizationHeader)
+	if value == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, authorizationHeader, value)
+}
+
+func bearerTokenFromContext(ctx context.Context) string {
+	value := firstMetadataValue(ctx, authorizationHeader)
+	if len(value) < len(bearerPrefix) || !strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(value[len(bearerPrefix):])
+}
+
+func firstMetadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
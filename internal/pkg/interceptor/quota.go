@@ -0,0 +1,93 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/quota"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// QuotaInterceptor meters every call against its tenant's RPC rate, or its
+// address's when it is anonymous. It reads the claims and tenant the
+// AuthInterceptor resolved, so it must run after it.
+type QuotaInterceptor struct {
+	meter  quota.Meter
+	config *quota.Config
+}
+
+func NewQuotaInterceptor(meter quota.Meter, config *quota.Config) *QuotaInterceptor {
+	return &QuotaInterceptor{
+		meter:  meter,
+		config: config,
+	}
+}
+
+func (i *QuotaInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := i.admit(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream counts a stream as one call, when it opens.
+func (i *QuotaInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.admit(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// admit fails open: when Redis cannot count the call it is let through, so
+// an outage of the meter does not take the API down with it.
+//
+// Only a token or a client certificate vouches for the tenant, so anonymous
+// calls are limited per network address and billed to no tenant. Otherwise
+// anyone could exhaust a tenant's rate or fill the usage records with made
+// up tenants.
+func (i *QuotaInterceptor) admit(ctx context.Context) error {
+	now := time.Now()
+
+	if _, ok := ClaimsFromContext(ctx); !ok && !isServiceIdentity(ctx, nil) {
+		calls, err := i.meter.CountAnonymousCall(ctx, peerIP(ctx), now)
+		if err != nil {
+			return nil
+		}
+		return rateLimited(calls, i.config.ForAnonymous().RPCPerMinute, now, "anonymous")
+	}
+
+	tenantID := tenant.FromContext(ctx)
+	calls, err := i.meter.CountCall(ctx, tenantID, now)
+	if err != nil {
+		return nil
+	}
+	return rateLimited(calls, i.config.For(tenantID).RPCPerMinute, now, "tenant")
+}
+
+// rateLimited returns ResourceExhausted, with the time left until the next
+// minute, once calls exceed limit.
+func rateLimited(calls, limit int64, now time.Time, scope string) error {
+	if limit <= 0 || calls <= limit {
+		return nil
+	}
+
+	retryAfter := now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("%s rate limit of %d calls per minute exceeded", scope, limit))
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,70 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/quota"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type fakeMeter struct {
+	quota.Meter
+
+	tenants   map[string]int64
+	anonymous map[string]int64
+}
+
+func (m *fakeMeter) CountCall(ctx context.Context, tenantID string, at time.Time) (int64, error) {
+	m.tenants[tenantID]++
+	return m.tenants[tenantID], nil
+}
+
+func (m *fakeMeter) CountAnonymousCall(ctx context.Context, source string, at time.Time) (int64, error) {
+	m.anonymous[source]++
+	return m.anonymous[source], nil
+}
+
+func TestQuotaInterceptorMetersAnonymousCallsByPeer(t *testing.T) {
+	meter := &fakeMeter{tenants: map[string]int64{}, anonymous: map[string]int64{}}
+	interceptor := NewQuotaInterceptor(meter, &quota.Config{
+		Default:   quota.Limits{RPCPerMinute: 10},
+		Anonymous: quota.Limits{RPCPerMinute: 1},
+	})
+
+	anonymous := func(ip string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 443}})
+		// A spoofed forwarding header must not give the caller a fresh
+		// bucket.
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(forwardedForHeader, time.Now().String()))
+		return tenant.WithTenant(ctx, tenant.DefaultID)
+	}
+
+	if err := interceptor.admit(anonymous("192.0.2.1")); err != nil {
+		t.Fatalf("first anonymous call: %v", err)
+	}
+	if err := interceptor.admit(anonymous("192.0.2.1")); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second anonymous call from the same peer = %v, want ResourceExhausted", err)
+	}
+	if err := interceptor.admit(anonymous("192.0.2.2")); err != nil {
+		t.Fatalf("anonymous call from another peer: %v", err)
+	}
+	if len(meter.tenants) != 0 {
+		t.Errorf("anonymous calls were billed to tenants %v", meter.tenants)
+	}
+
+	ctx := ContextWithClaims(context.Background(), &jwtutils.JWTClaims{TokenType: "access", TenantID: "acme"})
+	if err := interceptor.admit(tenant.WithTenant(ctx, "acme")); err != nil {
+		t.Fatalf("authenticated call: %v", err)
+	}
+	if meter.tenants["acme"] != 1 {
+		t.Errorf("acme was billed %d calls, want 1", meter.tenants["acme"])
+	}
+}
@@ -0,0 +1,114 @@
+package interceptor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	serviceTokenDuration = 5 * time.Minute
+	serviceTokenLeeway   = time.Minute
+)
+
+// ServiceCredentials mints the token a service presents when it calls
+// another service on its own behalf. Tokens are reused until shortly before
+// they expire.
+type ServiceCredentials struct {
+	jwtUtil jwtutils.JwtUtil
+	service string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func NewServiceCredentials(jwtUtil jwtutils.JwtUtil, service string) *ServiceCredentials {
+	return &ServiceCredentials{
+		jwtUtil: jwtUtil,
+		service: service,
+	}
+}
+
+func (c *ServiceCredentials) Token() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Until(c.expiresAt) > serviceTokenLeeway {
+		return c.token, nil
+	}
+
+	token, expiresAt, err := c.jwtUtil.GenerateServiceToken(c.service, serviceTokenDuration)
+	if err != nil {
+		return "", err
+	}
+	c.token, c.expiresAt = token, expiresAt
+	return token, nil
+}
+
+// ServiceConn wraps a client connection so calls that do not forward a
+// caller's token, see ForwardAuthorization, authenticate as the service.
+func ServiceConn(conn grpc.ClientConnInterface, credentials *ServiceCredentials) grpc.ClientConnInterface {
+	return &serviceConn{conn: conn, credentials: credentials}
+}
+
+type serviceConn struct {
+	conn        grpc.ClientConnInterface
+	credentials *ServiceCredentials
+}
+
+func (c *serviceConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	ctx, err := c.authorize(ctx)
+	if err != nil {
+		return err
+	}
+	return c.conn.Invoke(ctx, method, args, reply, opts...)
+}
+
+func (c *serviceConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, err := c.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.conn.NewStream(ctx, desc, method, opts...)
+}
+
+func (c *serviceConn) authorize(ctx context.Context) (context.Context, error) {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(authorizationHeader)) > 0 {
+		return ctx, nil
+	}
+
+	token, err := c.credentials.Token()
+	if err != nil {
+		return nil, err
+	}
+	return metadata.AppendToOutgoingContext(ctx, authorizationHeader, "Bearer "+token), nil
+}
+
+// IsServiceIdentity reports whether the caller is another service, for use
+// cases that admit services on methods open to anonymous callers.
+func IsServiceIdentity(ctx context.Context) bool {
+	claims, _ := ClaimsFromContext(ctx)
+	return isServiceIdentity(ctx, claims)
+}
+
+// isServiceIdentity reports whether the caller is another service: it
+// presented a service token, or a client certificate the server verified.
+func isServiceIdentity(ctx context.Context, claims *jwtutils.JWTClaims) bool {
+	if claims != nil {
+		return claims.IsService()
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	return ok && len(info.State.VerifiedChains) > 0
+}
@@ -0,0 +1,70 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// resolveTenant picks the tenant a call acts in. A user's token settles it;
+// the x-tenant-id header may only repeat the token's tenant. Other services
+// name the tenant with the header, and so may anonymous callers of methods
+// whose rule allows it. Anyone else acts in tenant.DefaultID and may not send
+// the header, or they could pick any tenant they like.
+func resolveTenant(ctx context.Context, claims *jwtutils.JWTClaims, anonymousTenant bool) (string, error) {
+	header := firstMetadataValue(ctx, tenantHeader)
+	if header != "" && !tenant.Valid(header) {
+		return "", status.Error(codes.InvalidArgument, "invalid tenant")
+	}
+
+	if claims != nil && !claims.IsService() {
+		tenantID := claims.TenantID
+		if tenantID == "" {
+			tenantID = tenant.DefaultID
+		}
+		if header != "" && header != tenantID {
+			return "", status.Error(codes.PermissionDenied, "tenant does not match access token")
+		}
+		return tenantID, nil
+	}
+
+	if header == "" {
+		return tenant.DefaultID, nil
+	}
+	if claims == nil && anonymousTenant {
+		return header, nil
+	}
+	if !isServiceIdentity(ctx, claims) {
+		return "", status.Error(codes.PermissionDenied, "only services may choose the tenant")
+	}
+	return header, nil
+}
+
+// ForwardTenant names the caller's tenant on the outgoing context so a
+// downstream service acts in the same one.
+func ForwardTenant(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, tenantHeader, tenant.FromContext(ctx))
+}
+
+// TenantConn wraps a client connection so every call made through it
+// forwards the caller's tenant.
+func TenantConn(conn grpc.ClientConnInterface) grpc.ClientConnInterface {
+	return &tenantConn{conn: conn}
+}
+
+type tenantConn struct {
+	conn grpc.ClientConnInterface
+}
+
+func (c *tenantConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	return c.conn.Invoke(ForwardTenant(ctx), method, args, reply, opts...)
+}
+
+func (c *tenantConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return c.conn.NewStream(ForwardTenant(ctx), desc, method, opts...)
+}
@@ -0,0 +1,115 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestResolveTenant(t *testing.T) {
+	verifiedPeer := &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{}}},
+	}}}
+
+	tests := []struct {
+		name      string
+		header    string
+		claims    *jwtutils.JWTClaims
+		peer      *peer.Peer
+		anonymous bool
+		want      string
+		code      codes.Code
+	}{
+		{
+			name: "anonymous",
+			want: tenant.DefaultID,
+		},
+		{
+			name:   "anonymous naming a tenant",
+			header: "acme",
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:      "anonymous naming a tenant on a sign-in method",
+			header:    "acme",
+			anonymous: true,
+			want:      "acme",
+		},
+		{
+			name:      "anonymous on a sign-in method",
+			anonymous: true,
+			want:      tenant.DefaultID,
+		},
+		{
+			name:      "user token naming another tenant on a sign-in method",
+			header:    "globex",
+			claims:    &jwtutils.JWTClaims{TokenType: "access", TenantID: "acme"},
+			anonymous: true,
+			code:      codes.PermissionDenied,
+		},
+		{
+			name:   "user token",
+			claims: &jwtutils.JWTClaims{TokenType: "access", TenantID: "acme"},
+			want:   "acme",
+		},
+		{
+			name:   "user token repeating its tenant",
+			header: "acme",
+			claims: &jwtutils.JWTClaims{TokenType: "access", TenantID: "acme"},
+			want:   "acme",
+		},
+		{
+			name:   "user token naming another tenant",
+			header: "globex",
+			claims: &jwtutils.JWTClaims{TokenType: "access", TenantID: "acme"},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "service token naming a tenant",
+			header: "acme",
+			claims: &jwtutils.JWTClaims{TokenType: "service"},
+			want:   "acme",
+		},
+		{
+			name:   "verified client certificate naming a tenant",
+			header: "acme",
+			peer:   verifiedPeer,
+			want:   "acme",
+		},
+		{
+			name:   "invalid tenant",
+			header: tenant.All,
+			claims: &jwtutils.JWTClaims{TokenType: "service"},
+			code:   codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(tenantHeader, tt.header))
+			}
+			if tt.peer != nil {
+				ctx = peer.NewContext(ctx, tt.peer)
+			}
+
+			got, err := resolveTenant(ctx, tt.claims, tt.anonymous)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("resolveTenant code = %v, want %v (err %v)", code, tt.code, err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveTenant = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,427 @@
+// Package jsonschema validates JSON values against a subset of JSON Schema
+// (draft 2020-12) that covers flat, tenant-defined records: type, enum,
+// const, properties, required, additionalProperties, items, string length
+// and pattern, numeric bounds and array length. Keywords outside the subset
+// are rejected when the schema is compiled rather than silently ignored.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var supportedKeywords = map[string]struct{}{
+	"$schema": {}, "$id": {}, "title": {}, "description": {}, "default": {}, "examples": {},
+	"type": {}, "enum": {}, "const": {},
+	"properties": {}, "required": {}, "additionalProperties": {}, "minProperties": {}, "maxProperties": {},
+	"items": {}, "minItems": {}, "maxItems": {}, "uniqueItems": {},
+	"minLength": {}, "maxLength": {}, "pattern": {},
+	"minimum": {}, "maximum": {}, "exclusiveMinimum": {}, "exclusiveMaximum": {}, "multipleOf": {},
+}
+
+var validTypes = []string{"null", "boolean", "object", "array", "number", "integer", "string"}
+
+type Schema struct {
+	types                []string
+	enum                 []any
+	constValue           *any
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+	noAdditional         bool
+	minProperties        *int
+	maxProperties        *int
+	items                *Schema
+	minItems             *int
+	maxItems             *int
+	uniqueItems          bool
+	minLength            *int
+	maxLength            *int
+	pattern              *regexp.Regexp
+	minimum              *float64
+	maximum              *float64
+	exclusiveMinimum     *float64
+	exclusiveMaximum     *float64
+	multipleOf           *float64
+}
+
+// ValidationError lists every way a value failed its schema, each prefixed
+// with the JSON pointer of the offending value.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+// Compile parses a schema document. Only objects and the boolean schemas
+// true and false are accepted at the top level.
+func Compile(data []byte) (*Schema, error) {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+	return compile(raw, "#")
+}
+
+func compile(raw any, at string) (*Schema, error) {
+	switch v := raw.(type) {
+	case bool:
+		if v {
+			return &Schema{}, nil
+		}
+		return &Schema{types: []string{}}, nil
+	case map[string]any:
+		return compileObject(v, at)
+	default:
+		return nil, fmt.Errorf("%s: schema must be an object or a boolean", at)
+	}
+}
+
+func compileObject(raw map[string]any, at string) (*Schema, error) {
+	for keyword := range raw {
+		if _, ok := supportedKeywords[keyword]; !ok {
+			return nil, fmt.Errorf("%s: unsupported keyword %q", at, keyword)
+		}
+	}
+
+	s := &Schema{}
+	var err error
+
+	if t, ok := raw["type"]; ok {
+		if s.types, err = compileTypes(t, at); err != nil {
+			return nil, err
+		}
+	}
+	if e, ok := raw["enum"]; ok {
+		values, ok := e.([]any)
+		if !ok || len(values) == 0 {
+			return nil, fmt.Errorf("%s/enum: must be a non-empty array", at)
+		}
+		s.enum = values
+	}
+	if c, ok := raw["const"]; ok {
+		s.constValue = &c
+	}
+
+	if p, ok := raw["properties"]; ok {
+		properties, ok := p.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s/properties: must be an object", at)
+		}
+		s.properties = make(map[string]*Schema, len(properties))
+		for name, property := range properties {
+			if s.properties[name], err = compile(property, at+"/properties/"+name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if r, ok := raw["required"]; ok {
+		names, ok := r.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s/required: must be an array of strings", at)
+		}
+		for _, name := range names {
+			str, ok := name.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s/required: must be an array of strings", at)
+			}
+			s.required = append(s.required, str)
+		}
+	}
+	if a, ok := raw["additionalProperties"]; ok {
+		if allowed, ok := a.(bool); ok {
+			s.noAdditional = !allowed
+		} else if s.additionalProperties, err = compile(a, at+"/additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+	if i, ok := raw["items"]; ok {
+		if s.items, err = compile(i, at+"/items"); err != nil {
+			return nil, err
+		}
+	}
+	if u, ok := raw["uniqueItems"]; ok {
+		if s.uniqueItems, ok = u.(bool); !ok {
+			return nil, fmt.Errorf("%s/uniqueItems: must be a boolean", at)
+		}
+	}
+	if p, ok := raw["pattern"]; ok {
+		str, ok := p.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s/pattern: must be a string", at)
+		}
+		if s.pattern, err = regexp.Compile(str); err != nil {
+			return nil, fmt.Errorf("%s/pattern: %w", at, err)
+		}
+	}
+
+	counts := map[string]**int{
+		"minProperties": &s.minProperties, "maxProperties": &s.maxProperties,
+		"minItems": &s.minItems, "maxItems": &s.maxItems,
+		"minLength": &s.minLength, "maxLength": &s.maxLength,
+	}
+	for keyword, target := range counts {
+		if v, ok := raw[keyword]; ok {
+			n, ok := v.(float64)
+			if !ok || n < 0 || n != math.Trunc(n) {
+				return nil, fmt.Errorf("%s/%s: must be a non-negative integer", at, keyword)
+			}
+			count := int(n)
+			*target = &count
+		}
+	}
+
+	bounds := map[string]**float64{
+		"minimum": &s.minimum, "maximum": &s.maximum,
+		"exclusiveMinimum": &s.exclusiveMinimum, "exclusiveMaximum": &s.exclusiveMaximum,
+		"multipleOf": &s.multipleOf,
+	}
+	for keyword, target := range bounds {
+		if v, ok := raw[keyword]; ok {
+			n, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s/%s: must be a number", at, keyword)
+			}
+			*target = &n
+		}
+	}
+	if s.multipleOf != nil && *s.multipleOf <= 0 {
+		return nil, fmt.Errorf("%s/multipleOf: must be greater than zero", at)
+	}
+
+	return s, nil
+}
+
+func compileTypes(raw any, at string) ([]string, error) {
+	var names []any
+	switch v := raw.(type) {
+	case string:
+		names = []any{v}
+	case []any:
+		names = v
+	default:
+		return nil, fmt.Errorf("%s/type: must be a string or an array of strings", at)
+	}
+
+	types := make([]string, 0, len(names))
+	for _, name := range names {
+		str, ok := name.(string)
+		if !ok || !slices.Contains(validTypes, str) {
+			return nil, fmt.Errorf("%s/type: unknown type %v", at, name)
+		}
+		types = append(types, str)
+	}
+	return types, nil
+}
+
+// AllowsType reports whether the schema's type keyword admits values of
+// type t. A schema without a type keyword admits every type.
+func (s *Schema) AllowsType(t string) bool {
+	return s.types == nil || slices.Contains(s.types, t)
+}
+
+// Validate checks a value decoded by encoding/json, or any value that
+// round-trips through it, against the schema.
+func (s *Schema) Validate(value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	problems := s.validate(decoded, "")
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func (s *Schema) validate(value any, at string) []string {
+	if s.types != nil && len(s.types) == 0 {
+		return []string{pointer(at) + ": no value is allowed"}
+	}
+
+	problems := []string{}
+	if s.types != nil && !slices.ContainsFunc(s.types, func(t string) bool { return hasType(value, t) }) {
+		return append(problems, fmt.Sprintf("%s: must be of type %s", pointer(at), strings.Join(s.types, " or ")))
+	}
+	if s.enum != nil && !slices.ContainsFunc(s.enum, func(e any) bool { return reflect.DeepEqual(e, value) }) {
+		problems = append(problems, pointer(at)+": must be one of the allowed values")
+	}
+	if s.constValue != nil && !reflect.DeepEqual(*s.constValue, value) {
+		problems = append(problems, pointer(at)+": must equal the constant value")
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		problems = append(problems, s.validateObject(v, at)...)
+	case []any:
+		problems = append(problems, s.validateArray(v, at)...)
+	case string:
+		problems = append(problems, s.validateString(v, at)...)
+	case float64:
+		problems = append(problems, s.validateNumber(v, at)...)
+	}
+	return problems
+}
+
+func (s *Schema) validateObject(object map[string]any, at string) []string {
+	problems := []string{}
+	for _, name := range s.required {
+		if _, ok := object[name]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: missing required property %q", pointer(at), name))
+		}
+	}
+	if s.minProperties != nil && len(object) < *s.minProperties {
+		problems = append(problems, fmt.Sprintf("%s: must have at least %d properties", pointer(at), *s.minProperties))
+	}
+	if s.maxProperties != nil && len(object) > *s.maxProperties {
+		problems = append(problems, fmt.Sprintf("%s: must have at most %d properties", pointer(at), *s.maxProperties))
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := at + "/" + escape(name)
+		if property, ok := s.properties[name]; ok {
+			problems = append(problems, property.validate(object[name], path)...)
+			continue
+		}
+		if s.noAdditional {
+			problems = append(problems, fmt.Sprintf("%s: property is not allowed", pointer(path)))
+			continue
+		}
+		if s.additionalProperties != nil {
+			problems = append(problems, s.additionalProperties.validate(object[name], path)...)
+		}
+	}
+	return problems
+}
+
+func (s *Schema) validateArray(array []any, at string) []string {
+	problems := []string{}
+	if s.minItems != nil && len(array) < *s.minItems {
+		problems = append(problems, fmt.Sprintf("%s: must have at least %d items", pointer(at), *s.minItems))
+	}
+	if s.maxItems != nil && len(array) > *s.maxItems {
+		problems = append(problems, fmt.Sprintf("%s: must have at most %d items", pointer(at), *s.maxItems))
+	}
+	if s.uniqueItems && hasDuplicates(array) {
+		problems = append(problems, fmt.Sprintf("%s: items must be unique", pointer(at)))
+	}
+	if s.items != nil {
+		for i, item := range array {
+			problems = append(problems, s.items.validate(item, fmt.Sprintf("%s/%d", at, i))...)
+		}
+	}
+	return problems
+}
+
+func (s *Schema) validateString(str string, at string) []string {
+	problems := []string{}
+	length := utf8.RuneCountInString(str)
+	if s.minLength != nil && length < *s.minLength {
+		problems = append(problems, fmt.Sprintf("%s: must be at least %d characters", pointer(at), *s.minLength))
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		problems = append(problems, fmt.Sprintf("%s: must be at most %d characters", pointer(at), *s.maxLength))
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		problems = append(problems, fmt.Sprintf("%s: must match pattern %s", pointer(at), s.pattern))
+	}
+	return problems
+}
+
+func (s *Schema) validateNumber(n float64, at string) []string {
+	problems := []string{}
+	if s.minimum != nil && n < *s.minimum {
+		problems = append(problems, fmt.Sprintf("%s: must be at least %v", pointer(at), *s.minimum))
+	}
+	if s.maximum != nil && n > *s.maximum {
+		problems = append(problems, fmt.Sprintf("%s: must be at most %v", pointer(at), *s.maximum))
+	}
+	if s.exclusiveMinimum != nil && n <= *s.exclusiveMinimum {
+		problems = append(problems, fmt.Sprintf("%s: must be greater than %v", pointer(at), *s.exclusiveMinimum))
+	}
+	if s.exclusiveMaximum != nil && n >= *s.exclusiveMaximum {
+		problems = append(problems, fmt.Sprintf("%s: must be less than %v", pointer(at), *s.exclusiveMaximum))
+	}
+	if s.multipleOf != nil && !isMultipleOf(n, *s.multipleOf) {
+		problems = append(problems, fmt.Sprintf("%s: must be a multiple of %v", pointer(at), *s.multipleOf))
+	}
+	return problems
+}
+
+// isMultipleOf divides the shortest decimal forms of n and divisor, which
+// are what the document spelled out, so 0.3 counts as a multiple of 0.1
+// even though their binary approximations do not divide evenly.
+func isMultipleOf(n, divisor float64) bool {
+	x, ok := new(big.Rat).SetString(strconv.FormatFloat(n, 'g', -1, 64))
+	if !ok {
+		return false
+	}
+	y, ok := new(big.Rat).SetString(strconv.FormatFloat(divisor, 'g', -1, 64))
+	if !ok {
+		return false
+	}
+	return x.Quo(x, y).IsInt()
+}
+
+func hasDuplicates(array []any) bool {
+	for i := range array {
+		for j := i + 1; j < len(array); j++ {
+			if reflect.DeepEqual(array[i], array[j]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasType(value any, t string) bool {
+	switch v := value.(type) {
+	case nil:
+		return t == "null"
+	case bool:
+		return t == "boolean"
+	case map[string]any:
+		return t == "object"
+	case []any:
+		return t == "array"
+	case string:
+		return t == "string"
+	case float64:
+		return t == "number" || t == "integer" && v == math.Trunc(v) && !math.IsInf(v, 0)
+	}
+	return false
+}
+
+func pointer(at string) string {
+	if at == "" {
+		return "/"
+	}
+	return at
+}
+
+// escape encodes a property name as a JSON pointer token (RFC 6901).
+func escape(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,135 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	tests := map[string]struct {
+		schema  string
+		wantErr string
+	}{
+		"empty object":         {schema: `{}`},
+		"true":                 {schema: `true`},
+		"false":                {schema: `false`},
+		"type list":            {schema: `{"type": ["string", "null"]}`},
+		"not json":             {schema: `{`, wantErr: "not valid JSON"},
+		"array":                {schema: `[]`, wantErr: "must be an object or a boolean"},
+		"unsupported keyword":  {schema: `{"oneOf": []}`, wantErr: `unsupported keyword "oneOf"`},
+		"nested unsupported":   {schema: `{"properties": {"a": {"$ref": "#"}}}`, wantErr: "#/properties/a: unsupported keyword"},
+		"unknown type":         {schema: `{"type": "float"}`, wantErr: "unknown type float"},
+		"empty enum":           {schema: `{"enum": []}`, wantErr: "non-empty array"},
+		"required not strings": {schema: `{"required": [1]}`, wantErr: "array of strings"},
+		"bad pattern":          {schema: `{"pattern": "("}`, wantErr: "#/pattern"},
+		"fractional count":     {schema: `{"maxLength": 1.5}`, wantErr: "non-negative integer"},
+		"negative count":       {schema: `{"minItems": -1}`, wantErr: "non-negative integer"},
+		"string bound":         {schema: `{"minimum": "1"}`, wantErr: "must be a number"},
+		"zero multipleOf":      {schema: `{"multipleOf": 0}`, wantErr: "greater than zero"},
+		"negative multipleOf":  {schema: `{"multipleOf": -0.5}`, wantErr: "greater than zero"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Compile([]byte(tt.schema))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Compile: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Compile: %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		schema string
+		value  string
+		want   []string
+	}{
+		"integer accepts whole number":    {schema: `{"type": "integer"}`, value: `3`},
+		"integer accepts 1.0":             {schema: `{"type": "integer"}`, value: `1.0`},
+		"integer accepts large whole":     {schema: `{"type": "integer"}`, value: `1e20`},
+		"integer rejects fraction":        {schema: `{"type": "integer"}`, value: `1.5`, want: []string{"/: must be of type integer"}},
+		"integer rejects numeric string":  {schema: `{"type": "integer"}`, value: `"3"`, want: []string{"/: must be of type integer"}},
+		"number accepts integer":          {schema: `{"type": "number"}`, value: `3`},
+		"number accepts fraction":         {schema: `{"type": "number"}`, value: `-0.25`},
+		"number rejects boolean":          {schema: `{"type": "number"}`, value: `true`, want: []string{"/: must be of type number"}},
+		"type list":                       {schema: `{"type": ["string", "null"]}`, value: `null`},
+		"false schema":                    {schema: `false`, value: `1`, want: []string{"/: no value is allowed"}},
+		"multipleOf decimal fraction":     {schema: `{"multipleOf": 0.1}`, value: `0.3`},
+		"multipleOf cents":                {schema: `{"multipleOf": 0.01}`, value: `19.99`},
+		"multipleOf fraction of fraction": {schema: `{"multipleOf": 0.25}`, value: `1.75`},
+		"multipleOf integer":              {schema: `{"multipleOf": 3}`, value: `-9`},
+		"multipleOf zero":                 {schema: `{"multipleOf": 0.7}`, value: `0`},
+		"not a multiple of fraction":      {schema: `{"multipleOf": 0.1}`, value: `0.35`, want: []string{"/: must be a multiple of 0.1"}},
+		"not a multiple of integer":       {schema: `{"multipleOf": 3}`, value: `10`, want: []string{"/: must be a multiple of 3"}},
+		"integer with multipleOf":         {schema: `{"type": "integer", "multipleOf": 0.5}`, value: `2.5`, want: []string{"/: must be of type integer"}},
+		"bounds inclusive":                {schema: `{"minimum": 1, "maximum": 2}`, value: `2`},
+		"between exclusive bounds":        {schema: `{"exclusiveMinimum": 1, "exclusiveMaximum": 2}`, value: `1.5`},
+		"exclusive minimum":               {schema: `{"exclusiveMinimum": 1}`, value: `1`, want: []string{"/: must be greater than 1"}},
+		"exclusive maximum":               {schema: `{"exclusiveMaximum": 2}`, value: `2`, want: []string{"/: must be less than 2"}},
+		"below minimum":                   {schema: `{"minimum": 1}`, value: `0.5`, want: []string{"/: must be at least 1"}},
+		"string length counts runes":      {schema: `{"maxLength": 2}`, value: `"éé"`},
+		"string too long":                 {schema: `{"maxLength": 2}`, value: `"abc"`, want: []string{"/: must be at most 2 characters"}},
+		"pattern":                         {schema: `{"pattern": "^[A-Z]{2}$"}`, value: `"gb"`, want: []string{"/: must match pattern ^[A-Z]{2}$"}},
+		"enum":                            {schema: `{"enum": ["a", 1]}`, value: `1`},
+		"const":                           {schema: `{"const": {"a": 1}}`, value: `{"a": 2}`, want: []string{"/: must equal the constant value"}},
+		"object": {
+			schema: `{"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}, "age": {"type": "integer"}}, "additionalProperties": false}`,
+			value:  `{"age": 1.5, "a/b": true}`,
+			want: []string{
+				`/: missing required property "id"`,
+				"/a~1b: property is not allowed",
+				"/age: must be of type integer",
+			},
+		},
+		"additionalProperties schema": {schema: `{"additionalProperties": {"type": "string"}}`, value: `{"x": 1}`, want: []string{"/x: must be of type string"}},
+		"array items":                 {schema: `{"items": {"type": "integer"}, "maxItems": 2, "uniqueItems": true}`, value: `[1, 1, 2.5]`, want: []string{"/: must have at most 2 items", "/: items must be unique", "/2: must be of type integer"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			schema, err := Compile([]byte(tt.schema))
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+			var value any
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatalf("bad test value: %v", err)
+			}
+			err = schema.Validate(value)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			verr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Validate: %v, want ValidationError %q", err, tt.want)
+			}
+			if strings.Join(verr.Problems, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("problems =\n%s\nwant\n%s", strings.Join(verr.Problems, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestAllowsType(t *testing.T) {
+	schema, err := Compile([]byte(`{"type": ["integer", "null"]}`))
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	if !schema.AllowsType("integer") || !schema.AllowsType("null") || schema.AllowsType("string") {
+		t.Errorf("AllowsType disagrees with the type keyword")
+	}
+
+	untyped, _ := Compile([]byte(`{}`))
+	if !untyped.AllowsType("string") {
+		t.Errorf("a schema without type should allow every type")
+	}
+}
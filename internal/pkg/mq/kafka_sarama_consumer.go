@@ -0,0 +1,104 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/hailsayan/achilles/internal/pkg/logger"
+)
+
+const (
+	handlerMaxAttempts  = 5
+	handlerRetryBackoff = time.Second
+)
+
+type saramaKafkaConsumer struct {
+	group   sarama.ConsumerGroup
+	topic   string
+	handler KafkaHandler
+	log     logger.Logger
+}
+
+func NewKafkaConsumer(group sarama.ConsumerGroup, topic string, handler KafkaHandler, log logger.Logger) KafkaConsumer {
+	return &saramaKafkaConsumer{
+		group:   group,
+		topic:   topic,
+		handler: handler,
+		log:     log,
+	}
+}
+
+// Consume rejoins the group whenever a session ends, which is also how a
+// message whose handler kept failing is delivered again.
+func (c *saramaKafkaConsumer) Consume(ctx context.Context) error {
+	for {
+		err := c.group.Consume(ctx, []string{c.topic}, &consumerGroupHandler{handler: c.handler, log: c.log})
+		if err != nil && !errors.Is(err, sarama.ErrClosedConsumerGroup) {
+			return err
+		}
+		if ctx.Err() != nil || errors.Is(err, sarama.ErrClosedConsumerGroup) {
+			return nil
+		}
+	}
+}
+
+func (c *saramaKafkaConsumer) Handler() KafkaHandler {
+	return c.handler
+}
+
+func (c *saramaKafkaConsumer) Topic() string {
+	return c.topic
+}
+
+func (c *saramaKafkaConsumer) Close() error {
+	return c.group.Close()
+}
+
+type consumerGroupHandler struct {
+	handler KafkaHandler
+	log     logger.Logger
+}
+
+func (h *consumerGroupHandler) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *consumerGroupHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim marks a message only once its handler succeeded. A message
+// that still fails after handlerMaxAttempts ends the session unmarked, so
+// it is redelivered, and the messages after it wait rather than overtake
+// it.
+func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for message := range claim.Messages() {
+		if err := h.handle(session.Context(), message); err != nil {
+			h.log.Errorf("giving up on message from %s[%d]@%d for now: %v", message.Topic, message.Partition, message.Offset, err)
+			return err
+		}
+		session.MarkMessage(message, "")
+	}
+	return nil
+}
+
+// handle runs the handler, retrying with an exponential backoff.
+func (h *consumerGroupHandler) handle(ctx context.Context, message *sarama.ConsumerMessage) error {
+	backoff := handlerRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := h.handler(ctx, message.Value)
+		if err == nil || attempt == handlerMaxAttempts {
+			return err
+		}
+		h.log.Warnf("failed to handle message from %s[%d]@%d, attempt %d: %v", message.Topic, message.Partition, message.Offset, attempt, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,38 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/IBM/sarama"
+)
+
+type saramaKafkaProducer struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func NewKafkaProducer(producer sarama.SyncProducer, topic string) KafkaProducer {
+	return &saramaKafkaProducer{
+		producer: producer,
+		topic:    topic,
+	}
+}
+
+func (p *saramaKafkaProducer) Send(ctx context.Context, event KafkaEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: p.topic,
+		Key:   sarama.StringEncoder(event.ID()),
+		Value: sarama.ByteEncoder(body),
+	})
+	return err
+}
+
+func (p *saramaKafkaProducer) Topic() string {
+	return p.topic
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type SentMessage struct {
+	Message
+	SentAt time.Time
+}
+
+// MemoryNotifier keeps messages instead of sending them, for tests and local
+// development.
+type MemoryNotifier struct {
+	mu       sync.Mutex
+	messages []SentMessage
+}
+
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+func (n *MemoryNotifier) Notify(ctx context.Context, message *Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.messages = append(n.messages, SentMessage{Message: *message, SentAt: time.Now()})
+	return nil
+}
+
+func (n *MemoryNotifier) Messages() []SentMessage {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]SentMessage(nil), n.messages...)
+}
+
+// Last returns the most recent message sent to an address.
+func (n *MemoryNotifier) Last(to string) (SentMessage, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for i := len(n.messages) - 1; i >= 0; i-- {
+		if n.messages[i].To == to {
+			return n.messages[i], true
+		}
+	}
+	return SentMessage{}, false
+}
@@ -0,0 +1,16 @@
+// Package notify delivers email notifications through a pluggable
+// transport.
+package notify
+
+import "context"
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers a message to the email address in Message.To.
+type Notifier interface {
+	Notify(ctx context.Context, message *Message) error
+}
@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Achilles-Signature"
+	TimestampHeader = "X-Achilles-Timestamp"
+
+	webhookErrorBodyMax = 1 << 10
+)
+
+type webhookPayload struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// WebhookNotifier posts each message as JSON to a mail provider or relay.
+// When a secret is set, the request carries an HMAC-SHA256 of the timestamp
+// and body so the receiver can reject forged or replayed requests.
+type WebhookNotifier struct {
+	url        string
+	secret     []byte
+	httpClient *http.Client
+}
+
+func NewWebhookNotifier(url, secret string, httpClient *http.Client) *WebhookNotifier {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &WebhookNotifier{
+		url:        url,
+		secret:     []byte(secret),
+		httpClient: httpClient,
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, message *Message) error {
+	payload, err := json.Marshal(&webhookPayload{
+		To:      message.To,
+		Subject: message.Subject,
+		Body:    message.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(n.secret) > 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(n.secret, timestamp, payload))
+	}
+
+	res, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify: webhook: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, webhookErrorBodyMax))
+		return fmt.Errorf("notify: webhook: %s: %s", res.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// Sign computes the signature header value for a webhook request.
+func Sign(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,48 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/oidc/oidctest"
+)
+
+func TestKeySetRotation(t *testing.T) {
+	idp := oidctest.NewServer("client", "secret")
+	defer idp.Close()
+
+	provider := newTestProvider(t, idp)
+	ctx := context.Background()
+
+	before := idp.Sign(idp.Claims("subject", "nonce"))
+	if _, err := provider.VerifyIDToken(ctx, before, "nonce"); err != nil {
+		t.Fatalf("VerifyIDToken before rotation: %v", err)
+	}
+
+	idp.RotateKey()
+	after := idp.Sign(idp.Claims("subject", "nonce"))
+
+	// An unknown kid right after a fetch is not worth another round trip.
+	if _, err := provider.VerifyIDToken(ctx, after, "nonce"); err == nil {
+		t.Fatal("VerifyIDToken accepted a new key without refetching the key set")
+	}
+	if got := idp.JWKSRequests(); got != 1 {
+		t.Fatalf("jwks fetched %d times within the refresh interval, want 1", got)
+	}
+
+	provider.keySet.mu.Lock()
+	provider.keySet.lastFetched = time.Now().Add(-keySetRefreshInterval)
+	provider.keySet.mu.Unlock()
+
+	if _, err := provider.VerifyIDToken(ctx, after, "nonce"); err != nil {
+		t.Fatalf("VerifyIDToken after rotation: %v", err)
+	}
+	if got := idp.JWKSRequests(); got != 2 {
+		t.Errorf("jwks fetched %d times, want 2", got)
+	}
+
+	if _, err := provider.VerifyIDToken(ctx, before, "nonce"); err == nil {
+		t.Error("VerifyIDToken accepted a token signed with a retired key")
+	}
+}
@@ -0,0 +1,118 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hailsayan/achilles/internal/pkg/oidc/oidctest"
+)
+
+func newTestProvider(t *testing.T, idp *oidctest.Server) *Provider {
+	t.Helper()
+
+	provider, err := NewProvider(context.Background(), &ProviderConfig{
+		Name:         "test",
+		Issuer:       idp.Issuer(),
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  "https://app.example.com/callback",
+	}, idp.Client())
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	return provider
+}
+
+func TestNewProviderDiscovery(t *testing.T) {
+	idp := oidctest.NewServer("client", "secret")
+	defer idp.Close()
+
+	provider := newTestProvider(t, idp)
+
+	if provider.discovery.TokenEndpoint != idp.URL+"/token" {
+		t.Errorf("token endpoint = %q, want %q", provider.discovery.TokenEndpoint, idp.URL+"/token")
+	}
+	if provider.discovery.JwksURI != idp.URL+"/jwks" {
+		t.Errorf("jwks uri = %q, want %q", provider.discovery.JwksURI, idp.URL+"/jwks")
+	}
+
+	authURL, err := url.Parse(provider.AuthCodeURL("state", "nonce", "challenge"))
+	if err != nil {
+		t.Fatalf("parse auth code url: %v", err)
+	}
+	query := authURL.Query()
+	for param, want := range map[string]string{
+		"client_id":             "client",
+		"state":                 "state",
+		"nonce":                 "nonce",
+		"code_challenge":        "challenge",
+		"code_challenge_method": "S256",
+		"scope":                 "openid email profile",
+	} {
+		if got := query.Get(param); got != want {
+			t.Errorf("auth code url %s = %q, want %q", param, got, want)
+		}
+	}
+}
+
+func TestNewProviderRejectsIssuerMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&Discovery{Issuer: "https://attacker.example.com"})
+	}))
+	defer server.Close()
+
+	_, err := NewProvider(context.Background(), &ProviderConfig{
+		Name:   "test",
+		Issuer: server.URL,
+	}, server.Client())
+	if err == nil {
+		t.Fatal("NewProvider accepted a discovery document for another issuer")
+	}
+}
+
+func TestExchangePKCE(t *testing.T) {
+	idp := oidctest.NewServer("client", "secret")
+	defer idp.Close()
+
+	provider := newTestProvider(t, idp)
+	verifier := "a-sufficiently-long-code-verifier-for-the-test"
+
+	t.Run("matching verifier", func(t *testing.T) {
+		code := idp.Grant(CodeChallenge(verifier), idp.Claims("subject", "nonce"))
+
+		token, err := provider.Exchange(context.Background(), code, verifier)
+		if err != nil {
+			t.Fatalf("Exchange: %v", err)
+		}
+		claims, err := provider.VerifyIDToken(context.Background(), token.IDToken, "nonce")
+		if err != nil {
+			t.Fatalf("VerifyIDToken: %v", err)
+		}
+		if claims.Subject != "subject" {
+			t.Errorf("subject = %q, want %q", claims.Subject, "subject")
+		}
+	})
+
+	t.Run("wrong verifier", func(t *testing.T) {
+		code := idp.Grant(CodeChallenge(verifier), idp.Claims("subject", "nonce"))
+
+		if _, err := provider.Exchange(context.Background(), code, "another-verifier"); err == nil {
+			t.Fatal("Exchange succeeded with a verifier that does not match the challenge")
+		}
+	})
+
+	t.Run("reused code", func(t *testing.T) {
+		code := idp.Grant(CodeChallenge(verifier), idp.Claims("subject", "nonce"))
+
+		if _, err := provider.Exchange(context.Background(), code, verifier); err != nil {
+			t.Fatalf("Exchange: %v", err)
+		}
+		if _, err := provider.Exchange(context.Background(), code, verifier); err == nil {
+			t.Fatal("Exchange succeeded twice with the same code")
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package oidc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/oidc/oidctest"
+)
+
+func TestVerifyIDToken(t *testing.T) {
+	idp := oidctest.NewServer("client", "secret")
+	defer idp.Close()
+
+	provider := newTestProvider(t, idp)
+
+	tests := []struct {
+		name    string
+		mutate  func(claims map[string]any)
+		nonce   string
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			nonce: "nonce",
+		},
+		{
+			name:    "nonce mismatch",
+			nonce:   "another-nonce",
+			wantErr: "nonce mismatch",
+		},
+		{
+			name:    "missing nonce",
+			mutate:  func(claims map[string]any) { delete(claims, "nonce") },
+			nonce:   "nonce",
+			wantErr: "nonce mismatch",
+		},
+		{
+			name:    "other audience",
+			mutate:  func(claims map[string]any) { claims["aud"] = "another-client" },
+			nonce:   "nonce",
+			wantErr: "audience",
+		},
+		{
+			name:    "other issuer",
+			mutate:  func(claims map[string]any) { claims["iss"] = "https://attacker.example.com" },
+			nonce:   "nonce",
+			wantErr: "issuer",
+		},
+		{
+			name:    "expired",
+			mutate:  func(claims map[string]any) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+			nonce:   "nonce",
+			wantErr: "expired",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := idp.Claims("subject", "nonce")
+			if tt.mutate != nil {
+				tt.mutate(claims)
+			}
+
+			_, err := provider.VerifyIDToken(context.Background(), idp.Sign(claims), tt.nonce)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("VerifyIDToken: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("VerifyIDToken error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636 appendix B.
+	got := CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Errorf("CodeChallenge = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,110 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	rateKey    = "quota:rpc:%s:%d"
+	anonKey    = "quota:anon:%s:%d"
+	callsKey   = "usage:rpc_calls:%s:%s"
+	tenantsKey = "usage:tenants:%s"
+	dayLayout  = "20060102"
+
+	// UsageRetention keeps daily counters in Redis long enough for a
+	// roll-up that was down for a while to catch up.
+	UsageRetention = 8 * 24 * time.Hour
+)
+
+// Meter counts calls per tenant in Redis.
+type Meter interface {
+	// CountCall records one call by tenantID and returns how many it has
+	// made in the current minute, this one included.
+	CountCall(ctx context.Context, tenantID string, at time.Time) (int64, error)
+	// CountAnonymousCall records one call without a token from source and
+	// returns how many it has made in the current minute. Anonymous calls
+	// are not billed, so they are neither counted per day nor listed in
+	// Tenants.
+	CountAnonymousCall(ctx context.Context, source string, at time.Time) (int64, error)
+	// CallsThisMinute returns the calls tenantID has made in the minute of
+	// at.
+	CallsThisMinute(ctx context.Context, tenantID string, at time.Time) (int64, error)
+	// Calls returns the calls tenantID made on the UTC day of day.
+	Calls(ctx context.Context, tenantID string, day time.Time) (int64, error)
+	// Tenants returns the tenants that made calls on the UTC day of day.
+	Tenants(ctx context.Context, day time.Time) ([]string, error)
+}
+
+type redisMeter struct {
+	client redis.Cmdable
+}
+
+func NewRedisMeter(client redis.Cmdable) Meter {
+	return &redisMeter{
+		client: client,
+	}
+}
+
+func (m *redisMeter) CountCall(ctx context.Context, tenantID string, at time.Time) (int64, error) {
+	at = at.UTC()
+	rate := fmt.Sprintf(rateKey, tenantID, at.Unix()/60)
+	calls := fmt.Sprintf(callsKey, tenantID, at.Format(dayLayout))
+	tenants := fmt.Sprintf(tenantsKey, at.Format(dayLayout))
+
+	// The keys live in different slots, so they are written in a pipeline
+	// rather than a transaction.
+	var incr *redis.IntCmd
+	_, err := m.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, rate)
+		pipe.Expire(ctx, rate, 2*time.Minute)
+		pipe.Incr(ctx, calls)
+		pipe.Expire(ctx, calls, UsageRetention)
+		pipe.SAdd(ctx, tenants, tenantID)
+		pipe.Expire(ctx, tenants, UsageRetention)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (m *redisMeter) CountAnonymousCall(ctx context.Context, source string, at time.Time) (int64, error) {
+	key := fmt.Sprintf(anonKey, source, at.UTC().Unix()/60)
+
+	var incr *redis.IntCmd
+	_, err := m.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, 2*time.Minute)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (m *redisMeter) CallsThisMinute(ctx context.Context, tenantID string, at time.Time) (int64, error) {
+	return m.count(ctx, fmt.Sprintf(rateKey, tenantID, at.UTC().Unix()/60))
+}
+
+func (m *redisMeter) Calls(ctx context.Context, tenantID string, day time.Time) (int64, error) {
+	return m.count(ctx, fmt.Sprintf(callsKey, tenantID, day.UTC().Format(dayLayout)))
+}
+
+func (m *redisMeter) Tenants(ctx context.Context, day time.Time) ([]string, error) {
+	return m.client.SMembers(ctx, fmt.Sprintf(tenantsKey, day.UTC().Format(dayLayout))).Result()
+}
+
+func (m *redisMeter) count(ctx context.Context, key string) (int64, error) {
+	n, err := m.client.Get(ctx, key).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return n, err
+}
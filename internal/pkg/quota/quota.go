@@ -0,0 +1,46 @@
+// Package quota holds the per-tenant limits of a deployment and meters the
+// usage they are checked against.
+package quota
+
+// Metrics reported for billing. Calls are counted per day and rolled up;
+// users are a gauge sampled at each roll-up. The RPC rate is only enforced,
+// never stored.
+const (
+	MetricUsers    = "users"
+	MetricRPCCalls = "rpc_calls"
+	MetricRPCRate  = "rpc_per_minute"
+)
+
+// Limits caps what one tenant may consume. Zero leaves a limit unset.
+type Limits struct {
+	MaxUsers     int64 `mapstructure:"max_users"`
+	RPCPerMinute int64 `mapstructure:"rpc_per_minute"`
+}
+
+// Config sets the limits of every tenant. A tenant listed in Tenants gets
+// that entry in place of Default, so a plan states all of its limits.
+// Anonymous applies to each address that calls without a token.
+type Config struct {
+	Default   Limits            `mapstructure:"default"`
+	Tenants   map[string]Limits `mapstructure:"tenants"`
+	Anonymous Limits            `mapstructure:"anonymous"`
+}
+
+// For returns the limits of tenantID.
+func (c *Config) For(tenantID string) Limits {
+	if c == nil {
+		return Limits{}
+	}
+	if limits, ok := c.Tenants[tenantID]; ok {
+		return limits
+	}
+	return c.Default
+}
+
+// ForAnonymous returns the limits of each anonymous caller.
+func (c *Config) ForAnonymous() Limits {
+	if c == nil {
+		return Limits{}
+	}
+	return c.Anonymous
+}
@@ -0,0 +1,9 @@
+// Package sms delivers text messages through a pluggable gateway.
+package sms
+
+import "context"
+
+// SMSSender delivers body to a phone number in E.164 form.
+type SMSSender interface {
+	Send(ctx context.Context, to, body string) error
+}
@@ -0,0 +1,86 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Achilles-Signature"
+	TimestampHeader = "X-Achilles-Timestamp"
+
+	webhookErrorBodyMax = 1 << 10
+)
+
+type webhookPayload struct {
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+// WebhookSender posts each message as JSON to a provider or relay. When a
+// secret is set, the request carries an HMAC-SHA256 of the timestamp and
+// body so the receiver can reject forged or replayed requests.
+type WebhookSender struct {
+	url        string
+	secret     []byte
+	httpClient *http.Client
+}
+
+func NewWebhookSender(url, secret string, httpClient *http.Client) *WebhookSender {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &WebhookSender{
+		url:        url,
+		secret:     []byte(secret),
+		httpClient: httpClient,
+	}
+}
+
+func (s *WebhookSender) Send(ctx context.Context, to, body string) error {
+	payload, err := json.Marshal(&webhookPayload{To: to, Body: body})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.secret) > 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(s.secret, timestamp, payload))
+	}
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms: webhook: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, webhookErrorBodyMax))
+		return fmt.Errorf("sms: webhook: %s: %s", res.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// Sign computes the signature header value for a webhook request.
+func Sign(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
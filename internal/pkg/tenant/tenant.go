@@ -0,0 +1,43 @@
+// Package tenant carries the tenant a request acts in through its context.
+// Every service scopes its database access to that tenant.
+package tenant
+
+import (
+	"context"
+	"regexp"
+)
+
+const (
+	// DefaultID serves callers that name no tenant, which keeps a
+	// single-customer deployment working unchanged.
+	DefaultID = "default"
+
+	// All scopes a context to every tenant, for reads only. Background jobs
+	// that sweep the whole deployment use it; it is never accepted from a
+	// request.
+	All = "*"
+)
+
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+type contextKey struct{}
+
+// WithTenant returns a context acting in tenantID. An empty ID means
+// DefaultID.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantID)
+}
+
+// FromContext returns the tenant the context acts in.
+func FromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(contextKey{}).(string)
+	if tenantID == "" {
+		return DefaultID
+	}
+	return tenantID
+}
+
+// Valid reports whether id may name a tenant. All is not valid.
+func Valid(id string) bool {
+	return idPattern.MatchString(id)
+}
@@ -0,0 +1,226 @@
+// Package imageutils decodes uploaded images and renders square thumbnails.
+// Thumbnails are re-encoded from pixels alone, so EXIF and any other
+// metadata in the upload never reaches them.
+package imageutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeGIF  = "image/gif"
+
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("imageutils: unsupported image format")
+	ErrTooLarge          = errors.New("imageutils: image dimensions too large")
+)
+
+var supportedContentTypes = map[string]struct{}{
+	ContentTypeJPEG: {},
+	ContentTypePNG:  {},
+	ContentTypeGIF:  {},
+}
+
+// Sniff returns the content type of data judged by its leading bytes,
+// ignoring whatever the client claimed.
+func Sniff(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := supportedContentTypes[contentType]; !ok {
+		return "", ErrUnsupportedFormat
+	}
+	return contentType, nil
+}
+
+// Decode reads the header first and refuses images above maxPixels before
+// allocating them, so a small file cannot expand into a huge bitmap.
+func Decode(data []byte, maxPixels int) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width > maxPixels/config.Height {
+		return nil, ErrTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	return img, nil
+}
+
+// Thumbnail crops the centre square of img and scales it to size by box
+// sampling, then applies the EXIF orientation of the source JPEG, if any.
+// Cropping the centre commutes with the orientation transforms, so rotating
+// the small result is equivalent to rotating the source.
+func Thumbnail(img image.Image, size int, source []byte) *image.NRGBA {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	src := image.NewNRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(src, src.Bounds(), img, crop.Min, draw.Src)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := y*side/size, max((y+1)*side/size, y*side/size+1)
+		for x := 0; x < size; x++ {
+			x0, x1 := x*side/size, max((x+1)*side/size, x*side/size+1)
+			dst.SetNRGBA(x, y, average(src, x0, y0, x1, y1))
+		}
+	}
+
+	return orient(dst, jpegOrientation(source))
+}
+
+// average blends premultiplied values so transparent pixels do not darken
+// the edges of opaque ones.
+func average(img *image.NRGBA, x0, y0, x1, y1 int) color.NRGBA {
+	var r, g, b, a, n uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			c := img.NRGBAAt(x, y)
+			alpha := uint64(c.A)
+			r += uint64(c.R) * alpha
+			g += uint64(c.G) * alpha
+			b += uint64(c.B) * alpha
+			a += alpha
+			n++
+		}
+	}
+	if a == 0 {
+		return color.NRGBA{}
+	}
+	return color.NRGBA{
+		R: uint8(r / a),
+		G: uint8(g / a),
+		B: uint8(b / a),
+		A: uint8(a / n),
+	}
+}
+
+// Encode writes PNG when img has transparency and JPEG otherwise.
+func Encode(img *image.NRGBA) ([]byte, string, error) {
+	buf := new(bytes.Buffer)
+	if !img.Opaque() {
+		if err := png.Encode(buf, img); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), ContentTypePNG, nil
+	}
+
+	if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), ContentTypeJPEG, nil
+}
+
+// orient applies one of the eight EXIF orientations to a square image.
+func orient(img *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	n := img.Bounds().Dx()
+	out := image.NewNRGBA(img.Bounds())
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = n-1-x, y
+			case 3:
+				sx, sy = n-1-x, n-1-y
+			case 4:
+				sx, sy = x, n-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, n-1-x
+			case 7:
+				sx, sy = n-1-y, n-1-x
+			case 8:
+				sx, sy = n-1-y, x
+			}
+			out.SetNRGBA(x, y, img.NRGBAAt(sx, sy))
+		}
+	}
+	return out
+}
+
+// jpegOrientation returns the EXIF orientation tag of a JPEG, or 0 when
+// data is not a JPEG or carries none.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 0
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return 0
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 0
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 0
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 0
+}
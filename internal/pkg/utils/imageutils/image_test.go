@@ -0,0 +1,196 @@
+package imageutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func solid(width, height int, c color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := jpeg.Encode(buf, img, nil); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeGIF(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := gif.Encode(buf, img, nil); err != nil {
+		t.Fatalf("gif.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withExif inserts an APP1 segment after the SOI marker of a JPEG. The
+// segment carries the orientation tag and, after the IFD, extra.
+func withExif(data []byte, orientation uint16, extra string) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0)
+	tiff = binary.BigEndian.AppendUint32(tiff, 0)
+	tiff = append(tiff, extra...)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, app1...)
+	return append(out, data[2:]...)
+}
+
+// withPNGSize rewrites the dimensions in a PNG's IHDR chunk and fixes its
+// checksum, leaving the pixel data as it was.
+func withPNGSize(data []byte, width, height uint32) []byte {
+	out := append([]byte{}, data...)
+	ihdr := out[8+8 : 8+8+13]
+	binary.BigEndian.PutUint32(ihdr[0:], width)
+	binary.BigEndian.PutUint32(ihdr[4:], height)
+	binary.BigEndian.PutUint32(out[8+8+13:], crc32.ChecksumIEEE(out[8+4:8+8+13]))
+	return out
+}
+
+func TestSniff(t *testing.T) {
+	img := solid(4, 4, color.White)
+	tests := map[string]struct {
+		data    []byte
+		want    string
+		wantErr bool
+	}{
+		"png":   {data: encodePNG(t, img), want: ContentTypePNG},
+		"jpeg":  {data: encodeJPEG(t, img), want: ContentTypeJPEG},
+		"gif":   {data: encodeGIF(t, img), want: ContentTypeGIF},
+		"svg":   {data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), wantErr: true},
+		"html":  {data: []byte("<html><script>alert(1)</script></html>"), wantErr: true},
+		"empty": {data: nil, wantErr: true},
+		// A PNG signature in the middle does not make a PNG.
+		"png later": {data: append([]byte("hello"), encodePNG(t, img)...), wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Sniff(tt.data)
+			if tt.wantErr {
+				if err != ErrUnsupportedFormat {
+					t.Fatalf("Sniff = %q, %v, want ErrUnsupportedFormat", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("Sniff = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodePixelLimit(t *testing.T) {
+	data := encodePNG(t, solid(100, 50, color.White))
+
+	if _, err := Decode(data, 5000); err != nil {
+		t.Errorf("Decode at the limit: %v", err)
+	}
+	if _, err := Decode(data, 4999); err != ErrTooLarge {
+		t.Errorf("Decode over the limit: %v, want ErrTooLarge", err)
+	}
+
+	// The header alone is enough to refuse an image that would expand into
+	// gigabytes, and the product of its sides must not overflow.
+	for _, size := range [][2]uint32{{50000, 50000}, {1 << 31, 2}, {0, 10}} {
+		if _, err := Decode(withPNGSize(data, size[0], size[1]), 1<<24); err == nil {
+			t.Errorf("Decode of a %dx%d header succeeded, want error", size[0], size[1])
+		}
+	}
+
+	if _, err := Decode([]byte("not an image"), 1<<24); err != ErrUnsupportedFormat {
+		t.Errorf("Decode of garbage: %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+func TestThumbnailDropsExifAndAppliesOrientation(t *testing.T) {
+	// Left half red, right half blue.
+	src := solid(64, 64, color.NRGBA{B: 255, A: 255})
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 32; x++ {
+			src.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	const secret = "GPS 51.5007N 0.1246W"
+	upload := withExif(encodeJPEG(t, src), 6, secret)
+	if jpegOrientation(upload) != 6 {
+		t.Fatalf("test upload orientation = %d, want 6", jpegOrientation(upload))
+	}
+
+	img, err := Decode(upload, 1<<20)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	data, contentType, err := Encode(Thumbnail(img, 16, upload))
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if contentType != ContentTypeJPEG {
+		t.Errorf("content type = %q, want %q for an opaque image", contentType, ContentTypeJPEG)
+	}
+	if bytes.Contains(data, []byte("Exif")) || bytes.Contains(data, []byte(secret)) {
+		t.Error("thumbnail still carries the upload's EXIF data")
+	}
+
+	thumbnail, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode thumbnail: %v", err)
+	}
+	// Orientation 6 turns the image a quarter clockwise, so the red left
+	// half ends up on top.
+	isRed := func(c color.Color) bool {
+		r, g, b, _ := c.RGBA()
+		return r > 0xC000 && g < 0x4000 && b < 0x4000
+	}
+	if top, bottom := thumbnail.At(8, 1), thumbnail.At(8, 14); !isRed(top) || isRed(bottom) {
+		t.Errorf("thumbnail top = %v, bottom = %v, want red above blue", top, bottom)
+	}
+}
+
+func TestEncodeKeepsTransparencyAsPNG(t *testing.T) {
+	data, contentType, err := Encode(Thumbnail(solid(8, 8, color.NRGBA{}), 4, nil))
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if contentType != ContentTypePNG {
+		t.Errorf("content type = %q, want %q", contentType, ContentTypePNG)
+	}
+	if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+		t.Errorf("decode thumbnail: %v", err)
+	}
+}
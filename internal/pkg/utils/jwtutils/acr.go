@@ -0,0 +1,32 @@
+package jwtutils
+
+const (
+	ACRPassword    = "aal1"
+	ACRMultiFactor = "aal2"
+
+	AMRPassword  = "pwd"
+	AMROTP       = "otp"
+	AMRFederated = "fed"
+	AMRSMS       = "sms"
+)
+
+var acrLevels = map[string]int{
+	ACRPassword:    1,
+	ACRMultiFactor: 2,
+}
+
+// ACRForAMR derives the assurance level from the methods used to
+// authenticate: any one-time password on top of the session, whether from
+// an authenticator app or a text message, counts as multi-factor.
+func ACRForAMR(amr []string) string {
+	for _, method := range amr {
+		if method == AMROTP || method == AMRSMS {
+			return ACRMultiFactor
+		}
+	}
+	return ACRPassword
+}
+
+func ACRSatisfies(actual, required string) bool {
+	return acrLevels[actual] >= acrLevels[required]
+}
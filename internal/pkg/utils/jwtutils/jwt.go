@@ -17,17 +17,65 @@ type JwtConfig struct {
 }
 
 type JwtUtil interface {
-	GenerateAccessToken(userID, email string) (string, time.Time, error)
-	GenerateRefreshToken(userID string) (string, error)
+	GenerateAccessToken(userID, email string, opts *AccessTokenOptions) (string, time.Time, error)
+	GenerateImpersonationToken(userID, actorID, tenantID string, duration time.Duration) (string, time.Time, error)
+	GenerateRefreshToken(userID, tenantID string) (string, error)
+	GenerateServiceToken(service string, duration time.Duration) (string, time.Time, error)
 	ValidateToken(token string) (*JWTClaims, error)
 	GetTokenExpiration() time.Time
+	GetRefreshTokenDuration() time.Duration
 }
 
 type JWTClaims struct {
 	jwt.RegisteredClaims
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TokenType string `json:"token_type"`
+	UserID      string           `json:"user_id"`
+	Username    string           `json:"username"`
+	TokenType   string           `json:"token_type"`
+	Permissions []string         `json:"permissions,omitempty"`
+	Actor       *ActorClaim      `json:"act,omitempty"`
+	ACR         string           `json:"acr,omitempty"`
+	AMR         []string         `json:"amr,omitempty"`
+	AuthTime    *jwt.NumericDate `json:"auth_time,omitempty"`
+	OrgID       string           `json:"org_id,omitempty"`
+	OrgRole     string           `json:"org_role,omitempty"`
+	TenantID    string           `json:"tenant_id,omitempty"`
+}
+
+type AccessTokenOptions struct {
+	Permissions []string
+	AMR         []string
+	AuthTime    time.Time
+
+	// OrgID and OrgRole name the organization the token acts in, if any.
+	OrgID   string
+	OrgRole string
+
+	TenantID string
+}
+
+// ActorClaim follows RFC 8693: it names the party acting on behalf of the
+// subject when the token was issued through impersonation.
+type ActorClaim struct {
+	Subject string `json:"sub"`
+}
+
+func (c *JWTClaims) IsImpersonation() bool {
+	return c.Actor != nil && c.Actor.Subject != ""
+}
+
+// IsService reports whether the token identifies a service calling on its
+// own behalf rather than a user.
+func (c *JWTClaims) IsService() bool {
+	return c.TokenType == "service"
+}
+
+func (c *JWTClaims) HasPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
 }
 
 type jwtUtil struct {
@@ -43,14 +91,29 @@ func NewJwtUtil(config *JwtConfig) JwtUtil {
 	}
 }
 
-func (j *jwtUtil) GenerateAccessToken(userID, username string) (string, time.Time, error) {
+func (j *jwtUtil) GenerateAccessToken(userID, username string, opts *AccessTokenOptions) (string, time.Time, error) {
 	currentTime := time.Now()
 	expirationTime := currentTime.Add(time.Duration(j.config.AccessTokenDuration) * time.Minute)
+
+	if opts == nil {
+		opts = &AccessTokenOptions{}
+	}
+	authTime := opts.AuthTime
+	if authTime.IsZero() {
+		authTime = currentTime
+	}
 	
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{
 		UserID:   userID,
 		Username: username,
 		TokenType: "access",
+		Permissions: opts.Permissions,
+		ACR: ACRForAMR(opts.AMR),
+		AMR: opts.AMR,
+		AuthTime: jwt.NewNumericDate(authTime),
+		OrgID: opts.OrgID,
+		OrgRole: opts.OrgRole,
+		TenantID: opts.TenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        uuid.NewString(),
 			IssuedAt:  jwt.NewNumericDate(currentTime),
@@ -67,13 +130,40 @@ func (j *jwtUtil) GenerateAccessToken(userID, username string) (string, time.Tim
 	return signedToken, expirationTime, nil
 }
 
-func (j *jwtUtil) GenerateRefreshToken(userID string) (string, error) {
+func (j *jwtUtil) GenerateImpersonationToken(userID, actorID, tenantID string, duration time.Duration) (string, time.Time, error) {
+	currentTime := time.Now()
+	expirationTime := currentTime.Add(duration)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{
+		UserID:    userID,
+		TokenType: "access",
+		Actor:     &ActorClaim{Subject: actorID},
+		TenantID:  tenantID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(currentTime),
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			Issuer:    j.config.Issuer,
+		},
+	})
+
+	signedToken, err := token.SignedString([]byte(j.config.SecretKey))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signedToken, expirationTime, nil
+}
+
+func (j *jwtUtil) GenerateRefreshToken(userID, tenantID string) (string, error) {
 	currentTime := time.Now()
 	expirationTime := currentTime.Add(time.Duration(j.config.RefreshTokenDuration) * time.Minute)
 	
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{
 		UserID:   userID,
 		TokenType: "refresh",
+		TenantID: tenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        uuid.NewString(),
 			IssuedAt:  jwt.NewNumericDate(currentTime),
@@ -90,6 +180,31 @@ func (j *jwtUtil) GenerateRefreshToken(userID string) (string, error) {
 	return signedToken, nil
 }
 
+// GenerateServiceToken issues a token naming service as its subject. It
+// carries no user, tenant or permissions.
+func (j *jwtUtil) GenerateServiceToken(service string, duration time.Duration) (string, time.Time, error) {
+	currentTime := time.Now()
+	expirationTime := currentTime.Add(duration)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{
+		TokenType: "service",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   service,
+			IssuedAt:  jwt.NewNumericDate(currentTime),
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			Issuer:    j.config.Issuer,
+		},
+	})
+
+	signedToken, err := token.SignedString([]byte(j.config.SecretKey))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signedToken, expirationTime, nil
+}
+
 func (j *jwtUtil) ValidateToken(tokenString string) (*JWTClaims, error) {
 	parser := jwt.NewParser(
 		jwt.WithValidMethods(j.config.AllowedAlgs),
@@ -114,4 +229,8 @@ func (j *jwtUtil) ValidateToken(tokenString string) (*JWTClaims, error) {
 
 func (j *jwtUtil) GetTokenExpiration() time.Time {
 	return time.Now().Add(time.Duration(j.config.AccessTokenDuration) * time.Minute)
+}
+
+func (j *jwtUtil) GetRefreshTokenDuration() time.Duration {
+	return time.Duration(j.config.RefreshTokenDuration) * time.Minute
 }
\ No newline at end of file
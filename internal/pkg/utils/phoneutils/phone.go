@@ -0,0 +1,48 @@
+package phoneutils
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	minDigits = 8
+	maxDigits = 15
+)
+
+var ErrInvalidPhone = errors.New("phone number must be in international format, e.g. +14155550123")
+
+// NormalizeE164 turns an international phone number into E.164: a plus sign
+// followed by up to 15 digits, the first of which is the country code.
+// Spaces, dots, dashes and parentheses are dropped, and a leading 00 is read
+// as the international prefix. National numbers are rejected because their
+// country cannot be known.
+func NormalizeE164(raw string) (string, error) {
+	number := strings.TrimSpace(raw)
+	switch {
+	case strings.HasPrefix(number, "+"):
+		number = number[1:]
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	default:
+		return "", ErrInvalidPhone
+	}
+
+	digits := make([]byte, 0, maxDigits)
+	for i := 0; i < len(number); i++ {
+		c := number[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == ' ' || c == '.' || c == '-' || c == '(' || c == ')':
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+
+	if len(digits) < minDigits || len(digits) > maxDigits || digits[0] == '0' {
+		return "", ErrInvalidPhone
+	}
+
+	return "+" + string(digits), nil
+}
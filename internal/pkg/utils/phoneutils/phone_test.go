@@ -0,0 +1,50 @@
+package phoneutils
+
+import "testing"
+
+func TestNormalizeE164(t *testing.T) {
+	tests := map[string]struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		"e164":                  {raw: "+14155550123", want: "+14155550123"},
+		"surrounding space":     {raw: "  +14155550123 ", want: "+14155550123"},
+		"separators":            {raw: "+1 (415) 555-0123", want: "+14155550123"},
+		"dots":                  {raw: "+44.20.7946.0958", want: "+442079460958"},
+		"00 prefix":             {raw: "0044 20 7946 0958", want: "+442079460958"},
+		"00 prefix e164 length": {raw: "00861234567890123", want: "+861234567890123"},
+		"shortest":              {raw: "+12345678", want: "+12345678"},
+		"longest":               {raw: "+123456789012345", want: "+123456789012345"},
+		"national":              {raw: "4155550123", wantErr: true},
+		"national trunk 0":      {raw: "020 7946 0958", wantErr: true},
+		"single 0 prefix":       {raw: "044 20 7946 0958", wantErr: true},
+		"000 prefix":            {raw: "00044 20 7946 0958", wantErr: true},
+		"plus and 00":           {raw: "+0044 20 7946 0958", wantErr: true},
+		"country code 0":        {raw: "+04155550123", wantErr: true},
+		"too short":             {raw: "+1234567", wantErr: true},
+		"too long":              {raw: "+1234567890123456", wantErr: true},
+		"00 too long":           {raw: "001234567890123456", wantErr: true},
+		"letters":               {raw: "+1415CALLNOW", wantErr: true},
+		"extension":             {raw: "+14155550123 x12", wantErr: true},
+		"second plus":           {raw: "++14155550123", wantErr: true},
+		"slash":                 {raw: "+1415/5550123", wantErr: true},
+		"non-ascii digits":      {raw: "+١٤١٥٥٥٥٠١٢٣", wantErr: true},
+		"empty":                 {raw: "", wantErr: true},
+		"plus only":             {raw: "+", wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeE164(tt.raw)
+			if tt.wantErr {
+				if err != ErrInvalidPhone {
+					t.Fatalf("NormalizeE164(%q) = %q, %v, want ErrInvalidPhone", tt.raw, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("NormalizeE164(%q) = %q, %v, want %q", tt.raw, got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package factory
+
+import (
+	"database/sql"
+
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/mq"
+	"github.com/hailsayan/achilles/internal/pkg/oidc"
+	"github.com/hailsayan/achilles/internal/pkg/utils/encryptutils"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/client"
+	"github.com/hailsayan/achilles/internal/svc/auth/handler"
+	"github.com/hailsayan/achilles/internal/svc/auth/repository"
+	"github.com/hailsayan/achilles/internal/svc/auth/usecase"
+	"github.com/redis/go-redis/v9"
+)
+
+type AuthServiceFactory struct {
+	db              *sql.DB
+	rdb             *redis.ClusterClient
+	jwtUtil         jwtutils.JwtUtil
+	hasher          encryptutils.Hasher
+	userClient      client.UserClient
+	providers       []*oidc.Provider
+	verificationURI string
+	erasureProducer mq.KafkaProducer
+	invitations     usecase.InvitationConfig
+
+	dataStore repository.DataStore
+
+	authUseCase usecase.AuthUseCase
+
+	authHandler *handler.AuthHandler
+}
+
+func NewAuthServiceFactory(
+	db *sql.DB,
+	rdb *redis.ClusterClient,
+	jwtUtil jwtutils.JwtUtil,
+	hasher encryptutils.Hasher,
+	userClient client.UserClient,
+	providers []*oidc.Provider,
+	verificationURI string,
+	erasureProducer mq.KafkaProducer,
+	invitations usecase.InvitationConfig,
+) (*AuthServiceFactory, error) {
+	factory := &AuthServiceFactory{
+		db:              db,
+		rdb:             rdb,
+		jwtUtil:         jwtUtil,
+		hasher:          hasher,
+		userClient:      userClient,
+		providers:       providers,
+		verificationURI: verificationURI,
+		erasureProducer: erasureProducer,
+		invitations:     invitations,
+	}
+
+	factory.initRepositories()
+	if err := factory.initUseCases(); err != nil {
+		return nil, err
+	}
+	factory.initHandlers()
+
+	return factory, nil
+}
+
+func (f *AuthServiceFactory) initRepositories() {
+	f.dataStore = repository.NewDataStore(f.db, f.rdb)
+}
+
+func (f *AuthServiceFactory) initUseCases() error {
+	authUseCase, err := usecase.NewAuthUseCase(f.dataStore, f.jwtUtil, f.hasher, f.userClient, f.providers, f.verificationURI, f.erasureProducer, f.invitations)
+	if err != nil {
+		return err
+	}
+	f.authUseCase = authUseCase
+	return nil
+}
+
+func (f *AuthServiceFactory) initHandlers() {
+	f.authHandler = handler.NewAuthHandler(f.authUseCase)
+}
+
+func (f *AuthServiceFactory) GetDataStore() repository.DataStore {
+	return f.dataStore
+}
+
+func (f *AuthServiceFactory) GetAuditRecorder() audit.Recorder {
+	return repository.NewAuditRecorder(f.dataStore)
+}
+
+func (f *AuthServiceFactory) GetAccountChecker() interceptor.AccountChecker {
+	return repository.NewAccountChecker(f.dataStore)
+}
+
+func (f *AuthServiceFactory) GetAuthUseCase() usecase.AuthUseCase {
+	return f.authUseCase
+}
+
+func (f *AuthServiceFactory) GetAuthHandler() *handler.AuthHandler {
+	return f.authHandler
+}
+
+func (f *AuthServiceFactory) GetUserStatusChangedHandler() mq.KafkaHandler {
+	return handler.NewUserStatusChangedHandler(f.authUseCase)
+}
+
+func (f *AuthServiceFactory) GetUserLifecycleHandler() mq.KafkaHandler {
+	return handler.NewUserLifecycleHandler(f.authUseCase)
+}
+
+func (f *AuthServiceFactory) GetUserErasureRequestedHandler() mq.KafkaHandler {
+	return handler.NewUserErasureRequestedHandler(f.authUseCase)
+}
+
+func (f *AuthServiceFactory) Close() error {
+	if f.db != nil {
+		return f.db.Close()
+	}
+	return nil
+}
+
+func (f *AuthServiceFactory) HealthCheck() error {
+	if err := f.db.Ping(); err != nil {
+		return err
+	}
+	return nil
+}
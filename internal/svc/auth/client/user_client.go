@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	userpb "github.com/hailsayan/achilles/internal/svc/user/pb/user"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type User struct {
+	ID        string
+	Email     string
+	FirstName string
+	LastName  string
+	Status    string
+	Username  string
+}
+
+type Membership struct {
+	OrganizationID string
+	UserID         string
+	Role           string
+}
+
+type UserClient interface {
+	GetUserByID(ctx context.Context, userID string) (*User, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	GetUserByUsername(ctx context.Context, username string) (*User, error)
+	CreateUser(ctx context.Context, email, firstName, lastName string) (*User, error)
+	ConfirmPhoneCode(ctx context.Context, userID, purpose, code string) (bool, error)
+	GetMembership(ctx context.Context, organizationID, userID string) (*Membership, error)
+}
+
+type userClientImpl struct {
+	client             userpb.UserServiceClient
+	organizationClient userpb.OrganizationServiceClient
+}
+
+// NewUserClient returns a client whose calls act in the caller's tenant. Calls
+// that do not forward the caller's token authenticate with credentials.
+func NewUserClient(conn grpc.ClientConnInterface, credentials *interceptor.ServiceCredentials) UserClient {
+	conn = interceptor.TenantConn(interceptor.ServiceConn(conn, credentials))
+	return &userClientImpl{
+		client:             userpb.NewUserServiceClient(conn),
+		organizationClient: userpb.NewOrganizationServiceClient(conn),
+	}
+}
+
+func (c *userClientImpl) GetUserByID(ctx context.Context, userID string) (*User, error) {
+	res, err := c.client.GetUserByID(ctx, &userpb.GetUserRequest{UserId: userID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toUser(res), nil
+}
+
+func (c *userClientImpl) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	res, err := c.client.GetUserByEmail(ctx, &userpb.GetUserByEmailRequest{Email: email})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toUser(res), nil
+}
+
+func (c *userClientImpl) GetUserByUsername(ctx context.Context, username string) (*User, error) {
+	res, err := c.client.GetUserByUsername(ctx, &userpb.GetUserByUsernameRequest{Username: username})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toUser(res), nil
+}
+
+func (c *userClientImpl) CreateUser(ctx context.Context, email, firstName, lastName string) (*User, error) {
+	res, err := c.client.CreateUser(ctx, &userpb.CreateUserRequest{
+		Email:     email,
+		FirstName: firstName,
+		LastName:  lastName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toUser(res), nil
+}
+
+// ConfirmPhoneCode redeems a code texted by the user service. A wrong or
+// expired code reports false; other failures, such as exhausted attempts,
+// are returned as errors.
+func (c *userClientImpl) ConfirmPhoneCode(ctx context.Context, userID, purpose, code string) (bool, error) {
+	res, err := c.client.ConfirmPhoneVerification(ctx, &userpb.ConfirmPhoneVerificationRequest{
+		UserId:  userID,
+		Purpose: purpose,
+		Code:    code,
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return false, nil
+		}
+		return false, err
+	}
+	return res.Verified, nil
+}
+
+// GetMembership returns nil if the user is not a member of the organization,
+// or if the caller may not see it.
+func (c *userClientImpl) GetMembership(ctx context.Context, organizationID, userID string) (*Membership, error) {
+	res, err := c.organizationClient.GetMembership(ctx, &userpb.GetMembershipRequest{
+		OrganizationId: organizationID,
+		UserId:         userID,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &Membership{
+		OrganizationID: res.OrganizationId,
+		UserID:         res.UserId,
+		Role:           res.Role,
+	}, nil
+}
+
+func toUser(res *userpb.UserResponse) *User {
+	return &User{
+		ID:        res.Id,
+		Email:     res.Email,
+		FirstName: res.FirstName,
+		LastName:  res.LastName,
+		Status:    res.Status,
+		Username:  res.Username,
+	}
+}
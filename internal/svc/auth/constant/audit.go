@@ -0,0 +1,17 @@
+package constant
+
+const (
+	AuditOperationImpersonate    = "impersonate"
+	AuditOperationLogin          = "login"
+	AuditOperationLogout         = "logout"
+	AuditOperationChangePassword = "change_password"
+	AuditOperationRecoverAccount = "recover_account"
+
+	AuditOperationCreateInvitation = "create_invitation"
+	AuditOperationRevokeInvitation = "revoke_invitation"
+	AuditOperationAcceptInvitation = "accept_invitation"
+)
+
+// AuditRedactedAuthFields are recorded as changed in audit diffs without
+// their values.
+var AuditRedactedAuthFields = []string{"hashed_password"}
@@ -0,0 +1,13 @@
+package constant
+
+const (
+	UserCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+	UserCodeLength   = 8
+	DeviceCodeBytes  = 32
+
+	FederatedTokenBytes = 32
+
+	DeviceStatusPending  = "pending"
+	DeviceStatusApproved = "approved"
+	DeviceStatusDenied   = "denied"
+)
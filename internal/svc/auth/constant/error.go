@@ -0,0 +1,40 @@
+package constant
+
+const (
+	InternalServerErrorMessage = "internal server error"
+	InvalidClientErrorMessage  = "invalid client"
+	InvalidUserCodeMessage     = "invalid or expired user code"
+	AuthorizationPendingError  = "authorization_pending"
+	SlowDownError              = "slow_down"
+	AccessDeniedError          = "access_denied"
+	ExpiredTokenError          = "expired_token"
+	UnknownProviderMessage     = "unknown identity provider"
+	InvalidStateMessage        = "invalid or expired login state"
+	IdentityTokenInvalid       = "identity token is invalid"
+	EmailNotVerifiedMessage    = "email is not verified by identity provider"
+	IdentityNotFoundMessage    = "identity not found"
+	LastCredentialMessage      = "cannot unlink the only sign-in method"
+	UnauthenticatedMessage     = "authentication required"
+	UserNotFoundErrorMessage   = "user not found"
+	ImpersonationNotAllowed    = "impersonation of this user is not allowed"
+	ReasonRequiredMessage      = "impersonation reason is required"
+	InvalidCredentialsMessage  = "invalid credentials"
+	CredentialRequiredMessage  = "password, totp code or sms code is required"
+	TOTPNotEnrolledMessage     = "totp is not enrolled for this user"
+	ImpersonationStepUpMessage = "impersonation tokens cannot be elevated"
+	ReauthLockedMessage        = "too many failed attempts, try again later"
+	AccountInactiveMessage     = "account is %s"
+	PermissionDeniedMessage    = "permission denied"
+	InvalidRefreshTokenMessage = "invalid refresh token"
+	InvalidPageTokenMessage    = "invalid page token"
+	OrganizationNotFound       = "organization not found"
+	ImpersonationSwitchMessage = "impersonation tokens cannot switch organization"
+
+	InvitationNotFoundMessage     = "invitation not found"
+	InvitationExistsMessage       = "an invitation is already pending for this email"
+	InvitationClosedMessage       = "invitation is no longer valid"
+	InvalidInvitationTokenMessage = "invalid invitation token"
+	UserExistsMessage             = "an account already exists for this email"
+	InvalidEmailMessage           = "invalid email address"
+	PermissionNotGrantableMessage = "cannot grant permission %s"
+)
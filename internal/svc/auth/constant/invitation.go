@@ -0,0 +1,19 @@
+package constant
+
+import "time"
+
+const (
+	DefaultInvitationTTL = time.Hour * 24 * 7
+	// MinInvitationSecretBytes is the shortest secret invitation links may be
+	// signed with.
+	MinInvitationSecretBytes = 32
+
+	InvitationStatePending  = "pending"
+	InvitationStateAccepted = "accepted"
+	InvitationStateRevoked  = "revoked"
+	InvitationStateExpired  = "expired"
+
+	InvitationEmailSubject = "You have been invited"
+	// InvitationEmailBody is filled with the accept link and the expiry.
+	InvitationEmailBody = "You have been invited to create an account.\n\nAccept the invitation here: %s\n\nThe link expires on %s."
+)
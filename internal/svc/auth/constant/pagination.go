@@ -0,0 +1,9 @@
+package constant
+
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 200
+
+	DefaultInvitationPageSize = 50
+	MaxInvitationPageSize     = 200
+)
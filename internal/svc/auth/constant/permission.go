@@ -0,0 +1,12 @@
+package constant
+
+import "time"
+
+const (
+	PermissionImpersonate    = "users:impersonate"
+	PermissionExportUserData = "users:export_data"
+	PermissionReadAudit      = "audit:read"
+	PermissionInviteUsers    = "users:invite"
+
+	ImpersonationTokenTTL = time.Minute * 15
+)
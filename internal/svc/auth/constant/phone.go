@@ -0,0 +1,7 @@
+package constant
+
+// Purposes of the phone codes the user service texts on behalf of auth.
+const (
+	PhonePurposeSecondFactor = "second_factor"
+	PhonePurposeRecovery     = "recovery"
+)
@@ -0,0 +1,22 @@
+package constant
+
+import "time"
+
+const (
+	DeviceCodePrefix      = "device_code:%s"
+	DeviceUserCodePrefix  = "device_user_code:%s"
+	DeviceCodeTTL         = time.Minute * 10
+	DevicePollInterval    = time.Second * 5
+	DeviceSlowDownBackoff = time.Second * 5
+	FederatedStatePrefix  = "oidc_state:%s"
+	FederatedStateTTL     = time.Minute * 10
+	// Reauthenticate locks a user out for ReauthLockoutDuration once they
+	// have made ReauthMaxAttempts attempts within it without succeeding.
+	ReauthAttemptsPrefix  = "reauth_attempts:%s"
+	ReauthMaxAttempts     = 5
+	ReauthLockoutDuration = time.Minute * 15
+	// A TOTP code is accepted for a step either side of its own, so a used
+	// step is remembered for three steps.
+	TOTPStepPrefix = "totp_step:%s:%d"
+	TOTPStepTTL    = time.Second * 90
+)
@@ -0,0 +1,6 @@
+package constant
+
+const (
+	UserStatusActive  = "active"
+	UserStatusDeleted = "deleted"
+)
@@ -0,0 +1,10 @@
+package constant
+
+const (
+	DeviceApprovedSuccessfully   = "device approved successfully"
+	DeviceDeniedSuccessfully     = "device denied successfully"
+	IdentityUnlinkedSuccessfully = "identity unlinked successfully"
+	LoggedOutSuccessfully        = "logged out successfully"
+	PasswordChangedSuccessfully  = "password changed successfully"
+	AccountRecoveredSuccessfully = "account recovered successfully"
+)
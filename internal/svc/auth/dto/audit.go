@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+)
+
+type ListAuditEventsRequest struct {
+	PageSize  int        `json:"page_size" validate:"omitempty,min=1"`
+	PageToken string     `json:"page_token"`
+	ActorID   string     `json:"actor_id"`
+	TargetID  string     `json:"target_id"`
+	Operation string     `json:"operation"`
+	From      *time.Time `json:"from,omitempty"`
+	To        *time.Time `json:"to,omitempty"`
+}
+
+type ListAuditEventsResponse struct {
+	Events        []*audit.Event `json:"events"`
+	NextPageToken string         `json:"next_page_token"`
+}
@@ -1,7 +1,10 @@
 package dto
 
+// LoginRequest identifies the user by Username when it is set, otherwise
+// by Email, which may also hold a username.
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
+	Email    string `json:"email" validate:"required_without=Username"`
+	Username string `json:"username" validate:"required_without=Email"`
 	Password string `json:"password" validate:"required,min=6"`
 }
 
@@ -62,4 +65,15 @@ type ChangePasswordRequest struct {
 type ChangePasswordResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
-}
\ No newline at end of file
+}
+
+type RecoverAccountRequest struct {
+	Email       string `json:"email" validate:"required,email"`
+	Code        string `json:"code" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=6"`
+}
+
+type RecoverAccountResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+type StartDeviceAuthorizationRequest struct {
+	ClientID string `json:"client_id" validate:"required"`
+	Scope    string `json:"scope"`
+}
+
+type StartDeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+type VerifyDeviceCodeRequest struct {
+	UserCode string `json:"user_code" validate:"required"`
+	Approve  bool   `json:"approve"`
+}
+
+type VerifyDeviceCodeResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type PollDeviceTokenRequest struct {
+	DeviceCode string `json:"device_code" validate:"required"`
+	ClientID   string `json:"client_id" validate:"required"`
+}
+
+type PollDeviceTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresAt    int64  `json:"expires_at"`
+	UserID       string `json:"user_id"`
+}
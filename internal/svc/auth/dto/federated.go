@@ -0,0 +1,51 @@
+package dto
+
+import "github.com/hailsayan/achilles/internal/svc/auth/entity"
+
+type StartFederatedLoginRequest struct {
+	Provider string `json:"provider" validate:"required"`
+}
+
+type StartFederatedLoginResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+type CompleteFederatedLoginRequest struct {
+	State string `json:"state" validate:"required"`
+	Code  string `json:"code" validate:"required"`
+}
+
+type IdentityResponse struct {
+	Provider  string `json:"provider"`
+	Subject   string `json:"subject"`
+	Email     string `json:"email"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type ListIdentitiesRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+type ListIdentitiesResponse struct {
+	Identities []*IdentityResponse `json:"identities"`
+}
+
+type UnlinkIdentityRequest struct {
+	UserID   string `json:"user_id" validate:"required"`
+	Provider string `json:"provider" validate:"required"`
+}
+
+type UnlinkIdentityResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func ToIdentityResponse(identity *entity.Identity) *IdentityResponse {
+	return &IdentityResponse{
+		Provider:  identity.Provider,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: identity.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,13 @@
+package dto
+
+type ImpersonateRequest struct {
+	TargetUserID string `json:"target_user_id" validate:"required"`
+	Reason       string `json:"reason" validate:"required"`
+}
+
+type ImpersonateResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresAt   int64  `json:"expires_at"`
+	UserID      string `json:"user_id"`
+	ActorID     string `json:"actor_id"`
+}
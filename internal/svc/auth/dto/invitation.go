@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+)
+
+type CreateInvitationRequest struct {
+	Email       string   `json:"email" validate:"required,email"`
+	FirstName   string   `json:"first_name"`
+	LastName    string   `json:"last_name"`
+	Permissions []string `json:"permissions"`
+}
+
+type ListInvitationsRequest struct {
+	PageSize  int    `json:"page_size" validate:"omitempty,min=1"`
+	PageToken string `json:"page_token"`
+}
+
+type RevokeInvitationRequest struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// AcceptInvitationRequest creates the invitee's account. The names default
+// to the ones on the invitation.
+type AcceptInvitationRequest struct {
+	Token     string `json:"token" validate:"required"`
+	Password  string `json:"password" validate:"required,min=6"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+type InvitationResponse struct {
+	ID          string     `json:"id"`
+	Email       string     `json:"email"`
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"last_name"`
+	Permissions []string   `json:"permissions"`
+	InvitedBy   string     `json:"invited_by"`
+	State       string     `json:"state"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+type ListInvitationsResponse struct {
+	Invitations   []*InvitationResponse `json:"invitations"`
+	NextPageToken string                `json:"next_page_token"`
+}
+
+func ToInvitationResponse(invitation *entity.Invitation, now time.Time) *InvitationResponse {
+	state := constant.InvitationStatePending
+	switch {
+	case invitation.AcceptedAt != nil:
+		state = constant.InvitationStateAccepted
+	case invitation.RevokedAt != nil:
+		state = constant.InvitationStateRevoked
+	case !now.Before(invitation.ExpiresAt):
+		state = constant.InvitationStateExpired
+	}
+
+	return &InvitationResponse{
+		ID:          invitation.ID,
+		Email:       invitation.Email,
+		FirstName:   invitation.FirstName,
+		LastName:    invitation.LastName,
+		Permissions: invitation.Permissions,
+		InvitedBy:   invitation.InvitedBy,
+		State:       state,
+		CreatedAt:   invitation.CreatedAt,
+		ExpiresAt:   invitation.ExpiresAt,
+		AcceptedAt:  invitation.AcceptedAt,
+		RevokedAt:   invitation.RevokedAt,
+	}
+}
@@ -0,0 +1,14 @@
+package dto
+
+// SwitchOrganizationRequest clears the active organization when
+// OrganizationID is empty.
+type SwitchOrganizationRequest struct {
+	OrganizationID string `json:"organization_id"`
+}
+
+type SwitchOrganizationResponse struct {
+	AccessToken    string `json:"access_token"`
+	ExpiresAt      int64  `json:"expires_at"`
+	OrganizationID string `json:"organization_id"`
+	Role           string `json:"role"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/hailsayan/achilles/internal/pkg/audit"
+
+type ExportUserDataRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+type SessionInfo struct {
+	Active    bool  `json:"active"`
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+}
+
+// UserDataExport is everything the auth service holds about a user except
+// secrets: password hashes and TOTP secrets are reported only as present.
+type UserDataExport struct {
+	UserID       string              `json:"user_id"`
+	Status       string              `json:"status"`
+	Permissions  []string            `json:"permissions"`
+	HasPassword  bool                `json:"has_password"`
+	TOTPEnabled  bool                `json:"totp_enabled"`
+	Identities   []*IdentityResponse `json:"identities"`
+	Session      *SessionInfo        `json:"session"`
+	LoginHistory []*audit.Event      `json:"login_history"`
+	AuditEvents  []*audit.Event      `json:"audit_events"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+type ReauthenticateRequest struct {
+	Password string `json:"password"`
+	TOTPCode string `json:"totp_code"`
+	SMSCode  string `json:"sms_code"`
+}
+
+type ReauthenticateResponse struct {
+	AccessToken string   `json:"access_token"`
+	ExpiresAt   int64    `json:"expires_at"`
+	ACR         string   `json:"acr"`
+	AMR         []string `json:"amr"`
+}
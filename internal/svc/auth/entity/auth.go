@@ -1,6 +1,9 @@
 package entity
 
 type UserAuth struct {
-	ID             string `json:"id"`
-	HashedPassword string `json:"hashed_password"`
+	ID             string   `json:"id"`
+	HashedPassword string   `json:"hashed_password"`
+	Permissions    []string `json:"permissions"`
+	TOTPSecret     string   `json:"-"`
+	Status         string   `json:"status"`
 }
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type DeviceAuthorization struct {
+	DeviceCode   string        `json:"device_code"`
+	UserCode     string        `json:"user_code"`
+	ClientID     string        `json:"client_id"`
+	Scope        string        `json:"scope"`
+	Status       string        `json:"status"`
+	UserID       string        `json:"user_id"`
+	TenantID     string        `json:"tenant_id"`
+	Interval     time.Duration `json:"interval"`
+	LastPolledAt time.Time     `json:"last_polled_at"`
+	ExpiresAt    time.Time     `json:"expires_at"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+type Identity struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FederatedLoginState struct {
+	State        string `json:"state"`
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	TenantID     string `json:"tenant_id"`
+}
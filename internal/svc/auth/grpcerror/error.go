@@ -0,0 +1,147 @@
+package grpcerror
+
+import (
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func NewInternalError() error {
+	return status.Error(codes.Internal, constant.InternalServerErrorMessage)
+}
+
+func NewInvalidClientError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidClientErrorMessage)
+}
+
+func NewInvalidUserCodeError() error {
+	return status.Error(codes.NotFound, constant.InvalidUserCodeMessage)
+}
+
+func NewAuthorizationPendingError() error {
+	return status.Error(codes.FailedPrecondition, constant.AuthorizationPendingError)
+}
+
+func NewSlowDownError() error {
+	return status.Error(codes.ResourceExhausted, constant.SlowDownError)
+}
+
+func NewAccessDeniedError() error {
+	return status.Error(codes.PermissionDenied, constant.AccessDeniedError)
+}
+
+func NewExpiredTokenError() error {
+	return status.Error(codes.FailedPrecondition, constant.ExpiredTokenError)
+}
+
+func NewUnknownProviderError() error {
+	return status.Error(codes.InvalidArgument, constant.UnknownProviderMessage)
+}
+
+func NewInvalidStateError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidStateMessage)
+}
+
+func NewIdentityTokenInvalidError() error {
+	return status.Error(codes.Unauthenticated, constant.IdentityTokenInvalid)
+}
+
+func NewEmailNotVerifiedError() error {
+	return status.Error(codes.FailedPrecondition, constant.EmailNotVerifiedMessage)
+}
+
+func NewIdentityNotFoundError() error {
+	return status.Error(codes.NotFound, constant.IdentityNotFoundMessage)
+}
+
+func NewLastCredentialError() error {
+	return status.Error(codes.FailedPrecondition, constant.LastCredentialMessage)
+}
+
+func NewUnauthenticatedError() error {
+	return status.Error(codes.Unauthenticated, constant.UnauthenticatedMessage)
+}
+
+func NewUserNotFoundError() error {
+	return status.Error(codes.NotFound, constant.UserNotFoundErrorMessage)
+}
+
+func NewImpersonationNotAllowedError() error {
+	return status.Error(codes.PermissionDenied, constant.ImpersonationNotAllowed)
+}
+
+func NewReasonRequiredError() error {
+	return status.Error(codes.InvalidArgument, constant.ReasonRequiredMessage)
+}
+
+func NewInvalidCredentialsError() error {
+	return status.Error(codes.Unauthenticated, constant.InvalidCredentialsMessage)
+}
+
+func NewCredentialRequiredError() error {
+	return status.Error(codes.InvalidArgument, constant.CredentialRequiredMessage)
+}
+
+func NewReauthLockedError() error {
+	return status.Error(codes.ResourceExhausted, constant.ReauthLockedMessage)
+}
+
+func NewTOTPNotEnrolledError() error {
+	return status.Error(codes.FailedPrecondition, constant.TOTPNotEnrolledMessage)
+}
+
+func NewImpersonationStepUpError() error {
+	return status.Error(codes.PermissionDenied, constant.ImpersonationStepUpMessage)
+}
+
+func NewAccountInactiveError(accountStatus string) error {
+	return status.Errorf(codes.PermissionDenied, constant.AccountInactiveMessage, accountStatus)
+}
+
+func NewPermissionDeniedError() error {
+	return status.Error(codes.PermissionDenied, constant.PermissionDeniedMessage)
+}
+
+func NewInvalidRefreshTokenError() error {
+	return status.Error(codes.Unauthenticated, constant.InvalidRefreshTokenMessage)
+}
+
+func NewInvalidPageTokenError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidPageTokenMessage)
+}
+
+func NewOrganizationNotFoundError() error {
+	return status.Error(codes.NotFound, constant.OrganizationNotFound)
+}
+
+func NewImpersonationSwitchError() error {
+	return status.Error(codes.PermissionDenied, constant.ImpersonationSwitchMessage)
+}
+
+func NewInvitationNotFoundError() error {
+	return status.Error(codes.NotFound, constant.InvitationNotFoundMessage)
+}
+
+func NewInvitationExistsError() error {
+	return status.Error(codes.AlreadyExists, constant.InvitationExistsMessage)
+}
+
+func NewInvitationClosedError() error {
+	return status.Error(codes.FailedPrecondition, constant.InvitationClosedMessage)
+}
+
+func NewInvalidInvitationTokenError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidInvitationTokenMessage)
+}
+
+func NewUserExistsError() error {
+	return status.Error(codes.AlreadyExists, constant.UserExistsMessage)
+}
+
+func NewInvalidEmailError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidEmailMessage)
+}
+
+func NewPermissionNotGrantableError(permission string) error {
+	return status.Errorf(codes.PermissionDenied, constant.PermissionNotGrantableMessage, permission)
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hailsayan/achilles/internal/pkg/events"
+	"github.com/hailsayan/achilles/internal/pkg/mq"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/auth/usecase"
+)
+
+func NewUserStatusChangedHandler(authUseCase usecase.AuthUseCase) mq.KafkaHandler {
+	return func(ctx context.Context, body []byte) error {
+		event := &events.UserStatusChangedEvent{}
+		if err := json.Unmarshal(body, event); err != nil {
+			return err
+		}
+		ctx = tenant.WithTenant(ctx, event.TenantID)
+		return authUseCase.ApplyUserStatus(ctx, event)
+	}
+}
+
+func NewUserLifecycleHandler(authUseCase usecase.AuthUseCase) mq.KafkaHandler {
+	return func(ctx context.Context, body []byte) error {
+		event := &events.UserLifecycleEvent{}
+		if err := json.Unmarshal(body, event); err != nil {
+			return err
+		}
+		ctx = tenant.WithTenant(ctx, event.TenantID)
+		return authUseCase.ApplyUserLifecycle(ctx, event)
+	}
+}
+
+func NewUserErasureRequestedHandler(authUseCase usecase.AuthUseCase) mq.KafkaHandler {
+	return func(ctx context.Context, body []byte) error {
+		event := &events.UserErasureRequestedEvent{}
+		if err := json.Unmarshal(body, event); err != nil {
+			return err
+		}
+		ctx = tenant.WithTenant(ctx, event.TenantID)
+		return authUseCase.EraseUserData(ctx, event)
+	}
+}
@@ -1,2 +1,462 @@
 package handler
 
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	pb "github.com/hailsayan/achilles/internal/svc/auth/pb/auth"
+	"github.com/hailsayan/achilles/internal/svc/auth/usecase"
+)
+
+type AuthHandler struct {
+	pb.UnimplementedAuthServiceServer
+	authUseCase usecase.AuthUseCase
+}
+
+func NewAuthHandler(authUseCase usecase.AuthUseCase) *AuthHandler {
+	return &AuthHandler{
+		authUseCase: authUseCase,
+	}
+}
+
+func (h *AuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	loginReq := &dto.LoginRequest{
+		Email:    req.Email,
+		Username: req.Username,
+		Password: req.Password,
+	}
+
+	res, err := h.authUseCase.Login(ctx, loginReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LoginResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+		ExpiresAt:    res.ExpiresAt,
+		UserId:       res.UserID,
+	}, nil
+}
+
+func (h *AuthHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	res, err := h.authUseCase.Logout(ctx, &dto.LogoutRequest{
+		UserID:       req.UserId,
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LogoutResponse{
+		Success: res.Success,
+		Message: res.Message,
+	}, nil
+}
+
+func (h *AuthHandler) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	res, err := h.authUseCase.ChangePassword(ctx, &dto.ChangePasswordRequest{
+		UserID:      req.UserId,
+		OldPassword: req.OldPassword,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ChangePasswordResponse{
+		Success: res.Success,
+		Message: res.Message,
+	}, nil
+}
+
+func (h *AuthHandler) RecoverAccount(ctx context.Context, req *pb.RecoverAccountRequest) (*pb.RecoverAccountResponse, error) {
+	res, err := h.authUseCase.RecoverAccount(ctx, &dto.RecoverAccountRequest{
+		Email:       req.Email,
+		Code:        req.Code,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RecoverAccountResponse{
+		Success: res.Success,
+		Message: res.Message,
+	}, nil
+}
+
+func (h *AuthHandler) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error) {
+	validateReq := &dto.ValidateTokenRequest{
+		Token: req.Token,
+	}
+
+	res, err := h.authUseCase.ValidateToken(ctx, validateReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ValidateTokenResponse{
+		IsValid: res.IsValid,
+		UserId:  res.UserID,
+	}, nil
+}
+
+func (h *AuthHandler) StartDeviceAuthorization(ctx context.Context, req *pb.StartDeviceAuthorizationRequest) (*pb.StartDeviceAuthorizationResponse, error) {
+	startReq := &dto.StartDeviceAuthorizationRequest{
+		ClientID: req.ClientId,
+		Scope:    req.Scope,
+	}
+
+	res, err := h.authUseCase.StartDeviceAuthorization(ctx, startReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.StartDeviceAuthorizationResponse{
+		DeviceCode:              res.DeviceCode,
+		UserCode:                res.UserCode,
+		VerificationUri:         res.VerificationURI,
+		VerificationUriComplete: res.VerificationURIComplete,
+		ExpiresIn:               res.ExpiresIn,
+		Interval:                res.Interval,
+	}, nil
+}
+
+func (h *AuthHandler) VerifyDeviceCode(ctx context.Context, req *pb.VerifyDeviceCodeRequest) (*pb.VerifyDeviceCodeResponse, error) {
+	verifyReq := &dto.VerifyDeviceCodeRequest{
+		UserCode: req.UserCode,
+		Approve:  req.Approve,
+	}
+
+	res, err := h.authUseCase.VerifyDeviceCode(ctx, verifyReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.VerifyDeviceCodeResponse{
+		Success: res.Success,
+		Message: res.Message,
+	}, nil
+}
+
+func (h *AuthHandler) PollDeviceToken(ctx context.Context, req *pb.PollDeviceTokenRequest) (*pb.PollDeviceTokenResponse, error) {
+	pollReq := &dto.PollDeviceTokenRequest{
+		DeviceCode: req.DeviceCode,
+		ClientID:   req.ClientId,
+	}
+
+	res, err := h.authUseCase.PollDeviceToken(ctx, pollReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PollDeviceTokenResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+		ExpiresAt:    res.ExpiresAt,
+		UserId:       res.UserID,
+	}, nil
+}
+
+func (h *AuthHandler) StartFederatedLogin(ctx context.Context, req *pb.StartFederatedLoginRequest) (*pb.StartFederatedLoginResponse, error) {
+	startReq := &dto.StartFederatedLoginRequest{
+		Provider: req.Provider,
+	}
+
+	res, err := h.authUseCase.StartFederatedLogin(ctx, startReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.StartFederatedLoginResponse{
+		AuthorizationUrl: res.AuthorizationURL,
+		State:            res.State,
+	}, nil
+}
+
+func (h *AuthHandler) CompleteFederatedLogin(ctx context.Context, req *pb.CompleteFederatedLoginRequest) (*pb.LoginResponse, error) {
+	completeReq := &dto.CompleteFederatedLoginRequest{
+		State: req.State,
+		Code:  req.Code,
+	}
+
+	res, err := h.authUseCase.CompleteFederatedLogin(ctx, completeReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LoginResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+		ExpiresAt:    res.ExpiresAt,
+		UserId:       res.UserID,
+	}, nil
+}
+
+func (h *AuthHandler) ListIdentities(ctx context.Context, req *pb.ListIdentitiesRequest) (*pb.ListIdentitiesResponse, error) {
+	listReq := &dto.ListIdentitiesRequest{
+		UserID: req.UserId,
+	}
+
+	res, err := h.authUseCase.ListIdentities(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]*pb.Identity, 0, len(res.Identities))
+	for _, identity := range res.Identities {
+		identities = append(identities, &pb.Identity{
+			Provider:  identity.Provider,
+			Subject:   identity.Subject,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		})
+	}
+
+	return &pb.ListIdentitiesResponse{
+		Identities: identities,
+	}, nil
+}
+
+func (h *AuthHandler) UnlinkIdentity(ctx context.Context, req *pb.UnlinkIdentityRequest) (*pb.UnlinkIdentityResponse, error) {
+	unlinkReq := &dto.UnlinkIdentityRequest{
+		UserID:   req.UserId,
+		Provider: req.Provider,
+	}
+
+	res, err := h.authUseCase.UnlinkIdentity(ctx, unlinkReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UnlinkIdentityResponse{
+		Success: res.Success,
+		Message: res.Message,
+	}, nil
+}
+
+func (h *AuthHandler) Impersonate(ctx context.Context, req *pb.ImpersonateRequest) (*pb.ImpersonateResponse, error) {
+	impersonateReq := &dto.ImpersonateRequest{
+		TargetUserID: req.TargetUserId,
+		Reason:       req.Reason,
+	}
+
+	res, err := h.authUseCase.Impersonate(ctx, impersonateReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ImpersonateResponse{
+		AccessToken: res.AccessToken,
+		ExpiresAt:   res.ExpiresAt,
+		UserId:      res.UserID,
+		ActorId:     res.ActorID,
+	}, nil
+}
+
+func (h *AuthHandler) Reauthenticate(ctx context.Context, req *pb.ReauthenticateRequest) (*pb.ReauthenticateResponse, error) {
+	reauthReq := &dto.ReauthenticateRequest{
+		Password: req.Password,
+		TOTPCode: req.TotpCode,
+		SMSCode:  req.SmsCode,
+	}
+
+	res, err := h.authUseCase.Reauthenticate(ctx, reauthReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ReauthenticateResponse{
+		AccessToken: res.AccessToken,
+		ExpiresAt:   res.ExpiresAt,
+		Acr:         res.ACR,
+		Amr:         res.AMR,
+	}, nil
+}
+
+func (h *AuthHandler) SwitchOrganization(ctx context.Context, req *pb.SwitchOrganizationRequest) (*pb.SwitchOrganizationResponse, error) {
+	res, err := h.authUseCase.SwitchOrganization(ctx, &dto.SwitchOrganizationRequest{
+		OrganizationID: req.OrganizationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SwitchOrganizationResponse{
+		AccessToken:    res.AccessToken,
+		ExpiresAt:      res.ExpiresAt,
+		OrganizationId: res.OrganizationID,
+		Role:           res.Role,
+	}, nil
+}
+
+func (h *AuthHandler) CreateInvitation(ctx context.Context, req *pb.CreateInvitationRequest) (*pb.Invitation, error) {
+	res, err := h.authUseCase.CreateInvitation(ctx, &dto.CreateInvitationRequest{
+		Email:       req.Email,
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvitation(res), nil
+}
+
+func (h *AuthHandler) ListInvitations(ctx context.Context, req *pb.ListInvitationsRequest) (*pb.ListInvitationsResponse, error) {
+	res, err := h.authUseCase.ListInvitations(ctx, &dto.ListInvitationsRequest{
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]*pb.Invitation, 0, len(res.Invitations))
+	for _, invitation := range res.Invitations {
+		invitations = append(invitations, toInvitation(invitation))
+	}
+	return &pb.ListInvitationsResponse{
+		Invitations:   invitations,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+func (h *AuthHandler) RevokeInvitation(ctx context.Context, req *pb.RevokeInvitationRequest) (*pb.Invitation, error) {
+	res, err := h.authUseCase.RevokeInvitation(ctx, &dto.RevokeInvitationRequest{
+		ID: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvitation(res), nil
+}
+
+func (h *AuthHandler) AcceptInvitation(ctx context.Context, req *pb.AcceptInvitationRequest) (*pb.LoginResponse, error) {
+	res, err := h.authUseCase.AcceptInvitation(ctx, &dto.AcceptInvitationRequest{
+		Token:     req.Token,
+		Password:  req.Password,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LoginResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+		ExpiresAt:    res.ExpiresAt,
+		UserId:       res.UserID,
+	}, nil
+}
+
+func toInvitation(invitation *dto.InvitationResponse) *pb.Invitation {
+	res := &pb.Invitation{
+		Id:          invitation.ID,
+		Email:       invitation.Email,
+		FirstName:   invitation.FirstName,
+		LastName:    invitation.LastName,
+		Permissions: invitation.Permissions,
+		InvitedBy:   invitation.InvitedBy,
+		State:       invitation.State,
+		CreatedAt:   invitation.CreatedAt.Unix(),
+		ExpiresAt:   invitation.ExpiresAt.Unix(),
+	}
+	if invitation.AcceptedAt != nil {
+		res.AcceptedAt = invitation.AcceptedAt.Unix()
+	}
+	if invitation.RevokedAt != nil {
+		res.RevokedAt = invitation.RevokedAt.Unix()
+	}
+	return res
+}
+
+func (h *AuthHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.UserDataExport, error) {
+	res, err := h.authUseCase.ExportUserData(ctx, &dto.ExportUserDataRequest{
+		UserID: req.UserId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]*pb.Identity, 0, len(res.Identities))
+	for _, identity := range res.Identities {
+		identities = append(identities, &pb.Identity{
+			Provider:  identity.Provider,
+			Subject:   identity.Subject,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		})
+	}
+
+	return &pb.UserDataExport{
+		UserId:      res.UserID,
+		Status:      res.Status,
+		Permissions: res.Permissions,
+		HasPassword: res.HasPassword,
+		TotpEnabled: res.TOTPEnabled,
+		Identities:  identities,
+		Session: &pb.SessionInfo{
+			Active:    res.Session.Active,
+			ExpiresAt: res.Session.ExpiresAt,
+		},
+		LoginHistory: toAuditEntries(res.LoginHistory),
+		AuditEvents:  toAuditEntries(res.AuditEvents),
+	}, nil
+}
+
+func (h *AuthHandler) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	listReq := &dto.ListAuditEventsRequest{
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+		ActorID:   req.ActorId,
+		TargetID:  req.TargetId,
+		Operation: req.Operation,
+	}
+	if req.From > 0 {
+		from := time.Unix(req.From, 0).UTC()
+		listReq.From = &from
+	}
+	if req.To > 0 {
+		to := time.Unix(req.To, 0).UTC()
+		listReq.To = &to
+	}
+
+	res, err := h.authUseCase.ListAuditEvents(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListAuditEventsResponse{
+		Events:        toAuditEntries(res.Events),
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+func toAuditEntries(events []*audit.Event) []*pb.AuditEntry {
+	entries := make([]*pb.AuditEntry, 0, len(events))
+	for _, event := range events {
+		metadata, _ := json.Marshal(event.Metadata)
+		changes, _ := json.Marshal(event.Changes)
+		entries = append(entries, &pb.AuditEntry{
+			Id:             event.ID,
+			ActorId:        event.ActorID,
+			ImpersonatorId: event.ImpersonatorID,
+			TargetId:       event.TargetID,
+			Operation:      event.Operation,
+			RequestId:      event.RequestID,
+			SourceIp:       event.SourceIP,
+			MetadataJson:   string(metadata),
+			ChangesJson:    string(changes),
+			CreatedAt:      event.CreatedAt.Unix(),
+		})
+	}
+	return entries
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	pb "github.com/hailsayan/achilles/internal/svc/auth/pb/auth"
+)
+
+// MethodRules applies to the auth service. The sign-in methods marked
+// AnonymousTenant are called before the caller has a token, so they name
+// their tenant in the x-tenant-id header.
+var MethodRules = map[string]interceptor.MethodRule{
+	pb.AuthService_Login_FullMethodName:                    {AnonymousTenant: true},
+	pb.AuthService_StartDeviceAuthorization_FullMethodName: {AnonymousTenant: true},
+	pb.AuthService_StartFederatedLogin_FullMethodName:      {AnonymousTenant: true},
+	pb.AuthService_Logout_FullMethodName:                   {Authenticated: true, Mutating: true},
+	pb.AuthService_ChangePassword_FullMethodName:           {Mutating: true, ACR: jwtutils.ACRPassword, MaxAuthAge: 5 * time.Minute},
+	pb.AuthService_RecoverAccount_FullMethodName:           {Mutating: true, AnonymousTenant: true},
+	pb.AuthService_VerifyDeviceCode_FullMethodName:         {Authenticated: true, Mutating: true},
+	pb.AuthService_ListIdentities_FullMethodName:           {Authenticated: true},
+	pb.AuthService_UnlinkIdentity_FullMethodName:           {Authenticated: true, Mutating: true, ACR: jwtutils.ACRPassword, MaxAuthAge: 5 * time.Minute},
+	pb.AuthService_Impersonate_FullMethodName:              {Permission: constant.PermissionImpersonate},
+	pb.AuthService_Reauthenticate_FullMethodName:           {Authenticated: true},
+	pb.AuthService_SwitchOrganization_FullMethodName:       {Authenticated: true},
+	pb.AuthService_CreateInvitation_FullMethodName:         {Permission: constant.PermissionInviteUsers, Mutating: true},
+	pb.AuthService_ListInvitations_FullMethodName:          {Permission: constant.PermissionInviteUsers},
+	pb.AuthService_RevokeInvitation_FullMethodName:         {Permission: constant.PermissionInviteUsers, Mutating: true},
+	pb.AuthService_AcceptInvitation_FullMethodName:         {Mutating: true, AnonymousTenant: true},
+	pb.AuthService_ExportUserData_FullMethodName:           {Authenticated: true},
+	pb.AuthService_ListAuditEvents_FullMethodName:          {Permission: constant.PermissionReadAudit},
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	pb "github.com/hailsayan/achilles/internal/svc/auth/pb/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestSignInMethodsTakeTenantFromHeader(t *testing.T) {
+	unary := interceptor.NewAuthInterceptor(jwtutils.NewJwtUtil(&jwtutils.JwtConfig{
+		SecretKey: "test-secret",
+		Issuer:    "achilles",
+	}), MethodRules, nil, nil).Unary()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant-id", "acme"))
+
+	signIn := []string{
+		pb.AuthService_Login_FullMethodName,
+		pb.AuthService_RecoverAccount_FullMethodName,
+		pb.AuthService_StartDeviceAuthorization_FullMethodName,
+		pb.AuthService_StartFederatedLogin_FullMethodName,
+		pb.AuthService_AcceptInvitation_FullMethodName,
+	}
+	for _, method := range signIn {
+		t.Run(method, func(t *testing.T) {
+			var got string
+			_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+				got = tenant.FromContext(ctx)
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatalf("anonymous call naming acme: %v", err)
+			}
+			if got != "acme" {
+				t.Errorf("tenant = %q, want acme", got)
+			}
+		})
+	}
+
+	_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: pb.AuthService_ValidateToken_FullMethodName}, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	if code := status.Code(err); code != codes.PermissionDenied {
+		t.Errorf("anonymous ValidateToken naming acme: code = %v, want PermissionDenied", code)
+	}
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+)
+
+type accountChecker struct {
+	dataStore DataStore
+}
+
+// NewAccountChecker returns a checker that reads the status the auth
+// service keeps in step with the user service's status events.
+func NewAccountChecker(dataStore DataStore) interceptor.AccountChecker {
+	return &accountChecker{
+		dataStore: dataStore,
+	}
+}
+
+func (c *accountChecker) IsActive(ctx context.Context, userID string) (bool, error) {
+	var userAuth *entity.UserAuth
+	err := c.dataStore.Atomic(ctx, func(ds DataStore) error {
+		var err error
+		userAuth, err = ds.AuthRepository().GetByID(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return userAuth != nil && userAuth.Status == constant.UserStatusActive, nil
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+)
+
+type AuditRepository interface {
+	Record(ctx context.Context, event *audit.Event) error
+	List(ctx context.Context, filter *audit.Filter) ([]*audit.Event, error)
+	ListByUser(ctx context.Context, userID string) ([]*audit.Event, error)
+	AnonymizeByUser(ctx context.Context, userID string) error
+}
+
+type auditRepository struct {
+	db DBTX
+}
+
+func NewAuditRepository(db DBTX) AuditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+func (r *auditRepository) Record(ctx context.Context, event *audit.Event) error {
+	query := `
+		INSERT INTO
+			audit_events (id, actor_id, impersonator_id, target_id, operation, request_id, source_ip, metadata, changes, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	metadata, err := json.Marshal(event.Metadata)
+	if err != nil {
+		return err
+	}
+	if event.Metadata == nil {
+		metadata = []byte("{}")
+	}
+
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return err
+	}
+	if event.Changes == nil {
+		changes = []byte("{}")
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		event.ID,
+		event.ActorID,
+		sql.NullString{String: event.ImpersonatorID, Valid: event.ImpersonatorID != ""},
+		event.TargetID,
+		event.Operation,
+		event.RequestID,
+		event.SourceIP,
+		metadata,
+		changes,
+		event.CreatedAt,
+	)
+
+	return err
+}
+
+// List returns the events matching filter, newest first.
+func (r *auditRepository) List(ctx context.Context, filter *audit.Filter) ([]*audit.Event, error) {
+	conditions := []string{}
+	args := []any{}
+	addCondition := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.ActorID != "" {
+		addCondition("actor_id = $%d", filter.ActorID)
+	}
+	if filter.TargetID != "" {
+		addCondition("target_id = $%d", filter.TargetID)
+	}
+	if filter.Operation != "" {
+		addCondition("operation = $%d", filter.Operation)
+	}
+	if !filter.From.IsZero() {
+		addCondition("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition("created_at < $%d", filter.To)
+	}
+	if filter.BeforeID != "" {
+		args = append(args, filter.BeforeTime, filter.BeforeID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE\n\t\t\t" + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`
+		SELECT
+			id, actor_id, COALESCE(impersonator_id, ''), target_id, operation, request_id, source_ip, metadata, changes, created_at
+		FROM
+			audit_events
+		%s
+		ORDER BY
+			created_at DESC, id DESC
+		LIMIT
+			$%d
+	`, where, len(args))
+
+	return r.query(ctx, query, args...)
+}
+
+// ListByUser returns every event where the user is the actor or the target,
+// oldest first.
+func (r *auditRepository) ListByUser(ctx context.Context, userID string) ([]*audit.Event, error) {
+	query := `
+		SELECT
+			id, actor_id, COALESCE(impersonator_id, ''), target_id, operation, request_id, source_ip, metadata, changes, created_at
+		FROM
+			audit_events
+		WHERE
+			actor_id = $1 OR target_id = $1
+		ORDER BY
+			created_at, id
+	`
+
+	return r.query(ctx, query, userID)
+}
+
+// AnonymizeByUser strips the source address, free-form metadata and field
+// values from the user's events. The rows themselves stay so the trail keeps
+// its shape.
+func (r *auditRepository) AnonymizeByUser(ctx context.Context, userID string) error {
+	query := `
+		UPDATE
+			audit_events
+		SET
+			source_ip = '', metadata = '{}', changes = '{}'
+		WHERE
+			actor_id = $1 OR target_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *auditRepository) query(ctx context.Context, query string, args ...any) ([]*audit.Event, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*audit.Event{}
+	for rows.Next() {
+		event := &audit.Event{}
+		var metadata, changes []byte
+		if err := rows.Scan(
+			&event.ID,
+			&event.ActorID,
+			&event.ImpersonatorID,
+			&event.TargetID,
+			&event.Operation,
+			&event.RequestID,
+			&event.SourceIP,
+			&metadata,
+			&changes,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(metadata, &event.Metadata); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &event.Changes); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+type auditRecorder struct {
+	dataStore DataStore
+}
+
+// NewAuditRecorder returns a recorder that writes each event in its own
+// transaction, so it is stored under the caller's tenant.
+func NewAuditRecorder(dataStore DataStore) audit.Recorder {
+	return &auditRecorder{
+		dataStore: dataStore,
+	}
+}
+
+func (r *auditRecorder) Record(ctx context.Context, event *audit.Event) error {
+	return r.dataStore.Atomic(ctx, func(ds DataStore) error {
+		return ds.AuditRepository().Record(ctx, event)
+	})
+}
@@ -6,12 +6,15 @@ import (
 	"errors"
 
 	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"github.com/lib/pq"
 )
 
 type AuthRepository interface {
 	Create(ctx context.Context, userAuth *entity.UserAuth) error
 	GetByID(ctx context.Context, userID string) (*entity.UserAuth, error)
 	UpdatePassword(ctx context.Context, userID, hashedPassword string) error
+	UpdateStatus(ctx context.Context, userID, status string) error
+	Delete(ctx context.Context, userID string) error
 }
 
 type authRepository struct {
@@ -27,19 +30,24 @@ func NewAuthRepository(db DBTX) AuthRepository {
 func (r *authRepository) Create(ctx context.Context, userAuth *entity.UserAuth) error {
 	query := `
 	INSERT INTO
-		user_auth(id, hashed_password)
+		user_auth(id, hashed_password, permissions)
 	VALUES
-		($1, $2)
+		($1, $2, $3)
 	`
 
-	_, err := r.db.ExecContext(ctx, query, userAuth.ID, userAuth.HashedPassword)
+	permissions := userAuth.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	_, err := r.db.ExecContext(ctx, query, userAuth.ID, userAuth.HashedPassword, pq.Array(permissions))
 	return err
 }
 
 func (r *authRepository) GetByID(ctx context.Context, userID string) (*entity.UserAuth, error) {
 	query := `
 		SELECT
-			id, hashed_password
+			id, hashed_password, permissions, totp_secret, status
 		FROM
 			user_auth
 		WHERE
@@ -47,7 +55,7 @@ func (r *authRepository) GetByID(ctx context.Context, userID string) (*entity.Us
 	`
 
 	userAuth := &entity.UserAuth{}
-	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&userAuth.ID, &userAuth.HashedPassword); err != nil {
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&userAuth.ID, &userAuth.HashedPassword, pq.Array(&userAuth.Permissions), &userAuth.TOTPSecret, &userAuth.Status); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
@@ -69,4 +77,30 @@ func (r *authRepository) UpdatePassword(ctx context.Context, userID, hashedPassw
 
 	_, err := r.db.ExecContext(ctx, query, hashedPassword, userID)
 	return err
-}
\ No newline at end of file
+}
+
+func (r *authRepository) UpdateStatus(ctx context.Context, userID, status string) error {
+	query := `
+		UPDATE
+			user_auth
+		SET
+			status = $1
+		WHERE
+			id = $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, status, userID)
+	return err
+}
+
+func (r *authRepository) Delete(ctx context.Context, userID string) error {
+	query := `
+		DELETE FROM
+			user_auth
+		WHERE
+			id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -17,6 +18,12 @@ type DataStore interface {
 	Atomic(ctx context.Context, fn func(DataStore) error) error
 	AuthRepository() AuthRepository
 	TokenRepository() TokenRepository
+	DeviceRepository() DeviceRepository
+	IdentityRepository() IdentityRepository
+	FederatedStateRepository() FederatedStateRepository
+	AuditRepository() AuditRepository
+	InvitationRepository() InvitationRepository
+	ReauthRepository() ReauthRepository
 }
 
 type dataStore struct {
@@ -39,6 +46,13 @@ func (s *dataStore) Atomic(ctx context.Context, fn func(DataStore) error) error
 		return err
 	}
 
+	// Row-level security reads the tenant from app.tenant_id; it lasts
+	// until the transaction ends.
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('app.tenant_id', $1, true)`, tenant.FromContext(ctx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	err = fn(&dataStore{conn: s.conn, db: tx, rdb: s.rdb})
 	if err != nil {
 		if errRollback := tx.Rollback(); errRollback != nil {
@@ -57,3 +71,27 @@ func (s *dataStore) AuthRepository() AuthRepository {
 func (s *dataStore) TokenRepository() TokenRepository {
 	return NewTokenRepository(s.rdb)
 }
+
+func (s *dataStore) DeviceRepository() DeviceRepository {
+	return NewDeviceRepository(s.rdb)
+}
+
+func (s *dataStore) IdentityRepository() IdentityRepository {
+	return NewIdentityRepository(s.db)
+}
+
+func (s *dataStore) FederatedStateRepository() FederatedStateRepository {
+	return NewFederatedStateRepository(s.rdb)
+}
+
+func (s *dataStore) AuditRepository() AuditRepository {
+	return NewAuditRepository(s.db)
+}
+
+func (s *dataStore) InvitationRepository() InvitationRepository {
+	return NewInvitationRepository(s.db)
+}
+
+func (s *dataStore) ReauthRepository() ReauthRepository {
+	return NewReauthRepository(s.rdb)
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+type DeviceRepository interface {
+	Create(ctx context.Context, device *entity.DeviceAuthorization, expiration time.Duration) error
+	GetByDeviceCode(ctx context.Context, deviceCode string) (*entity.DeviceAuthorization, error)
+	GetByUserCode(ctx context.Context, userCode string) (*entity.DeviceAuthorization, error)
+	Decide(ctx context.Context, device *entity.DeviceAuthorization) (bool, error)
+	RecordPoll(ctx context.Context, device *entity.DeviceAuthorization) (bool, error)
+	Delete(ctx context.Context, device *entity.DeviceAuthorization) error
+	Claim(ctx context.Context, device *entity.DeviceAuthorization) (bool, error)
+}
+
+type deviceRepositoryImpl struct {
+	RDB *redis.ClusterClient
+}
+
+func NewDeviceRepository(rdb *redis.ClusterClient) DeviceRepository {
+	return &deviceRepositoryImpl{
+		RDB: rdb,
+	}
+}
+
+func (r *deviceRepositoryImpl) Create(ctx context.Context, device *entity.DeviceAuthorization, expiration time.Duration) error {
+	data, err := json.Marshal(device)
+	if err != nil {
+		return err
+	}
+
+	deviceKey := fmt.Sprintf(constant.DeviceCodePrefix, device.DeviceCode)
+	if err := r.RDB.Set(ctx, deviceKey, data, expiration).Err(); err != nil {
+		return err
+	}
+
+	userCodeKey := fmt.Sprintf(constant.DeviceUserCodePrefix, device.UserCode)
+	return r.RDB.Set(ctx, userCodeKey, device.DeviceCode, expiration).Err()
+}
+
+func (r *deviceRepositoryImpl) GetByDeviceCode(ctx context.Context, deviceCode string) (*entity.DeviceAuthorization, error) {
+	key := fmt.Sprintf(constant.DeviceCodePrefix, deviceCode)
+	result, err := r.RDB.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	device := &entity.DeviceAuthorization{}
+	if err := json.Unmarshal([]byte(result), device); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+func (r *deviceRepositoryImpl) GetByUserCode(ctx context.Context, userCode string) (*entity.DeviceAuthorization, error) {
+	key := fmt.Sprintf(constant.DeviceUserCodePrefix, userCode)
+	deviceCode, err := r.RDB.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.GetByDeviceCode(ctx, deviceCode)
+}
+
+// decideScript sets the status and user of an authorization that is still
+// pending. Approval and polling change different fields of the same record,
+// so both are applied in Redis rather than by rewriting what was read.
+var decideScript = redis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if not data then
+	return 0
+end
+local device = cjson.decode(data)
+if device.status ~= ARGV[1] then
+	return 0
+end
+device.status = ARGV[2]
+device.user_id = ARGV[3]
+redis.call('SET', KEYS[1], cjson.encode(device), 'KEEPTTL')
+return 1
+`)
+
+// recordPollScript sets the poll interval and time of an authorization that
+// is still pending.
+var recordPollScript = redis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if not data then
+	return 0
+end
+local device = cjson.decode(data)
+if device.status ~= ARGV[1] then
+	return 0
+end
+device.interval = tonumber(ARGV[2])
+device.last_polled_at = ARGV[3]
+redis.call('SET', KEYS[1], cjson.encode(device), 'KEEPTTL')
+return 1
+`)
+
+// Decide stores device.Status and device.UserID. It reports false when the
+// authorization is gone or no longer pending.
+func (r *deviceRepositoryImpl) Decide(ctx context.Context, device *entity.DeviceAuthorization) (bool, error) {
+	key := fmt.Sprintf(constant.DeviceCodePrefix, device.DeviceCode)
+	updated, err := decideScript.Run(ctx, r.RDB, []string{key}, constant.DeviceStatusPending, device.Status, device.UserID).Int()
+	if err != nil {
+		return false, err
+	}
+	return updated == 1, nil
+}
+
+// RecordPoll stores device.Interval and device.LastPolledAt. It reports
+// false when the authorization is gone or no longer pending.
+func (r *deviceRepositoryImpl) RecordPoll(ctx context.Context, device *entity.DeviceAuthorization) (bool, error) {
+	key := fmt.Sprintf(constant.DeviceCodePrefix, device.DeviceCode)
+	updated, err := recordPollScript.Run(ctx, r.RDB, []string{key},
+		constant.DeviceStatusPending,
+		int64(device.Interval),
+		device.LastPolledAt.Format(time.RFC3339Nano),
+	).Int()
+	if err != nil {
+		return false, err
+	}
+	return updated == 1, nil
+}
+
+func (r *deviceRepositoryImpl) Delete(ctx context.Context, device *entity.DeviceAuthorization) error {
+	deviceKey := fmt.Sprintf(constant.DeviceCodePrefix, device.DeviceCode)
+	if err := r.RDB.Del(ctx, deviceKey).Err(); err != nil {
+		return err
+	}
+
+	userCodeKey := fmt.Sprintf(constant.DeviceUserCodePrefix, device.UserCode)
+	return r.RDB.Del(ctx, userCodeKey).Err()
+}
+
+func (r *deviceRepositoryImpl) Claim(ctx context.Context, device *entity.DeviceAuthorization) (bool, error) {
+	deviceKey := fmt.Sprintf(constant.DeviceCodePrefix, device.DeviceCode)
+	deleted, err := r.RDB.Del(ctx, deviceKey).Result()
+	if err != nil {
+		return false, err
+	}
+
+	userCodeKey := fmt.Sprintf(constant.DeviceUserCodePrefix, device.UserCode)
+	if err := r.RDB.Del(ctx, userCodeKey).Err(); err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
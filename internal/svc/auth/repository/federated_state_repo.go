@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+type FederatedStateRepository interface {
+	Store(ctx context.Context, state *entity.FederatedLoginState, expiration time.Duration) error
+	Consume(ctx context.Context, state string) (*entity.FederatedLoginState, error)
+}
+
+type federatedStateRepositoryImpl struct {
+	RDB *redis.ClusterClient
+}
+
+func NewFederatedStateRepository(rdb *redis.ClusterClient) FederatedStateRepository {
+	return &federatedStateRepositoryImpl{
+		RDB: rdb,
+	}
+}
+
+func (r *federatedStateRepositoryImpl) Store(ctx context.Context, state *entity.FederatedLoginState, expiration time.Duration) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf(constant.FederatedStatePrefix, state.State)
+	return r.RDB.Set(ctx, key, data, expiration).Err()
+}
+
+func (r *federatedStateRepositoryImpl) Consume(ctx context.Context, state string) (*entity.FederatedLoginState, error) {
+	key := fmt.Sprintf(constant.FederatedStatePrefix, state)
+	result, err := r.RDB.GetDel(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	loginState := &entity.FederatedLoginState{}
+	if err := json.Unmarshal([]byte(result), loginState); err != nil {
+		return nil, err
+	}
+	return loginState, nil
+}
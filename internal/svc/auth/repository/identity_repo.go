@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+)
+
+type IdentityRepository interface {
+	Create(ctx context.Context, identity *entity.Identity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*entity.Identity, error)
+	ListByUserID(ctx context.Context, userID string) ([]*entity.Identity, error)
+	DeleteByUserIDAndProvider(ctx context.Context, userID, provider string) (bool, error)
+}
+
+type identityRepository struct {
+	db DBTX
+}
+
+func NewIdentityRepository(db DBTX) IdentityRepository {
+	return &identityRepository{
+		db: db,
+	}
+}
+
+func (r *identityRepository) Create(ctx context.Context, identity *entity.Identity) error {
+	query := `
+		INSERT INTO
+			user_identities (id, user_id, provider, subject, email, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+		identity.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *identityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*entity.Identity, error) {
+	query := `
+		SELECT
+			id, user_id, provider, subject, email, created_at
+		FROM
+			user_identities
+		WHERE
+			provider = $1 AND subject = $2
+	`
+
+	identity := &entity.Identity{}
+	err := r.db.QueryRowContext(ctx, query, provider, subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+func (r *identityRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.Identity, error) {
+	query := `
+		SELECT
+			id, user_id, provider, subject, email, created_at
+		FROM
+			user_identities
+		WHERE
+			user_id = $1
+		ORDER BY
+			created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	identities := []*entity.Identity{}
+	for rows.Next() {
+		identity := &entity.Identity{}
+		if err := rows.Scan(
+			&identity.ID,
+			&identity.UserID,
+			&identity.Provider,
+			&identity.Subject,
+			&identity.Email,
+			&identity.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
+
+func (r *identityRepository) DeleteByUserIDAndProvider(ctx context.Context, userID, provider string) (bool, error) {
+	query := `
+		DELETE FROM
+			user_identities
+		WHERE
+			user_id = $1 AND provider = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, provider)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
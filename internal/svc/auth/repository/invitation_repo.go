@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"github.com/lib/pq"
+)
+
+type ListInvitationsParams struct {
+	AfterCreatedAt time.Time
+	AfterID        string
+	Limit          int
+}
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *entity.Invitation) error
+	GetByID(ctx context.Context, id string) (*entity.Invitation, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*entity.Invitation, error)
+	GetOpenByEmail(ctx context.Context, email string) (*entity.Invitation, error)
+	List(ctx context.Context, params *ListInvitationsParams) ([]*entity.Invitation, error)
+	MarkAccepted(ctx context.Context, id, userID string, acceptedAt time.Time) (bool, error)
+	Revoke(ctx context.Context, id string, revokedAt time.Time) (bool, error)
+	DeleteByAcceptedUserID(ctx context.Context, userID string) error
+}
+
+type invitationRepository struct {
+	db DBTX
+}
+
+func NewInvitationRepository(db DBTX) InvitationRepository {
+	return &invitationRepository{
+		db: db,
+	}
+}
+
+func (r *invitationRepository) Create(ctx context.Context, invitation *entity.Invitation) error {
+	query := `
+		INSERT INTO
+			invitations (id, email, first_name, last_name, permissions, invited_by, created_at, expires_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	permissions := invitation.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		invitation.ID,
+		invitation.Email,
+		invitation.FirstName,
+		invitation.LastName,
+		pq.Array(permissions),
+		invitation.InvitedBy,
+		invitation.CreatedAt,
+		invitation.ExpiresAt,
+	)
+
+	return err
+}
+
+func (r *invitationRepository) GetByID(ctx context.Context, id string) (*entity.Invitation, error) {
+	query := `
+		SELECT
+			id, email, first_name, last_name, permissions, invited_by, created_at, expires_at, accepted_at, accepted_user_id, revoked_at
+		FROM
+			invitations
+		WHERE
+			id = $1
+	`
+
+	return r.getOne(ctx, query, id)
+}
+
+// GetByIDForUpdate locks the invitation until the transaction ends, so two
+// acceptances of the same invitation cannot both succeed.
+func (r *invitationRepository) GetByIDForUpdate(ctx context.Context, id string) (*entity.Invitation, error) {
+	query := `
+		SELECT
+			id, email, first_name, last_name, permissions, invited_by, created_at, expires_at, accepted_at, accepted_user_id, revoked_at
+		FROM
+			invitations
+		WHERE
+			id = $1
+		FOR UPDATE
+	`
+
+	return r.getOne(ctx, query, id)
+}
+
+// GetOpenByEmail returns the invitation for email that was neither accepted
+// nor revoked, which may have expired.
+func (r *invitationRepository) GetOpenByEmail(ctx context.Context, email string) (*entity.Invitation, error) {
+	query := `
+		SELECT
+			id, email, first_name, last_name, permissions, invited_by, created_at, expires_at, accepted_at, accepted_user_id, revoked_at
+		FROM
+			invitations
+		WHERE
+			email = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+
+	return r.getOne(ctx, query, email)
+}
+
+// List returns invitations newest first.
+func (r *invitationRepository) List(ctx context.Context, params *ListInvitationsParams) ([]*entity.Invitation, error) {
+	query := `
+		SELECT
+			id, email, first_name, last_name, permissions, invited_by, created_at, expires_at, accepted_at, accepted_user_id, revoked_at
+		FROM
+			invitations
+		WHERE
+			$1 = '' OR (created_at, id::text) < ($2, $1)
+		ORDER BY
+			created_at DESC, id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, params.AfterID, params.AfterCreatedAt, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []*entity.Invitation{}
+	for rows.Next() {
+		invitation := &entity.Invitation{}
+		if err := rows.Scan(
+			&invitation.ID,
+			&invitation.Email,
+			&invitation.FirstName,
+			&invitation.LastName,
+			pq.Array(&invitation.Permissions),
+			&invitation.InvitedBy,
+			&invitation.CreatedAt,
+			&invitation.ExpiresAt,
+			&invitation.AcceptedAt,
+			&invitation.AcceptedUserID,
+			&invitation.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, rows.Err()
+}
+
+func (r *invitationRepository) MarkAccepted(ctx context.Context, id, userID string, acceptedAt time.Time) (bool, error) {
+	query := `
+		UPDATE
+			invitations
+		SET
+			accepted_at = $1, accepted_user_id = $2
+		WHERE
+			id = $3 AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+
+	return r.exec(ctx, query, acceptedAt, userID, id)
+}
+
+func (r *invitationRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) (bool, error) {
+	query := `
+		UPDATE
+			invitations
+		SET
+			revoked_at = $1
+		WHERE
+			id = $2 AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+
+	return r.exec(ctx, query, revokedAt, id)
+}
+
+func (r *invitationRepository) DeleteByAcceptedUserID(ctx context.Context, userID string) error {
+	query := `
+		DELETE FROM
+			invitations
+		WHERE
+			accepted_user_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *invitationRepository) getOne(ctx context.Context, query string, arg any) (*entity.Invitation, error) {
+	invitation := &entity.Invitation{}
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&invitation.ID,
+		&invitation.Email,
+		&invitation.FirstName,
+		&invitation.LastName,
+		pq.Array(&invitation.Permissions),
+		&invitation.InvitedBy,
+		&invitation.CreatedAt,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedAt,
+		&invitation.AcceptedUserID,
+		&invitation.RevokedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (r *invitationRepository) exec(ctx context.Context, query string, args ...any) (bool, error) {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
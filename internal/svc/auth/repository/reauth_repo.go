@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/redis/go-redis/v9"
+)
+
+// ReauthRepository keeps what Reauthenticate needs to resist guessing: a
+// per-user count of attempts and the TOTP steps already used.
+type ReauthRepository interface {
+	IncrAttempts(ctx context.Context, userID string) (int64, error)
+	ResetAttempts(ctx context.Context, userID string) error
+	UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error)
+}
+
+type reauthRepositoryImpl struct {
+	RDB *redis.ClusterClient
+}
+
+func NewReauthRepository(rdb *redis.ClusterClient) ReauthRepository {
+	return &reauthRepositoryImpl{
+		RDB: rdb,
+	}
+}
+
+// IncrAttempts counts an attempt. The count expires
+// constant.ReauthLockoutDuration after the first attempt it holds.
+func (r *reauthRepositoryImpl) IncrAttempts(ctx context.Context, userID string) (int64, error) {
+	key := fmt.Sprintf(constant.ReauthAttemptsPrefix, userID)
+	pipe := r.RDB.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, constant.ReauthLockoutDuration)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (r *reauthRepositoryImpl) ResetAttempts(ctx context.Context, userID string) error {
+	key := fmt.Sprintf(constant.ReauthAttemptsPrefix, userID)
+	return r.RDB.Del(ctx, key).Err()
+}
+
+// UseTOTPStep marks the user's TOTP code for step as used. It reports false
+// when the code had already been used.
+func (r *reauthRepositoryImpl) UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	key := fmt.Sprintf(constant.TOTPStepPrefix, userID, step)
+	return r.RDB.SetNX(ctx, key, 1, constant.TOTPStepTTL).Result()
+}
@@ -13,6 +13,7 @@ type TokenRepository interface {
 	StoreRefreshToken(ctx context.Context, userID, refreshToken string, expiration time.Duration) error
 	GetRefreshToken(ctx context.Context, userID string) (string, error)
 	DeleteRefreshToken(ctx context.Context, userID string) error
+	GetRefreshTokenTTL(ctx context.Context, userID string) (time.Duration, error)
 }
 
 type tokenRepositoryImpl struct {
@@ -46,3 +47,17 @@ func (r *tokenRepositoryImpl) DeleteRefreshToken(ctx context.Context, userID str
 	key := fmt.Sprintf("refresh_token:%s", userID)
 	return r.RDB.Del(ctx, key).Err()
 }
+
+// GetRefreshTokenTTL returns how long the user's refresh token has left, or
+// zero when there is none.
+func (r *tokenRepositoryImpl) GetRefreshTokenTTL(ctx context.Context, userID string) (time.Duration, error) {
+	key := fmt.Sprintf("refresh_token:%s", userID)
+	ttl, err := r.RDB.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/randutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"github.com/hailsayan/achilles/internal/svc/auth/grpcerror"
+)
+
+func (u *authUseCaseImpl) StartDeviceAuthorization(ctx context.Context, req *dto.StartDeviceAuthorizationRequest) (*dto.StartDeviceAuthorizationResponse, error) {
+	clientID := strings.TrimSpace(req.ClientID)
+	if clientID == "" {
+		return nil, grpcerror.NewInvalidClientError()
+	}
+
+	deviceCode, err := randutils.GenerateToken(constant.DeviceCodeBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	userCode, err := randutils.GenerateString(constant.UserCodeAlphabet, constant.UserCodeLength)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	device := &entity.DeviceAuthorization{
+		DeviceCode: deviceCode,
+		UserCode:   userCode,
+		ClientID:   clientID,
+		Scope:      strings.TrimSpace(req.Scope),
+		Status:     constant.DeviceStatusPending,
+		TenantID:   tenant.FromContext(ctx),
+		Interval:   constant.DevicePollInterval,
+		ExpiresAt:  now.Add(constant.DeviceCodeTTL),
+	}
+
+	if err := u.dataStore.DeviceRepository().Create(ctx, device, constant.DeviceCodeTTL); err != nil {
+		return nil, err
+	}
+
+	displayCode := formatUserCode(userCode)
+	return &dto.StartDeviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                displayCode,
+		VerificationURI:         u.verificationURI,
+		VerificationURIComplete: fmt.Sprintf("%s?user_code=%s", u.verificationURI, url.QueryEscape(displayCode)),
+		ExpiresIn:               int64(constant.DeviceCodeTTL.Seconds()),
+		Interval:                int64(constant.DevicePollInterval.Seconds()),
+	}, nil
+}
+
+// VerifyDeviceCode approves or denies a pending device code on behalf of
+// the caller, who is the user the device will act as. Impersonation tokens
+// are refused: a device must not outlive the impersonation session.
+func (u *authUseCaseImpl) VerifyDeviceCode(ctx context.Context, req *dto.VerifyDeviceCodeRequest) (*dto.VerifyDeviceCodeResponse, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+	if claims.IsImpersonation() {
+		return nil, grpcerror.NewPermissionDeniedError()
+	}
+
+	deviceRepository := u.dataStore.DeviceRepository()
+
+	device, err := deviceRepository.GetByUserCode(ctx, normalizeUserCode(req.UserCode))
+	if err != nil {
+		return nil, err
+	}
+	// A code started in another tenant is reported as unknown.
+	if device == nil || device.TenantID != tenant.FromContext(ctx) || device.Status != constant.DeviceStatusPending || time.Now().UTC().After(device.ExpiresAt) {
+		return nil, grpcerror.NewInvalidUserCodeError()
+	}
+
+	message := constant.DeviceDeniedSuccessfully
+	device.Status = constant.DeviceStatusDenied
+	if req.Approve {
+		message = constant.DeviceApprovedSuccessfully
+		device.Status = constant.DeviceStatusApproved
+		device.UserID = claims.UserID
+	}
+
+	// Another verification may have decided the code since it was read.
+	decided, err := deviceRepository.Decide(ctx, device)
+	if err != nil {
+		return nil, err
+	}
+	if !decided {
+		return nil, grpcerror.NewInvalidUserCodeError()
+	}
+
+	return &dto.VerifyDeviceCodeResponse{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+func (u *authUseCaseImpl) PollDeviceToken(ctx context.Context, req *dto.PollDeviceTokenRequest) (*dto.PollDeviceTokenResponse, error) {
+	deviceRepository := u.dataStore.DeviceRepository()
+
+	device, err := deviceRepository.GetByDeviceCode(ctx, req.DeviceCode)
+	if err != nil {
+		return nil, err
+	}
+	if device == nil {
+		return nil, grpcerror.NewExpiredTokenError()
+	}
+	if device.ClientID != req.ClientID {
+		return nil, grpcerror.NewInvalidClientError()
+	}
+	// The device polls anonymously; its tokens belong to the tenant the
+	// authorization was started and approved in.
+	ctx = tenant.WithTenant(ctx, device.TenantID)
+
+	now := time.Now().UTC()
+	if now.After(device.ExpiresAt) {
+		if err := deviceRepository.Delete(ctx, device); err != nil {
+			return nil, err
+		}
+		return nil, grpcerror.NewExpiredTokenError()
+	}
+
+	switch device.Status {
+	case constant.DeviceStatusDenied:
+		if err := deviceRepository.Delete(ctx, device); err != nil {
+			return nil, err
+		}
+		return nil, grpcerror.NewAccessDeniedError()
+
+	case constant.DeviceStatusPending:
+		tooFast := !device.LastPolledAt.IsZero() && now.Sub(device.LastPolledAt) < device.Interval
+		if tooFast {
+			device.Interval += constant.DeviceSlowDownBackoff
+		}
+		device.LastPolledAt = now
+		// Only the poll fields are written, and only while the code is
+		// pending, so a poll cannot undo an approval that raced it. The
+		// next poll sees the decision.
+		if _, err := deviceRepository.RecordPoll(ctx, device); err != nil {
+			return nil, err
+		}
+		if tooFast {
+			return nil, grpcerror.NewSlowDownError()
+		}
+		return nil, grpcerror.NewAuthorizationPendingError()
+	}
+
+	// Device codes are single use: only the poll that removes the key may
+	// issue tokens, so concurrent polls cannot mint a second pair.
+	claimed, err := deviceRepository.Claim(ctx, device)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, grpcerror.NewExpiredTokenError()
+	}
+
+	username, err := u.usernameOf(ctx, device.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := u.issueTokens(ctx, device.UserID, username, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PollDeviceTokenResponse{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresAt:    token.ExpiresAt.Unix(),
+		UserID:       token.UserID,
+	}, nil
+}
+
+func formatUserCode(code string) string {
+	half := len(code) / 2
+	return code[:half] + "-" + code[half:]
+}
+
+func normalizeUserCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/auth/client"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"github.com/hailsayan/achilles/internal/svc/auth/repository"
+)
+
+// fakeDataStore keeps everything in memory. Atomic runs fn against the same
+// store, so a failed transaction is not rolled back. Repositories a test does
+// not set are nil and panic when used.
+type fakeDataStore struct {
+	repository.DataStore
+
+	auth       *fakeAuthRepository
+	tokens     *fakeTokenRepository
+	identities *fakeIdentityRepository
+	states     *fakeFederatedStateRepository
+	audit      *fakeAuditRepository
+	reauth     *fakeReauthRepository
+}
+
+func newFakeDataStore() *fakeDataStore {
+	return &fakeDataStore{
+		auth:       &fakeAuthRepository{users: map[string]*entity.UserAuth{}},
+		tokens:     &fakeTokenRepository{tokens: map[string]string{}},
+		identities: &fakeIdentityRepository{},
+		states:     &fakeFederatedStateRepository{states: map[string]*entity.FederatedLoginState{}},
+		audit:      &fakeAuditRepository{},
+		reauth:     &fakeReauthRepository{attempts: map[string]int64{}, steps: map[string]bool{}},
+	}
+}
+
+func (s *fakeDataStore) Atomic(ctx context.Context, fn func(repository.DataStore) error) error {
+	return fn(s)
+}
+
+func (s *fakeDataStore) AuthRepository() repository.AuthRepository {
+	return s.auth
+}
+
+func (s *fakeDataStore) TokenRepository() repository.TokenRepository {
+	return s.tokens
+}
+
+func (s *fakeDataStore) IdentityRepository() repository.IdentityRepository {
+	return s.identities
+}
+
+func (s *fakeDataStore) FederatedStateRepository() repository.FederatedStateRepository {
+	return s.states
+}
+
+func (s *fakeDataStore) AuditRepository() repository.AuditRepository {
+	return s.audit
+}
+
+func (s *fakeDataStore) ReauthRepository() repository.ReauthRepository {
+	return s.reauth
+}
+
+type fakeAuthRepository struct {
+	repository.AuthRepository
+
+	mu    sync.Mutex
+	users map[string]*entity.UserAuth
+}
+
+func (r *fakeAuthRepository) Create(ctx context.Context, userAuth *entity.UserAuth) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	created := *userAuth
+	if created.Status == "" {
+		created.Status = constant.UserStatusActive
+	}
+	r.users[userAuth.ID] = &created
+	return nil
+}
+
+func (r *fakeAuthRepository) GetByID(ctx context.Context, userID string) (*entity.UserAuth, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	userAuth, ok := r.users[userID]
+	if !ok {
+		return nil, nil
+	}
+	found := *userAuth
+	return &found, nil
+}
+
+type fakeTokenRepository struct {
+	repository.TokenRepository
+
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+func (r *fakeTokenRepository) StoreRefreshToken(ctx context.Context, userID, refreshToken string, expiration time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[userID] = refreshToken
+	return nil
+}
+
+func (r *fakeTokenRepository) GetRefreshTokenTTL(ctx context.Context, userID string) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tokens[userID]; !ok {
+		return 0, nil
+	}
+	return time.Hour, nil
+}
+
+type fakeIdentityRepository struct {
+	repository.IdentityRepository
+
+	mu         sync.Mutex
+	identities []*entity.Identity
+}
+
+func (r *fakeIdentityRepository) Create(ctx context.Context, identity *entity.Identity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.identities = append(r.identities, identity)
+	return nil
+}
+
+func (r *fakeIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*entity.Identity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeIdentityRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.Identity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	identities := []*entity.Identity{}
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+type fakeFederatedStateRepository struct {
+	mu     sync.Mutex
+	states map[string]*entity.FederatedLoginState
+}
+
+func (r *fakeFederatedStateRepository) Store(ctx context.Context, state *entity.FederatedLoginState, expiration time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[state.State] = state
+	return nil
+}
+
+func (r *fakeFederatedStateRepository) Consume(ctx context.Context, state string) (*entity.FederatedLoginState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	loginState, ok := r.states[state]
+	if !ok {
+		return nil, nil
+	}
+	delete(r.states, state)
+	return loginState, nil
+}
+
+type fakeAuditRepository struct {
+	repository.AuditRepository
+
+	mu     sync.Mutex
+	events []*audit.Event
+}
+
+func (r *fakeAuditRepository) Record(ctx context.Context, event *audit.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *fakeAuditRepository) ListByUser(ctx context.Context, userID string) ([]*audit.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := []*audit.Event{}
+	for _, event := range r.events {
+		if event.ActorID == userID || event.TargetID == userID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+type fakeReauthRepository struct {
+	mu       sync.Mutex
+	attempts map[string]int64
+	steps    map[string]bool
+}
+
+func (r *fakeReauthRepository) IncrAttempts(ctx context.Context, userID string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts[userID]++
+	return r.attempts[userID], nil
+}
+
+func (r *fakeReauthRepository) ResetAttempts(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.attempts, userID)
+	return nil
+}
+
+func (r *fakeReauthRepository) UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := fmt.Sprintf("%s:%d", userID, step)
+	if r.steps[key] {
+		return false, nil
+	}
+	r.steps[key] = true
+	return true, nil
+}
+
+// fakeHasher "hashes" a password by prefixing it.
+type fakeHasher struct{}
+
+func (fakeHasher) Hash(password string) (string, error) {
+	return "hashed:" + password, nil
+}
+
+func (fakeHasher) Check(password, hash string) bool {
+	return hash == "hashed:"+password
+}
+
+// fakeUserClient stands in for the user service. created counts the users
+// that CreateUser added. Users named in tenants are only found in their
+// tenant; the others are found in any.
+type fakeUserClient struct {
+	client.UserClient
+
+	mu      sync.Mutex
+	users   []*client.User
+	tenants map[string]string
+	lookups int
+	created int
+}
+
+func (c *fakeUserClient) visible(ctx context.Context, user *client.User) bool {
+	tenantID, ok := c.tenants[user.ID]
+	return !ok || tenantID == tenant.FromContext(ctx)
+}
+
+func (c *fakeUserClient) GetUserByID(ctx context.Context, userID string) (*client.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, user := range c.users {
+		if user.ID == userID {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *fakeUserClient) GetUserByEmail(ctx context.Context, email string) (*client.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lookups++
+	for _, user := range c.users {
+		if user.Email == email && c.visible(ctx, user) {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *fakeUserClient) CreateUser(ctx context.Context, email, firstName, lastName string) (*client.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.created++
+	user := &client.User{
+		ID:        email + "-id",
+		Email:     email,
+		FirstName: firstName,
+		LastName:  lastName,
+		Status:    constant.UserStatusActive,
+	}
+	c.users = append(c.users, user)
+	return user, nil
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/oidc"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/pkg/utils/randutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"github.com/hailsayan/achilles/internal/svc/auth/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/auth/repository"
+)
+
+func (u *authUseCaseImpl) StartFederatedLogin(ctx context.Context, req *dto.StartFederatedLoginRequest) (*dto.StartFederatedLoginResponse, error) {
+	provider, ok := u.providers[req.Provider]
+	if !ok {
+		return nil, grpcerror.NewUnknownProviderError()
+	}
+
+	state, err := randutils.GenerateToken(constant.FederatedTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randutils.GenerateToken(constant.FederatedTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := randutils.GenerateToken(constant.FederatedTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	loginState := &entity.FederatedLoginState{
+		State:        state,
+		Provider:     provider.Name(),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		TenantID:     tenant.FromContext(ctx),
+	}
+	if err := u.dataStore.FederatedStateRepository().Store(ctx, loginState, constant.FederatedStateTTL); err != nil {
+		return nil, err
+	}
+
+	return &dto.StartFederatedLoginResponse{
+		AuthorizationURL: provider.AuthCodeURL(state, nonce, oidc.CodeChallenge(codeVerifier)),
+		State:            state,
+	}, nil
+}
+
+func (u *authUseCaseImpl) CompleteFederatedLogin(ctx context.Context, req *dto.CompleteFederatedLoginRequest) (*dto.LoginResponse, error) {
+	loginState, err := u.dataStore.FederatedStateRepository().Consume(ctx, req.State)
+	if err != nil {
+		return nil, err
+	}
+	if loginState == nil {
+		return nil, grpcerror.NewInvalidStateError()
+	}
+	// The provider redirects back without the tenant; the state remembers it.
+	ctx = tenant.WithTenant(ctx, loginState.TenantID)
+
+	provider, ok := u.providers[loginState.Provider]
+	if !ok {
+		return nil, grpcerror.NewUnknownProviderError()
+	}
+
+	tokenRes, err := provider.Exchange(ctx, req.Code, loginState.CodeVerifier)
+	if err != nil {
+		return nil, grpcerror.NewIdentityTokenInvalidError()
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, tokenRes.IDToken, loginState.Nonce)
+	if err != nil {
+		return nil, grpcerror.NewIdentityTokenInvalidError()
+	}
+
+	var userID string
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		identityRepository := ds.IdentityRepository()
+
+		identity, err := identityRepository.GetByProviderSubject(ctx, provider.Name(), claims.Subject)
+		if err != nil {
+			return err
+		}
+		if identity != nil {
+			userID = identity.UserID
+			return nil
+		}
+
+		// Linking by email is only safe when the provider vouches for it,
+		// otherwise anyone could claim an existing account's address.
+		if !claims.EmailVerified || claims.Email == "" {
+			return grpcerror.NewEmailNotVerifiedError()
+		}
+
+		email := strings.ToLower(strings.TrimSpace(claims.Email))
+		userID, err = u.findOrCreateFederatedUser(ctx, ds, email, claims)
+		if err != nil {
+			return err
+		}
+
+		return identityRepository.Create(ctx, &entity.Identity{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			Provider:  provider.Name(),
+			Subject:   claims.Subject,
+			Email:     email,
+			CreatedAt: time.Now().UTC(),
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := u.usernameOf(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := u.issueTokens(ctx, userID, username, []string{jwtutils.AMRFederated})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresAt:    token.ExpiresAt.Unix(),
+		UserID:       token.UserID,
+	}, nil
+}
+
+func (u *authUseCaseImpl) ListIdentities(ctx context.Context, req *dto.ListIdentitiesRequest) (*dto.ListIdentitiesResponse, error) {
+	if err := authorizeOwnIdentities(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	var identities []*entity.Identity
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		identities, err = ds.IdentityRepository().ListByUserID(ctx, req.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.ListIdentitiesResponse{
+		Identities: make([]*dto.IdentityResponse, 0, len(identities)),
+	}
+	for _, identity := range identities {
+		res.Identities = append(res.Identities, dto.ToIdentityResponse(identity))
+	}
+	return res, nil
+}
+
+func (u *authUseCaseImpl) UnlinkIdentity(ctx context.Context, req *dto.UnlinkIdentityRequest) (*dto.UnlinkIdentityResponse, error) {
+	if err := authorizeOwnIdentities(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		identityRepository := ds.IdentityRepository()
+
+		identities, err := identityRepository.ListByUserID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+
+		userAuth, err := ds.AuthRepository().GetByID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		hasPassword := userAuth != nil && userAuth.HashedPassword != ""
+		if !hasPassword && len(identities) <= 1 {
+			return grpcerror.NewLastCredentialError()
+		}
+
+		deleted, err := identityRepository.DeleteByUserIDAndProvider(ctx, req.UserID, req.Provider)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return grpcerror.NewIdentityNotFoundError()
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.UnlinkIdentityResponse{
+		Success: true,
+		Message: constant.IdentityUnlinkedSuccessfully,
+	}, nil
+}
+
+// authorizeOwnIdentities lets users see and unlink only their own linked
+// identities, and never through an impersonation token.
+func authorizeOwnIdentities(ctx context.Context, userID string) error {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return grpcerror.NewUnauthenticatedError()
+	}
+	if claims.IsImpersonation() || claims.UserID != userID {
+		return grpcerror.NewPermissionDeniedError()
+	}
+	return nil
+}
+
+func (u *authUseCaseImpl) findOrCreateFederatedUser(ctx context.Context, ds repository.DataStore, email string, claims *oidc.IDTokenClaims) (string, error) {
+	user, err := u.userClient.GetUserByEmail(ctx, email)
+	if err != nil {
+		return "", err
+	}
+
+	if user == nil {
+		firstName, lastName := federatedNames(email, claims)
+		user, err = u.userClient.CreateUser(ctx, email, firstName, lastName)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	authRepository := ds.AuthRepository()
+	userAuth, err := authRepository.GetByID(ctx, user.ID)
+	if err != nil {
+		return "", err
+	}
+	if userAuth == nil {
+		// Federated-only accounts have no password until the user sets one.
+		if err := authRepository.Create(ctx, &entity.UserAuth{ID: user.ID}); err != nil {
+			return "", err
+		}
+	}
+
+	return user.ID, nil
+}
+
+func federatedNames(email string, claims *oidc.IDTokenClaims) (string, string) {
+	firstName := strings.TrimSpace(claims.GivenName)
+	lastName := strings.TrimSpace(claims.FamilyName)
+
+	if firstName == "" && lastName == "" {
+		parts := strings.Fields(claims.Name)
+		if len(parts) > 0 {
+			firstName = parts[0]
+			lastName = strings.Join(parts[1:], " ")
+		}
+	}
+	if firstName == "" {
+		firstName = strings.SplitN(email, "@", 2)[0]
+	}
+	if lastName == "" {
+		lastName = "-"
+	}
+	return firstName, lastName
+}
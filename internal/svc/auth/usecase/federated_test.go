@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/oidc"
+	"github.com/hailsayan/achilles/internal/pkg/oidc/oidctest"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/client"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type federatedFixture struct {
+	idp       *oidctest.Server
+	dataStore *fakeDataStore
+	users     *fakeUserClient
+	usecase   *authUseCaseImpl
+}
+
+func newFederatedFixture(t *testing.T) *federatedFixture {
+	t.Helper()
+
+	idp := oidctest.NewServer("client", "secret")
+	t.Cleanup(idp.Close)
+
+	provider, err := oidc.NewProvider(context.Background(), &oidc.ProviderConfig{
+		Name:         "idp",
+		Issuer:       idp.Issuer(),
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  "https://app.example.com/callback",
+	}, idp.Client())
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	dataStore := newFakeDataStore()
+	users := &fakeUserClient{}
+	return &federatedFixture{
+		idp:       idp,
+		dataStore: dataStore,
+		users:     users,
+		usecase: &authUseCaseImpl{
+			dataStore: dataStore,
+			jwtUtil: jwtutils.NewJwtUtil(&jwtutils.JwtConfig{
+				AccessTokenDuration:  15,
+				RefreshTokenDuration: 60,
+				SecretKey:            "test-secret",
+				Issuer:               "achilles",
+			}),
+			userClient: users,
+			providers:  map[string]*oidc.Provider{provider.Name(): provider},
+		},
+	}
+}
+
+// login runs a federated login in which the provider vouches for claims.
+func (f *federatedFixture) login(t *testing.T, subject string, extra map[string]any) (*dto.LoginResponse, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	started, err := f.usecase.StartFederatedLogin(ctx, &dto.StartFederatedLoginRequest{Provider: "idp"})
+	if err != nil {
+		t.Fatalf("StartFederatedLogin: %v", err)
+	}
+	loginState := f.dataStore.states.states[started.State]
+
+	claims := f.idp.Claims(subject, loginState.Nonce)
+	for name, value := range extra {
+		claims[name] = value
+	}
+	code := f.idp.Grant(oidc.CodeChallenge(loginState.CodeVerifier), claims)
+
+	return f.usecase.CompleteFederatedLogin(ctx, &dto.CompleteFederatedLoginRequest{
+		State: started.State,
+		Code:  code,
+	})
+}
+
+func TestCompleteFederatedLoginLinksOnlyVerifiedEmail(t *testing.T) {
+	t.Run("unverified email", func(t *testing.T) {
+		f := newFederatedFixture(t)
+		f.users.users = []*client.User{{ID: "victim", Email: "victim@example.com"}}
+		f.dataStore.auth.users["victim"] = &entity.UserAuth{ID: "victim", Status: constant.UserStatusActive}
+
+		_, err := f.login(t, "attacker", map[string]any{
+			"email":          "victim@example.com",
+			"email_verified": false,
+		})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("CompleteFederatedLogin error = %v, want FailedPrecondition", err)
+		}
+		if len(f.dataStore.identities.identities) != 0 {
+			t.Errorf("linked %d identities, want none", len(f.dataStore.identities.identities))
+		}
+		if f.users.lookups != 0 {
+			t.Errorf("looked up %d users by an unverified email, want none", f.users.lookups)
+		}
+	})
+
+	t.Run("verified email links the existing user", func(t *testing.T) {
+		f := newFederatedFixture(t)
+		f.users.users = []*client.User{{ID: "user", Email: "user@example.com"}}
+		f.dataStore.auth.users["user"] = &entity.UserAuth{ID: "user", Status: constant.UserStatusActive}
+
+		res, err := f.login(t, "subject", map[string]any{
+			"email":          "User@Example.com",
+			"email_verified": true,
+		})
+		if err != nil {
+			t.Fatalf("CompleteFederatedLogin: %v", err)
+		}
+		if res.UserID != "user" {
+			t.Errorf("signed in as %q, want %q", res.UserID, "user")
+		}
+		if f.users.created != 0 {
+			t.Errorf("created %d users, want none", f.users.created)
+		}
+
+		identities := f.dataStore.identities.identities
+		if len(identities) != 1 || identities[0].UserID != "user" || identities[0].Subject != "subject" {
+			t.Fatalf("identities = %+v, want subject linked to user", identities)
+		}
+	})
+
+	t.Run("linked identity signs in without a verified email", func(t *testing.T) {
+		f := newFederatedFixture(t)
+		f.users.users = []*client.User{{ID: "user", Email: "user@example.com"}}
+		f.dataStore.auth.users["user"] = &entity.UserAuth{ID: "user", Status: constant.UserStatusActive}
+		f.dataStore.identities.identities = []*entity.Identity{{ID: "identity", UserID: "user", Provider: "idp", Subject: "subject"}}
+
+		res, err := f.login(t, "subject", nil)
+		if err != nil {
+			t.Fatalf("CompleteFederatedLogin: %v", err)
+		}
+		if res.UserID != "user" {
+			t.Errorf("signed in as %q, want %q", res.UserID, "user")
+		}
+	})
+}
+
+func TestCompleteFederatedLoginRejectsWrongNonce(t *testing.T) {
+	f := newFederatedFixture(t)
+
+	_, err := f.login(t, "subject", map[string]any{
+		"nonce":          "another-nonce",
+		"email":          "user@example.com",
+		"email_verified": true,
+	})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("CompleteFederatedLogin error = %v, want Unauthenticated", err)
+	}
+	if len(f.dataStore.identities.identities) != 0 {
+		t.Errorf("linked %d identities, want none", len(f.dataStore.identities.identities))
+	}
+}
+
+func TestListIdentitiesOnlyForOwner(t *testing.T) {
+	f := newFederatedFixture(t)
+	f.dataStore.identities.identities = []*entity.Identity{{ID: "identity", UserID: "user", Provider: "idp", Subject: "subject"}}
+
+	tests := []struct {
+		name   string
+		claims *jwtutils.JWTClaims
+		want   codes.Code
+	}{
+		{
+			name: "anonymous",
+			want: codes.Unauthenticated,
+		},
+		{
+			name:   "owner",
+			claims: &jwtutils.JWTClaims{UserID: "user"},
+			want:   codes.OK,
+		},
+		{
+			name:   "another user",
+			claims: &jwtutils.JWTClaims{UserID: "other"},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "impersonation",
+			claims: &jwtutils.JWTClaims{UserID: "user", Actor: &jwtutils.ActorClaim{Subject: "admin"}},
+			want:   codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = interceptor.ContextWithClaims(ctx, tt.claims)
+			}
+
+			_, err := f.usecase.ListIdentities(ctx, &dto.ListIdentitiesRequest{UserID: "user"})
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("ListIdentities code = %v, want %v (err %v)", got, tt.want, err)
+			}
+		})
+	}
+}
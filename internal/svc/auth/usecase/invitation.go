@@ -0,0 +1,398 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/notify"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/client"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"github.com/hailsayan/achilles/internal/svc/auth/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/auth/repository"
+)
+
+// InvitationConfig controls how invitations are signed and delivered.
+// AcceptURL is the page the invitee lands on; the token is appended as the
+// token query parameter.
+type InvitationConfig struct {
+	Notifier  notify.Notifier
+	Secret    string
+	AcceptURL string
+	TTL       time.Duration
+}
+
+// invitationCursor is serialized into the ListInvitations page token.
+type invitationCursor struct {
+	CreatedAt string `json:"c"`
+	ID        string `json:"i"`
+}
+
+// CreateInvitation records the invitation and mails the invitee a signed
+// link. The inviter can only pass on permissions they hold themselves.
+func (u *authUseCaseImpl) CreateInvitation(ctx context.Context, req *dto.CreateInvitationRequest) (*dto.InvitationResponse, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+	for _, permission := range req.Permissions {
+		if !claims.HasPermission(permission) {
+			return nil, grpcerror.NewPermissionNotGrantableError(permission)
+		}
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return nil, grpcerror.NewInvalidEmailError()
+	}
+	user, err := u.userClient.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		return nil, grpcerror.NewUserExistsError()
+	}
+
+	now := time.Now().UTC()
+	invitation := &entity.Invitation{
+		ID:          uuid.New().String(),
+		Email:       email,
+		FirstName:   strings.TrimSpace(req.FirstName),
+		LastName:    strings.TrimSpace(req.LastName),
+		Permissions: req.Permissions,
+		InvitedBy:   claims.UserID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(u.invitations.TTL),
+	}
+
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		invitationRepository := ds.InvitationRepository()
+
+		open, err := invitationRepository.GetOpenByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+		if open != nil {
+			if open.IsPending(now) {
+				return grpcerror.NewInvitationExistsError()
+			}
+			// An expired invitation still holds the email; retire it.
+			if _, err := invitationRepository.Revoke(ctx, open.ID, now); err != nil {
+				return err
+			}
+		}
+
+		if err := invitationRepository.Create(ctx, invitation); err != nil {
+			return err
+		}
+		if err := recordInvitationAudit(ctx, ds, constant.AuditOperationCreateInvitation, invitation); err != nil {
+			return err
+		}
+
+		// Sent last so a failed delivery rolls the invitation back and the
+		// admin can simply retry.
+		return u.invitations.Notifier.Notify(ctx, &notify.Message{
+			To:      invitation.Email,
+			Subject: constant.InvitationEmailSubject,
+			Body:    fmt.Sprintf(constant.InvitationEmailBody, u.invitationLink(invitation), invitation.ExpiresAt.Format(time.RFC1123)),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToInvitationResponse(invitation, now), nil
+}
+
+func (u *authUseCaseImpl) ListInvitations(ctx context.Context, req *dto.ListInvitationsRequest) (*dto.ListInvitationsResponse, error) {
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = constant.DefaultInvitationPageSize
+	}
+	if pageSize > constant.MaxInvitationPageSize {
+		pageSize = constant.MaxInvitationPageSize
+	}
+
+	params := &repository.ListInvitationsParams{Limit: pageSize + 1}
+	if req.PageToken != "" {
+		cursor, err := decodeInvitationCursor(req.PageToken)
+		if err != nil {
+			return nil, grpcerror.NewInvalidPageTokenError()
+		}
+		afterCreatedAt, err := time.Parse(time.RFC3339Nano, cursor.CreatedAt)
+		if err != nil || cursor.ID == "" {
+			return nil, grpcerror.NewInvalidPageTokenError()
+		}
+		params.AfterCreatedAt = afterCreatedAt
+		params.AfterID = cursor.ID
+	}
+
+	var invitations []*entity.Invitation
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		invitations, err = ds.InvitationRepository().List(ctx, params)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.ListInvitationsResponse{
+		Invitations: make([]*dto.InvitationResponse, 0, len(invitations)),
+	}
+	if len(invitations) > pageSize {
+		invitations = invitations[:pageSize]
+		res.NextPageToken = encodeInvitationCursor(invitations[len(invitations)-1])
+	}
+
+	now := time.Now().UTC()
+	for _, invitation := range invitations {
+		res.Invitations = append(res.Invitations, dto.ToInvitationResponse(invitation, now))
+	}
+	return res, nil
+}
+
+func (u *authUseCaseImpl) RevokeInvitation(ctx context.Context, req *dto.RevokeInvitationRequest) (*dto.InvitationResponse, error) {
+	now := time.Now().UTC()
+	res := new(dto.InvitationResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		invitationRepository := ds.InvitationRepository()
+
+		invitation, err := invitationRepository.GetByIDForUpdate(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if invitation == nil {
+			return grpcerror.NewInvitationNotFoundError()
+		}
+
+		revoked, err := invitationRepository.Revoke(ctx, invitation.ID, now)
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			return grpcerror.NewInvitationClosedError()
+		}
+		invitation.RevokedAt = &now
+
+		if err := recordInvitationAudit(ctx, ds, constant.AuditOperationRevokeInvitation, invitation); err != nil {
+			return err
+		}
+
+		res = dto.ToInvitationResponse(invitation, now)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// AcceptInvitation creates the invitee's account and signs them in.
+//
+// The user row lives in the user service and is created before the
+// credentials commit here. If that commit fails the invitation stays open,
+// and accepting it again adopts the user that was already created instead
+// of creating another, so a retry always converges on one account with the
+// invited permissions.
+func (u *authUseCaseImpl) AcceptInvitation(ctx context.Context, req *dto.AcceptInvitationRequest) (*dto.LoginResponse, error) {
+	invitation, err := u.verifyInvitationToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if !invitation.IsPending(time.Now()) {
+		return nil, grpcerror.NewInvitationClosedError()
+	}
+
+	hashedPassword, err := u.hasher.Hash(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.invitedUser(ctx, invitation, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		invitationRepository := ds.InvitationRepository()
+		authRepository := ds.AuthRepository()
+
+		// Re-read under lock: a concurrent acceptance or a revocation may
+		// have closed it since the token was checked.
+		locked, err := invitationRepository.GetByIDForUpdate(ctx, invitation.ID)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		if locked == nil || !locked.IsPending(now) {
+			return grpcerror.NewInvitationClosedError()
+		}
+
+		existing, err := authRepository.GetByID(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return grpcerror.NewUserExistsError()
+		}
+
+		userAuth := &entity.UserAuth{
+			ID:             user.ID,
+			HashedPassword: hashedPassword,
+			Permissions:    locked.Permissions,
+		}
+		if err := authRepository.Create(ctx, userAuth); err != nil {
+			return err
+		}
+		if _, err := invitationRepository.MarkAccepted(ctx, locked.ID, user.ID, now); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, ds, constant.AuditOperationAcceptInvitation, user.ID, nil, userAuth)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := u.issueTokens(ctx, user.ID, user.Username, []string{jwtutils.AMRPassword})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresAt:    token.ExpiresAt.Unix(),
+		UserID:       token.UserID,
+	}, nil
+}
+
+// invitedUser returns the user the invitation is for, creating it in the
+// user service unless an earlier attempt already did.
+func (u *authUseCaseImpl) invitedUser(ctx context.Context, invitation *entity.Invitation, req *dto.AcceptInvitationRequest) (*client.User, error) {
+	user, err := u.userClient.GetUserByEmail(ctx, invitation.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		if user.Status != constant.UserStatusActive {
+			return nil, grpcerror.NewAccountInactiveError(user.Status)
+		}
+		return user, nil
+	}
+
+	firstName := strings.TrimSpace(req.FirstName)
+	if firstName == "" {
+		firstName = invitation.FirstName
+	}
+	lastName := strings.TrimSpace(req.LastName)
+	if lastName == "" {
+		lastName = invitation.LastName
+	}
+	return u.userClient.CreateUser(ctx, invitation.Email, firstName, lastName)
+}
+
+// verifyInvitationToken returns the invitation a token was issued for. The
+// signature covers the invitation's email and expiry as stored, so a token
+// cannot be reused for a reissued invitation or outlive its own.
+func (u *authUseCaseImpl) verifyInvitationToken(ctx context.Context, token string) (*entity.Invitation, error) {
+	id, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, grpcerror.NewInvalidInvitationTokenError()
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, grpcerror.NewInvalidInvitationTokenError()
+	}
+
+	var invitation *entity.Invitation
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		invitation, err = ds.InvitationRepository().GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil || !hmac.Equal([]byte(signature), []byte(u.signInvitation(invitation))) {
+		return nil, grpcerror.NewInvalidInvitationTokenError()
+	}
+	return invitation, nil
+}
+
+func (u *authUseCaseImpl) invitationToken(invitation *entity.Invitation) string {
+	return invitation.ID + "." + u.signInvitation(invitation)
+}
+
+func (u *authUseCaseImpl) signInvitation(invitation *entity.Invitation) string {
+	mac := hmac.New(sha256.New, []byte(u.invitations.Secret))
+	mac.Write([]byte(invitation.ID))
+	mac.Write([]byte("."))
+	mac.Write([]byte(invitation.Email))
+	mac.Write([]byte("."))
+	mac.Write([]byte(strconv.FormatInt(invitation.ExpiresAt.Unix(), 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (u *authUseCaseImpl) invitationLink(invitation *entity.Invitation) string {
+	return fmt.Sprintf("%s?token=%s", u.invitations.AcceptURL, url.QueryEscape(u.invitationToken(invitation)))
+}
+
+// recordInvitationAudit records an invitation change. The invitation is
+// the target, so its email stays out of the event.
+func recordInvitationAudit(ctx context.Context, ds repository.DataStore, operation string, invitation *entity.Invitation) error {
+	event := &audit.Event{
+		ID:        uuid.New().String(),
+		TargetID:  invitation.ID,
+		Operation: operation,
+		RequestID: interceptor.RequestIDFromContext(ctx),
+		SourceIP:  interceptor.SourceIPFromContext(ctx),
+		Metadata: map[string]any{
+			"permissions": invitation.Permissions,
+		},
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		event.ActorID = claims.UserID
+		if claims.IsImpersonation() {
+			event.ImpersonatorID = claims.Actor.Subject
+		}
+	}
+
+	return ds.AuditRepository().Record(ctx, event)
+}
+
+func encodeInvitationCursor(invitation *entity.Invitation) string {
+	data, _ := json.Marshal(invitationCursor{
+		CreatedAt: invitation.CreatedAt.UTC().Format(time.RFC3339Nano),
+		ID:        invitation.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeInvitationCursor(token string) (*invitationCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := &invitationCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateInvitationRejectsMalformedEmail(t *testing.T) {
+	users := &fakeUserClient{}
+	usecase := &authUseCaseImpl{dataStore: newFakeDataStore(), userClient: users}
+	ctx := interceptor.ContextWithClaims(context.Background(), &jwtutils.JWTClaims{UserID: "admin", TokenType: "access"})
+
+	for _, email := range []string{"", "  ", "not-an-address", "Ada <ada@example.com>", "ada@example.com, eve@example.com"} {
+		_, err := usecase.CreateInvitation(ctx, &dto.CreateInvitationRequest{Email: email})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateInvitation(%q): %v, want InvalidArgument", email, err)
+		}
+	}
+	if users.lookups != 0 {
+		t.Errorf("looked up %d users for malformed addresses, want none", users.lookups)
+	}
+}
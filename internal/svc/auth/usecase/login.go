@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/hailsayan/achilles/internal/pkg/events"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/client"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/auth/repository"
+)
+
+func (u *authUseCaseImpl) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
+	user, err := u.findLoginUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, grpcerror.NewInvalidCredentialsError()
+	}
+
+	userAuth, err := u.getUserAuth(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if userAuth == nil || userAuth.HashedPassword == "" || !u.hasher.Check(req.Password, userAuth.HashedPassword) {
+		return nil, grpcerror.NewInvalidCredentialsError()
+	}
+
+	// The user service owns the status; check it only after the password so
+	// the response does not reveal the state of accounts to guessers.
+	if user.Status != constant.UserStatusActive {
+		return nil, grpcerror.NewAccountInactiveError(user.Status)
+	}
+
+	token, err := u.issueTokens(ctx, user.ID, user.Username, []string{jwtutils.AMRPassword})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresAt:    token.ExpiresAt.Unix(),
+		UserID:       token.UserID,
+	}, nil
+}
+
+// findLoginUser looks the user up by username or email. An identifier
+// without an @ cannot be an email, so it is taken as a username; usernames
+// cannot contain one.
+func (u *authUseCaseImpl) findLoginUser(ctx context.Context, req *dto.LoginRequest) (*client.User, error) {
+	identifier := strings.TrimSpace(req.Username)
+	if identifier == "" {
+		identifier = strings.TrimSpace(req.Email)
+		if strings.Contains(identifier, "@") {
+			return u.userClient.GetUserByEmail(ctx, strings.ToLower(identifier))
+		}
+	}
+	if identifier == "" {
+		return nil, nil
+	}
+	return u.userClient.GetUserByUsername(ctx, identifier)
+}
+
+func (u *authUseCaseImpl) ValidateToken(ctx context.Context, req *dto.ValidateTokenRequest) (*dto.ValidateTokenResponse, error) {
+	claims, err := u.jwtUtil.ValidateToken(req.Token)
+	if err != nil || claims.TokenType != "access" {
+		return &dto.ValidateTokenResponse{IsValid: false}, nil
+	}
+
+	userAuth, err := u.getUserAuth(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if userAuth == nil || userAuth.Status != constant.UserStatusActive {
+		return &dto.ValidateTokenResponse{IsValid: false}, nil
+	}
+
+	return &dto.ValidateTokenResponse{
+		IsValid: true,
+		UserID:  claims.UserID,
+	}, nil
+}
+
+func (u *authUseCaseImpl) Logout(ctx context.Context, req *dto.LogoutRequest) (*dto.LogoutResponse, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+	if claims.UserID != req.UserID {
+		return nil, grpcerror.NewPermissionDeniedError()
+	}
+
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		tokenRepository := ds.TokenRepository()
+
+		stored, err := tokenRepository.GetRefreshToken(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if stored == "" || subtle.ConstantTimeCompare([]byte(stored), []byte(req.RefreshToken)) != 1 {
+			return grpcerror.NewInvalidRefreshTokenError()
+		}
+
+		if err := recordAudit(ctx, ds, constant.AuditOperationLogout, req.UserID, nil, nil); err != nil {
+			return err
+		}
+
+		// Redis is outside the transaction, so the token goes last: if the
+		// delete fails the audit event rolls back with it.
+		return tokenRepository.DeleteRefreshToken(ctx, req.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LogoutResponse{
+		Success: true,
+		Message: constant.LoggedOutSuccessfully,
+	}, nil
+}
+
+func (u *authUseCaseImpl) ApplyUserStatus(ctx context.Context, event *events.UserStatusChangedEvent) error {
+	return u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		if err := ds.AuthRepository().UpdateStatus(ctx, event.UserID, event.NewStatus); err != nil {
+			return err
+		}
+
+		if event.NewStatus != constant.UserStatusActive {
+			return ds.TokenRepository().DeleteRefreshToken(ctx, event.UserID)
+		}
+		return nil
+	})
+}
+
+// ApplyUserLifecycle mirrors soft deletion, restoration and purging of a
+// user. A deleted user keeps their credentials but cannot sign in until
+// restored; a purged user's credentials and linked identities are removed.
+func (u *authUseCaseImpl) ApplyUserLifecycle(ctx context.Context, event *events.UserLifecycleEvent) error {
+	return u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		switch event.Type {
+		case events.UserLifecycleDeleted:
+			if err := ds.AuthRepository().UpdateStatus(ctx, event.UserID, constant.UserStatusDeleted); err != nil {
+				return err
+			}
+			return ds.TokenRepository().DeleteRefreshToken(ctx, event.UserID)
+		case events.UserLifecycleRestored:
+			return ds.AuthRepository().UpdateStatus(ctx, event.UserID, event.Status)
+		case events.UserLifecyclePurged:
+			if err := ds.TokenRepository().DeleteRefreshToken(ctx, event.UserID); err != nil {
+				return err
+			}
+			return ds.AuthRepository().Delete(ctx, event.UserID)
+		}
+		return nil
+	})
+}
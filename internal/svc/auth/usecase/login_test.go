@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/client"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginInNonDefaultTenant(t *testing.T) {
+	dataStore := newFakeDataStore()
+	dataStore.auth.Create(context.Background(), &entity.UserAuth{ID: "user-1", HashedPassword: "hashed:secret"})
+	jwtUtil := jwtutils.NewJwtUtil(&jwtutils.JwtConfig{
+		AccessTokenDuration:  15,
+		RefreshTokenDuration: 60,
+		SecretKey:            "test-secret",
+		Issuer:               "achilles",
+	})
+	usecase := &authUseCaseImpl{
+		dataStore: dataStore,
+		jwtUtil:   jwtUtil,
+		hasher:    fakeHasher{},
+		userClient: &fakeUserClient{
+			users:   []*client.User{{ID: "user-1", Email: "ada@acme.example", Status: constant.UserStatusActive}},
+			tenants: map[string]string{"user-1": "acme"},
+		},
+	}
+	req := &dto.LoginRequest{Email: "ada@acme.example", Password: "secret"}
+
+	_, err := usecase.Login(tenant.WithTenant(context.Background(), tenant.DefaultID), req)
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Fatalf("Login in the default tenant: code = %v, want Unauthenticated", code)
+	}
+
+	res, err := usecase.Login(tenant.WithTenant(context.Background(), "acme"), req)
+	if err != nil {
+		t.Fatalf("Login in acme: %v", err)
+	}
+	claims, err := jwtUtil.ValidateToken(res.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if claims.TenantID != "acme" || claims.UserID != "user-1" {
+		t.Errorf("access token for %q in tenant %q, want user-1 in acme", claims.UserID, claims.TenantID)
+	}
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/grpcerror"
+)
+
+// SwitchOrganization reissues the caller's access token scoped to another
+// organization, or to none. The user service confirms the membership under
+// the caller's own token; authentication time and methods carry over so
+// switching does not count as signing in again.
+func (u *authUseCaseImpl) SwitchOrganization(ctx context.Context, req *dto.SwitchOrganizationRequest) (*dto.SwitchOrganizationResponse, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+	if claims.IsImpersonation() {
+		return nil, grpcerror.NewImpersonationSwitchError()
+	}
+
+	userAuth, err := u.getUserAuth(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if userAuth == nil {
+		return nil, grpcerror.NewInvalidCredentialsError()
+	}
+
+	opts := &jwtutils.AccessTokenOptions{
+		Permissions: userAuth.Permissions,
+		AMR:         claims.AMR,
+		TenantID:    tenant.FromContext(ctx),
+	}
+	if claims.AuthTime != nil {
+		opts.AuthTime = claims.AuthTime.Time
+	}
+	if req.OrganizationID != "" {
+		membership, err := u.userClient.GetMembership(interceptor.ForwardAuthorization(ctx), req.OrganizationID, claims.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if membership == nil {
+			return nil, grpcerror.NewOrganizationNotFoundError()
+		}
+		opts.OrgID = membership.OrganizationID
+		opts.OrgRole = membership.Role
+	}
+
+	accessToken, expiresAt, err := u.jwtUtil.GenerateAccessToken(userAuth.ID, claims.Username, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SwitchOrganizationResponse{
+		AccessToken:    accessToken,
+		ExpiresAt:      expiresAt.Unix(),
+		OrganizationID: opts.OrgID,
+		Role:           opts.OrgRole,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/auth/repository"
+)
+
+// ChangePassword replaces the password and revokes the refresh token, so
+// other sessions must sign in again with the new one.
+func (u *authUseCaseImpl) ChangePassword(ctx context.Context, req *dto.ChangePasswordRequest) (*dto.ChangePasswordResponse, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+	if claims.IsImpersonation() || claims.UserID != req.UserID {
+		return nil, grpcerror.NewPermissionDeniedError()
+	}
+
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		authRepository := ds.AuthRepository()
+
+		userAuth, err := authRepository.GetByID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if userAuth == nil {
+			return grpcerror.NewUserNotFoundError()
+		}
+		if userAuth.HashedPassword == "" || !u.hasher.Check(req.OldPassword, userAuth.HashedPassword) {
+			return grpcerror.NewInvalidCredentialsError()
+		}
+
+		hashedPassword, err := u.hasher.Hash(req.NewPassword)
+		if err != nil {
+			return err
+		}
+		if err := authRepository.UpdatePassword(ctx, req.UserID, hashedPassword); err != nil {
+			return err
+		}
+
+		updated := *userAuth
+		updated.HashedPassword = hashedPassword
+		if err := recordAudit(ctx, ds, constant.AuditOperationChangePassword, req.UserID, userAuth, &updated); err != nil {
+			return err
+		}
+
+		return ds.TokenRepository().DeleteRefreshToken(ctx, req.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ChangePasswordResponse{
+		Success: true,
+		Message: constant.PasswordChangedSuccessfully,
+	}, nil
+}
+
+// RecoverAccount lets a signed-out user set a new password after proving
+// ownership of the account's verified phone with a recovery code. Unknown
+// emails and wrong codes fail alike. Like ChangePassword it revokes the
+// refresh token.
+func (u *authUseCaseImpl) RecoverAccount(ctx context.Context, req *dto.RecoverAccountRequest) (*dto.RecoverAccountResponse, error) {
+	user, err := u.userClient.GetUserByEmail(ctx, strings.ToLower(strings.TrimSpace(req.Email)))
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status != constant.UserStatusActive {
+		return nil, grpcerror.NewInvalidCredentialsError()
+	}
+
+	ok, err := u.userClient.ConfirmPhoneCode(ctx, user.ID, constant.PhonePurposeRecovery, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, grpcerror.NewInvalidCredentialsError()
+	}
+
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		authRepository := ds.AuthRepository()
+
+		userAuth, err := authRepository.GetByID(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if userAuth == nil {
+			return grpcerror.NewInvalidCredentialsError()
+		}
+
+		hashedPassword, err := u.hasher.Hash(req.NewPassword)
+		if err != nil {
+			return err
+		}
+		if err := authRepository.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+			return err
+		}
+
+		updated := *userAuth
+		updated.HashedPassword = hashedPassword
+		if err := recordAudit(ctx, ds, constant.AuditOperationRecoverAccount, user.ID, userAuth, &updated); err != nil {
+			return err
+		}
+
+		return ds.TokenRepository().DeleteRefreshToken(ctx, user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoverAccountResponse{
+		Success: true,
+		Message: constant.AccountRecoveredSuccessfully,
+	}, nil
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/pkg/events"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"github.com/hailsayan/achilles/internal/svc/auth/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/auth/repository"
+)
+
+func (u *authUseCaseImpl) ExportUserData(ctx context.Context, req *dto.ExportUserDataRequest) (*dto.UserDataExport, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+	// The user service runs exports in the background under its own
+	// service identity, after authorizing the user who asked for them.
+	if !claims.IsService() && claims.UserID != req.UserID && !claims.HasPermission(constant.PermissionExportUserData) {
+		return nil, grpcerror.NewPermissionDeniedError()
+	}
+
+	var userAuth *entity.UserAuth
+	var identities []*entity.Identity
+	var auditEvents []*audit.Event
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		userAuth, err = ds.AuthRepository().GetByID(ctx, req.UserID)
+		if err != nil || userAuth == nil {
+			return err
+		}
+		identities, err = ds.IdentityRepository().ListByUserID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		auditEvents, err = ds.AuditRepository().ListByUser(ctx, req.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userAuth == nil {
+		return nil, grpcerror.NewUserNotFoundError()
+	}
+
+	ttl, err := u.dataStore.TokenRepository().GetRefreshTokenTTL(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.UserDataExport{
+		UserID:       userAuth.ID,
+		Status:       userAuth.Status,
+		Permissions:  userAuth.Permissions,
+		HasPassword:  userAuth.HashedPassword != "",
+		TOTPEnabled:  userAuth.TOTPSecret != "",
+		Identities:   make([]*dto.IdentityResponse, 0, len(identities)),
+		Session:      &dto.SessionInfo{Active: ttl > 0},
+		LoginHistory: []*audit.Event{},
+		AuditEvents:  []*audit.Event{},
+	}
+	if ttl > 0 {
+		res.Session.ExpiresAt = time.Now().Add(ttl).Unix()
+	}
+	for _, identity := range identities {
+		res.Identities = append(res.Identities, dto.ToIdentityResponse(identity))
+	}
+	for _, event := range auditEvents {
+		if event.Operation == constant.AuditOperationLogin {
+			res.LoginHistory = append(res.LoginHistory, event)
+			continue
+		}
+		res.AuditEvents = append(res.AuditEvents, event)
+	}
+
+	return res, nil
+}
+
+// EraseUserData drops the user's credentials, linked identities and session
+// and anonymizes their audit trail, then confirms to the user service. It is
+// safe to repeat, so a redelivered request is confirmed again.
+func (u *authUseCaseImpl) EraseUserData(ctx context.Context, event *events.UserErasureRequestedEvent) error {
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		if err := ds.TokenRepository().DeleteRefreshToken(ctx, event.UserID); err != nil {
+			return err
+		}
+		if err := ds.AuditRepository().AnonymizeByUser(ctx, event.UserID); err != nil {
+			return err
+		}
+		// An accepted invitation still names the invitee's email.
+		if err := ds.InvitationRepository().DeleteByAcceptedUserID(ctx, event.UserID); err != nil {
+			return err
+		}
+		return ds.AuthRepository().Delete(ctx, event.UserID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.erasureProducer.Send(ctx, &events.UserErasureConfirmedEvent{
+		RequestID:  event.RequestID,
+		UserID:     event.UserID,
+		Service:    events.ErasureServiceAuth,
+		TenantID:   event.TenantID,
+		OccurredAt: time.Now().UTC(),
+	})
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestExportUserDataAuthorization(t *testing.T) {
+	dataStore := newFakeDataStore()
+	dataStore.auth.Create(context.Background(), &entity.UserAuth{ID: "user-1"})
+	usecase := &authUseCaseImpl{dataStore: dataStore}
+
+	tests := []struct {
+		name   string
+		claims *jwtutils.JWTClaims
+		code   codes.Code
+	}{
+		{name: "self", claims: &jwtutils.JWTClaims{UserID: "user-1", TokenType: "access"}, code: codes.OK},
+		{name: "other user", claims: &jwtutils.JWTClaims{UserID: "user-2", TokenType: "access"}, code: codes.PermissionDenied},
+		{name: "service", claims: &jwtutils.JWTClaims{TokenType: "service"}, code: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := interceptor.ContextWithClaims(context.Background(), tt.claims)
+			res, err := usecase.ExportUserData(ctx, &dto.ExportUserDataRequest{UserID: "user-1"})
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("ExportUserData code = %v, want %v (err %v)", code, tt.code, err)
+			}
+			if err == nil && res.UserID != "user-1" {
+				t.Fatalf("ExportUserData UserID = %q, want user-1", res.UserID)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/pkg/utils/totputils"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/grpcerror"
+)
+
+func (u *authUseCaseImpl) Reauthenticate(ctx context.Context, req *dto.ReauthenticateRequest) (*dto.ReauthenticateResponse, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+	if claims.IsImpersonation() {
+		return nil, grpcerror.NewImpersonationStepUpError()
+	}
+	if req.Password == "" && req.TOTPCode == "" && req.SMSCode == "" {
+		return nil, grpcerror.NewCredentialRequiredError()
+	}
+
+	// Every attempt counts until one succeeds, so guesses made in parallel
+	// cannot get past the limit.
+	reauthRepository := u.dataStore.ReauthRepository()
+	attempts, err := reauthRepository.IncrAttempts(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if attempts > constant.ReauthMaxAttempts {
+		return nil, grpcerror.NewReauthLockedError()
+	}
+
+	userAuth, err := u.getUserAuth(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if userAuth == nil {
+		return nil, grpcerror.NewInvalidCredentialsError()
+	}
+
+	amr := []string{}
+	if req.Password != "" {
+		if userAuth.HashedPassword == "" || !u.hasher.Check(req.Password, userAuth.HashedPassword) {
+			return nil, grpcerror.NewInvalidCredentialsError()
+		}
+		amr = append(amr, jwtutils.AMRPassword)
+	}
+	if req.TOTPCode != "" {
+		if userAuth.TOTPSecret == "" {
+			return nil, grpcerror.NewTOTPNotEnrolledError()
+		}
+		step, ok := totputils.Match(userAuth.TOTPSecret, req.TOTPCode, time.Now())
+		if !ok {
+			return nil, grpcerror.NewInvalidCredentialsError()
+		}
+		// A code seen once, for example over the user's shoulder, cannot
+		// be replayed while it is still valid.
+		unused, err := reauthRepository.UseTOTPStep(ctx, userAuth.ID, step)
+		if err != nil {
+			return nil, err
+		}
+		if !unused {
+			return nil, grpcerror.NewInvalidCredentialsError()
+		}
+		amr = append(amr, jwtutils.AMROTP)
+	}
+	if req.SMSCode != "" {
+		// The user service checks the code against the user's verified
+		// phone, under the caller's own token.
+		ok, err := u.userClient.ConfirmPhoneCode(interceptor.ForwardAuthorization(ctx), userAuth.ID, constant.PhonePurposeSecondFactor, req.SMSCode)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, grpcerror.NewInvalidCredentialsError()
+		}
+		amr = append(amr, jwtutils.AMRSMS)
+	}
+
+	if err := reauthRepository.ResetAttempts(ctx, userAuth.ID); err != nil {
+		return nil, err
+	}
+
+	accessToken, expiresAt, err := u.jwtUtil.GenerateAccessToken(userAuth.ID, claims.Username, &jwtutils.AccessTokenOptions{
+		Permissions: userAuth.Permissions,
+		AMR:         amr,
+		AuthTime:    time.Now(),
+		OrgID:       claims.OrgID,
+		OrgRole:     claims.OrgRole,
+		TenantID:    tenant.FromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ReauthenticateResponse{
+		AccessToken: accessToken,
+		ExpiresAt:   expiresAt.Unix(),
+		ACR:         jwtutils.ACRForAMR(amr),
+		AMR:         amr,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const reauthTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func newReauthFixture(t *testing.T) (*authUseCaseImpl, context.Context) {
+	t.Helper()
+
+	dataStore := newFakeDataStore()
+	dataStore.auth.Create(context.Background(), &entity.UserAuth{
+		ID:             "user-1",
+		HashedPassword: "hashed:correct",
+		TOTPSecret:     reauthTOTPSecret,
+	})
+	usecase := &authUseCaseImpl{
+		dataStore: dataStore,
+		hasher:    fakeHasher{},
+		jwtUtil: jwtutils.NewJwtUtil(&jwtutils.JwtConfig{
+			AccessTokenDuration:  15,
+			RefreshTokenDuration: 60,
+			SecretKey:            "test-secret",
+			Issuer:               "achilles",
+		}),
+	}
+	ctx := interceptor.ContextWithClaims(context.Background(), &jwtutils.JWTClaims{UserID: "user-1", TokenType: "access"})
+	return usecase, ctx
+}
+
+// totpCode computes the RFC 6238 code for secret at the given time.
+func totpCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("decode secret: %v", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(at.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	return fmt.Sprintf("%06d", (binary.BigEndian.Uint32(sum[offset:offset+4])&0x7fffffff)%1000000)
+}
+
+func TestReauthenticateLocksOutAfterRepeatedFailures(t *testing.T) {
+	usecase, ctx := newReauthFixture(t)
+
+	for i := 0; i < constant.ReauthMaxAttempts; i++ {
+		_, err := usecase.Reauthenticate(ctx, &dto.ReauthenticateRequest{Password: "wrong"})
+		if code := status.Code(err); code != codes.Unauthenticated {
+			t.Fatalf("attempt %d: code = %v, want Unauthenticated", i+1, code)
+		}
+	}
+
+	_, err := usecase.Reauthenticate(ctx, &dto.ReauthenticateRequest{Password: "correct"})
+	if code := status.Code(err); code != codes.ResourceExhausted {
+		t.Fatalf("after %d failures: code = %v, want ResourceExhausted", constant.ReauthMaxAttempts, code)
+	}
+}
+
+func TestReauthenticateResetsAttemptsOnSuccess(t *testing.T) {
+	usecase, ctx := newReauthFixture(t)
+
+	for round := 0; round < 2; round++ {
+		for i := 0; i < constant.ReauthMaxAttempts-1; i++ {
+			usecase.Reauthenticate(ctx, &dto.ReauthenticateRequest{Password: "wrong"})
+		}
+		if _, err := usecase.Reauthenticate(ctx, &dto.ReauthenticateRequest{Password: "correct"}); err != nil {
+			t.Fatalf("round %d: Reauthenticate: %v", round, err)
+		}
+	}
+}
+
+func TestReauthenticateRejectsReusedTOTPCode(t *testing.T) {
+	usecase, ctx := newReauthFixture(t)
+	code := totpCode(t, reauthTOTPSecret, time.Now())
+
+	res, err := usecase.Reauthenticate(ctx, &dto.ReauthenticateRequest{TOTPCode: code})
+	if err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if len(res.AMR) != 1 || res.AMR[0] != jwtutils.AMROTP {
+		t.Fatalf("AMR = %v, want [%s]", res.AMR, jwtutils.AMROTP)
+	}
+
+	_, err = usecase.Reauthenticate(ctx, &dto.ReauthenticateRequest{TOTPCode: code})
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Fatalf("second use: code = %v, want Unauthenticated", code)
+	}
+}
@@ -1 +1,174 @@
 package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/pkg/events"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/mq"
+	"github.com/hailsayan/achilles/internal/pkg/oidc"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/encryptutils"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/auth/client"
+	"github.com/hailsayan/achilles/internal/svc/auth/constant"
+	"github.com/hailsayan/achilles/internal/svc/auth/dto"
+	"github.com/hailsayan/achilles/internal/svc/auth/entity"
+	"github.com/hailsayan/achilles/internal/svc/auth/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/auth/repository"
+)
+
+type AuthUseCase interface {
+	Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error)
+	Logout(ctx context.Context, req *dto.LogoutRequest) (*dto.LogoutResponse, error)
+	ChangePassword(ctx context.Context, req *dto.ChangePasswordRequest) (*dto.ChangePasswordResponse, error)
+	RecoverAccount(ctx context.Context, req *dto.RecoverAccountRequest) (*dto.RecoverAccountResponse, error)
+	ListAuditEvents(ctx context.Context, req *dto.ListAuditEventsRequest) (*dto.ListAuditEventsResponse, error)
+	ValidateToken(ctx context.Context, req *dto.ValidateTokenRequest) (*dto.ValidateTokenResponse, error)
+	ApplyUserStatus(ctx context.Context, event *events.UserStatusChangedEvent) error
+	ApplyUserLifecycle(ctx context.Context, event *events.UserLifecycleEvent) error
+	ExportUserData(ctx context.Context, req *dto.ExportUserDataRequest) (*dto.UserDataExport, error)
+	EraseUserData(ctx context.Context, event *events.UserErasureRequestedEvent) error
+	StartDeviceAuthorization(ctx context.Context, req *dto.StartDeviceAuthorizationRequest) (*dto.StartDeviceAuthorizationResponse, error)
+	VerifyDeviceCode(ctx context.Context, req *dto.VerifyDeviceCodeRequest) (*dto.VerifyDeviceCodeResponse, error)
+	PollDeviceToken(ctx context.Context, req *dto.PollDeviceTokenRequest) (*dto.PollDeviceTokenResponse, error)
+	StartFederatedLogin(ctx context.Context, req *dto.StartFederatedLoginRequest) (*dto.StartFederatedLoginResponse, error)
+	CompleteFederatedLogin(ctx context.Context, req *dto.CompleteFederatedLoginRequest) (*dto.LoginResponse, error)
+	ListIdentities(ctx context.Context, req *dto.ListIdentitiesRequest) (*dto.ListIdentitiesResponse, error)
+	UnlinkIdentity(ctx context.Context, req *dto.UnlinkIdentityRequest) (*dto.UnlinkIdentityResponse, error)
+	Impersonate(ctx context.Context, req *dto.ImpersonateRequest) (*dto.ImpersonateResponse, error)
+	Reauthenticate(ctx context.Context, req *dto.ReauthenticateRequest) (*dto.ReauthenticateResponse, error)
+	SwitchOrganization(ctx context.Context, req *dto.SwitchOrganizationRequest) (*dto.SwitchOrganizationResponse, error)
+	CreateInvitation(ctx context.Context, req *dto.CreateInvitationRequest) (*dto.InvitationResponse, error)
+	ListInvitations(ctx context.Context, req *dto.ListInvitationsRequest) (*dto.ListInvitationsResponse, error)
+	RevokeInvitation(ctx context.Context, req *dto.RevokeInvitationRequest) (*dto.InvitationResponse, error)
+	AcceptInvitation(ctx context.Context, req *dto.AcceptInvitationRequest) (*dto.LoginResponse, error)
+}
+
+type authUseCaseImpl struct {
+	dataStore       repository.DataStore
+	jwtUtil         jwtutils.JwtUtil
+	hasher          encryptutils.Hasher
+	userClient      client.UserClient
+	providers       map[string]*oidc.Provider
+	verificationURI string
+	erasureProducer mq.KafkaProducer
+	invitations     InvitationConfig
+}
+
+func NewAuthUseCase(
+	dataStore repository.DataStore,
+	jwtUtil jwtutils.JwtUtil,
+	hasher encryptutils.Hasher,
+	userClient client.UserClient,
+	providers []*oidc.Provider,
+	verificationURI string,
+	erasureProducer mq.KafkaProducer,
+	invitations InvitationConfig,
+) (AuthUseCase, error) {
+	if len(invitations.Secret) < constant.MinInvitationSecretBytes {
+		return nil, fmt.Errorf("auth: invitation secret must be at least %d bytes", constant.MinInvitationSecretBytes)
+	}
+	if invitations.TTL <= 0 {
+		invitations.TTL = constant.DefaultInvitationTTL
+	}
+
+	providerMap := make(map[string]*oidc.Provider, len(providers))
+	for _, provider := range providers {
+		providerMap[provider.Name()] = provider
+	}
+
+	return &authUseCaseImpl{
+		dataStore:       dataStore,
+		jwtUtil:         jwtUtil,
+		hasher:          hasher,
+		userClient:      userClient,
+		providers:       providerMap,
+		verificationURI: verificationURI,
+		erasureProducer: erasureProducer,
+		invitations:     invitations,
+	}, nil
+}
+
+// usernameOf returns the username to carry in the user's tokens, which is
+// none for a user without one.
+func (u *authUseCaseImpl) usernameOf(ctx context.Context, userID string) (string, error) {
+	user, err := u.userClient.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", nil
+	}
+	return user.Username, nil
+}
+
+// getUserAuth reads a user's credentials outside of any larger transaction.
+func (u *authUseCaseImpl) getUserAuth(ctx context.Context, userID string) (*entity.UserAuth, error) {
+	var userAuth *entity.UserAuth
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		userAuth, err = ds.AuthRepository().GetByID(ctx, userID)
+		return err
+	})
+	return userAuth, err
+}
+
+func (u *authUseCaseImpl) issueTokens(ctx context.Context, userID, username string, amr []string) (*entity.Token, error) {
+	userAuth, err := u.getUserAuth(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userAuth == nil {
+		return nil, grpcerror.NewUserNotFoundError()
+	}
+	if userAuth.Status != constant.UserStatusActive {
+		return nil, grpcerror.NewAccountInactiveError(userAuth.Status)
+	}
+
+	accessToken, expiresAt, err := u.jwtUtil.GenerateAccessToken(userID, username, &jwtutils.AccessTokenOptions{
+		Permissions: userAuth.Permissions,
+		AMR:         amr,
+		TenantID:    tenant.FromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := u.jwtUtil.GenerateRefreshToken(userID, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	tokenRepository := u.dataStore.TokenRepository()
+	if err := tokenRepository.StoreRefreshToken(ctx, userID, refreshToken, u.jwtUtil.GetRefreshTokenDuration()); err != nil {
+		return nil, err
+	}
+
+	// Every sign-in path ends here, so this is the login history kept for
+	// data exports.
+	if err := repository.NewAuditRecorder(u.dataStore).Record(ctx, &audit.Event{
+		ID:        uuid.New().String(),
+		ActorID:   userID,
+		TargetID:  userID,
+		Operation: constant.AuditOperationLogin,
+		RequestID: interceptor.RequestIDFromContext(ctx),
+		SourceIP:  interceptor.SourceIPFromContext(ctx),
+		Metadata: map[string]any{
+			"amr": amr,
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	return &entity.Token{
+		UserID:       userID,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
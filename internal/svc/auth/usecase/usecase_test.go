@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewAuthUseCaseRequiresInvitationSecret(t *testing.T) {
+	for _, secret := range []string{"", strings.Repeat("s", 31)} {
+		if _, err := NewAuthUseCase(nil, nil, nil, nil, nil, "", nil, InvitationConfig{Secret: secret}); err == nil {
+			t.Errorf("NewAuthUseCase with a %d byte secret: want error", len(secret))
+		}
+	}
+
+	if _, err := NewAuthUseCase(nil, nil, nil, nil, nil, "", nil, InvitationConfig{Secret: strings.Repeat("s", 32)}); err != nil {
+		t.Errorf("NewAuthUseCase with a 32 byte secret: %v", err)
+	}
+}
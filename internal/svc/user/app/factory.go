@@ -2,35 +2,85 @@ package factory
 
 import (
 	"database/sql"
+	"net/http"
 
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/logger"
+	"github.com/hailsayan/achilles/internal/pkg/mq"
+	"github.com/hailsayan/achilles/internal/pkg/sms"
+	"github.com/hailsayan/achilles/internal/svc/user/client"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
 	"github.com/hailsayan/achilles/internal/svc/user/handler"
+	pb "github.com/hailsayan/achilles/internal/svc/user/pb/user"
 	"github.com/hailsayan/achilles/internal/svc/user/repository"
 	"github.com/hailsayan/achilles/internal/svc/user/usecase"
+	"github.com/hailsayan/achilles/internal/svc/user/worker"
+	"google.golang.org/grpc"
 )
 
 type UserServiceFactory struct {
-	db        *sql.DB
-	redisRepo repository.RedisRepository
+	db                *sql.DB
+	redisRepo         repository.RedisRepository
+	authClient        client.AuthClient
+	statusProducer    mq.KafkaProducer
+	lifecycleProducer mq.KafkaProducer
+	erasureProducer   mq.KafkaProducer
+	deletion          usecase.DeletionConfig
+	avatars           usecase.AvatarConfig
+	smsSender         sms.SMSSender
+	quotas            usecase.QuotaConfig
+	operations        usecase.OperationConfig
+	invitations       usecase.InvitationConfig
 	
 	userRepo  repository.UserRepository
 	dataStore repository.DataStore
 	
-	userUseCase usecase.UserUseCase
+	userUseCase         usecase.UserUseCase
+	organizationUseCase usecase.OrganizationUseCase
 	
-	userHandler *handler.UserHandler
+	userHandler         *handler.UserHandler
+	userAdminHandler    *handler.UserAdminHandler
+	organizationHandler *handler.OrganizationHandler
+	operationHandler    *handler.OperationHandler
 }
 
-func NewUserServiceFactory(db *sql.DB, redisRepo repository.RedisRepository) *UserServiceFactory {
+func NewUserServiceFactory(
+	db *sql.DB,
+	redisRepo repository.RedisRepository,
+	authClient client.AuthClient,
+	statusProducer mq.KafkaProducer,
+	lifecycleProducer mq.KafkaProducer,
+	erasureProducer mq.KafkaProducer,
+	deletion usecase.DeletionConfig,
+	avatars usecase.AvatarConfig,
+	smsSender sms.SMSSender,
+	quotas usecase.QuotaConfig,
+	operations usecase.OperationConfig,
+	invitations usecase.InvitationConfig,
+) (*UserServiceFactory, error) {
 	factory := &UserServiceFactory{
-		db:        db,
-		redisRepo: redisRepo,
+		db:                db,
+		redisRepo:         redisRepo,
+		authClient:        authClient,
+		statusProducer:    statusProducer,
+		lifecycleProducer: lifecycleProducer,
+		erasureProducer:   erasureProducer,
+		deletion:          deletion,
+		avatars:           avatars,
+		smsSender:         smsSender,
+		quotas:            quotas,
+		operations:        operations,
+		invitations:       invitations,
 	}
 	
 	factory.initRepositories()
-	factory.initUseCases()
+	if err := factory.initUseCases(); err != nil {
+		return nil, err
+	}
 	factory.initHandlers()
 	
-	return factory
+	return factory, nil
 }
 
 func (f *UserServiceFactory) initRepositories() {
@@ -38,12 +88,21 @@ func (f *UserServiceFactory) initRepositories() {
 	f.dataStore = repository.NewDataStore(f.db)
 }
 
-func (f *UserServiceFactory) initUseCases() {
-	f.userUseCase = usecase.NewUserUseCase(f.dataStore, f.redisRepo)
+func (f *UserServiceFactory) initUseCases() error {
+	f.userUseCase = usecase.NewUserUseCase(f.dataStore, f.redisRepo, f.authClient, f.statusProducer, f.lifecycleProducer, f.erasureProducer, f.deletion, f.avatars, f.smsSender, f.quotas, f.operations)
+	organizationUseCase, err := usecase.NewOrganizationUseCase(f.dataStore, f.invitations)
+	if err != nil {
+		return err
+	}
+	f.organizationUseCase = organizationUseCase
+	return nil
 }
 
 func (f *UserServiceFactory) initHandlers() {
 	f.userHandler = handler.NewUserHandler(f.userUseCase)
+	f.userAdminHandler = handler.NewUserAdminHandler(f.userUseCase)
+	f.organizationHandler = handler.NewOrganizationHandler(f.organizationUseCase)
+	f.operationHandler = handler.NewOperationHandler(f.userUseCase)
 }
 
 func (f *UserServiceFactory) GetUserRepository() repository.UserRepository {
@@ -54,6 +113,14 @@ func (f *UserServiceFactory) GetDataStore() repository.DataStore {
 	return f.dataStore
 }
 
+func (f *UserServiceFactory) GetAuditRecorder() audit.Recorder {
+	return repository.NewAuditRecorder(f.dataStore)
+}
+
+func (f *UserServiceFactory) GetAccountChecker() interceptor.AccountChecker {
+	return repository.NewAccountChecker(f.dataStore)
+}
+
 func (f *UserServiceFactory) GetUserUseCase() usecase.UserUseCase {
 	return f.userUseCase
 }
@@ -62,6 +129,50 @@ func (f *UserServiceFactory) GetUserHandler() *handler.UserHandler {
 	return f.userHandler
 }
 
+func (f *UserServiceFactory) GetUserAdminHandler() *handler.UserAdminHandler {
+	return f.userAdminHandler
+}
+
+// NewAdminServer returns a server with only UserAdminService registered. It
+// belongs on its own listener, typically with credentials from
+// tlsutils.NewServerCredentials, so the public server never carries admin
+// RPCs.
+func (f *UserServiceFactory) NewAdminServer(opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	pb.RegisterUserAdminServiceServer(server, f.userAdminHandler)
+	return server
+}
+
+func (f *UserServiceFactory) GetOrganizationHandler() *handler.OrganizationHandler {
+	return f.organizationHandler
+}
+
+func (f *UserServiceFactory) GetOperationHandler() *handler.OperationHandler {
+	return f.operationHandler
+}
+
+func (f *UserServiceFactory) GetUserErasureConfirmedHandler() mq.KafkaHandler {
+	return handler.NewUserErasureConfirmedHandler(f.userUseCase)
+}
+
+// GetAvatarHTTPHandler serves the URLs returned by UploadAvatar and is
+// mounted at constant.AvatarHTTPPath.
+func (f *UserServiceFactory) GetAvatarHTTPHandler(log logger.Logger) http.Handler {
+	return handler.NewAvatarHTTPHandler(f.avatars.Store, log)
+}
+
+func (f *UserServiceFactory) GetPurgeWorker(log logger.Logger) *worker.PurgeWorker {
+	return worker.NewPurgeWorker(f.userUseCase, constant.DefaultPurgeInterval, log)
+}
+
+func (f *UserServiceFactory) GetUsageRollupWorker(log logger.Logger) *worker.UsageRollupWorker {
+	return worker.NewUsageRollupWorker(f.userUseCase, constant.DefaultUsageRollupInterval, log)
+}
+
+func (f *UserServiceFactory) GetOperationWorker(log logger.Logger) *worker.OperationWorker {
+	return worker.NewOperationWorker(f.userUseCase, constant.DefaultOperationWorkers, constant.DefaultOperationPollInterval, log)
+}
+
 func (f *UserServiceFactory) Close() error {
 	if f.db != nil {
 		return f.db.Close()
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	authpb "github.com/hailsayan/achilles/internal/svc/auth/pb/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Identity struct {
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Session struct {
+	Active    bool       `json:"active"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type AuditEntry struct {
+	ID             string          `json:"id"`
+	ActorID        string          `json:"actor_id"`
+	ImpersonatorID string          `json:"impersonator_id,omitempty"`
+	TargetID       string          `json:"target_id"`
+	Operation      string          `json:"operation"`
+	RequestID      string          `json:"request_id"`
+	SourceIP       string          `json:"source_ip"`
+	Metadata       json.RawMessage `json:"metadata"`
+	Changes        json.RawMessage `json:"changes"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// AuthData is the auth service's part of a data export. It never contains
+// password hashes or TOTP secrets, only whether they are set.
+type AuthData struct {
+	Status       string        `json:"status"`
+	Permissions  []string      `json:"permissions"`
+	HasPassword  bool          `json:"has_password"`
+	TOTPEnabled  bool          `json:"totp_enabled"`
+	Identities   []*Identity   `json:"identities"`
+	Session      *Session      `json:"session"`
+	LoginHistory []*AuditEntry `json:"login_history"`
+	AuditEvents  []*AuditEntry `json:"audit_events"`
+}
+
+type AuthClient interface {
+	ExportUserData(ctx context.Context, userID string) (*AuthData, error)
+}
+
+type authClientImpl struct {
+	client authpb.AuthServiceClient
+}
+
+// NewAuthClient returns a client whose calls act in the caller's tenant. Calls
+// that do not forward the caller's token authenticate with credentials.
+func NewAuthClient(conn grpc.ClientConnInterface, credentials *interceptor.ServiceCredentials) AuthClient {
+	conn = interceptor.TenantConn(interceptor.ServiceConn(conn, credentials))
+	return &authClientImpl{
+		client: authpb.NewAuthServiceClient(conn),
+	}
+}
+
+func (c *authClientImpl) ExportUserData(ctx context.Context, userID string) (*AuthData, error) {
+	res, err := c.client.ExportUserData(ctx, &authpb.ExportUserDataRequest{UserId: userID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	data := &AuthData{
+		Status:       res.Status,
+		Permissions:  res.Permissions,
+		HasPassword:  res.HasPassword,
+		TOTPEnabled:  res.TotpEnabled,
+		Identities:   make([]*Identity, 0, len(res.Identities)),
+		Session:      &Session{Active: res.GetSession().GetActive()},
+		LoginHistory: toAuditEntries(res.LoginHistory),
+		AuditEvents:  toAuditEntries(res.AuditEvents),
+	}
+	if expiresAt := res.GetSession().GetExpiresAt(); expiresAt > 0 {
+		t := time.Unix(expiresAt, 0).UTC()
+		data.Session.ExpiresAt = &t
+	}
+	for _, identity := range res.Identities {
+		data.Identities = append(data.Identities, &Identity{
+			Provider:  identity.Provider,
+			Subject:   identity.Subject,
+			Email:     identity.Email,
+			CreatedAt: time.Unix(identity.CreatedAt, 0).UTC(),
+		})
+	}
+
+	return data, nil
+}
+
+func toAuditEntries(entries []*authpb.AuditEntry) []*AuditEntry {
+	res := make([]*AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		res = append(res, &AuditEntry{
+			ID:             entry.Id,
+			ActorID:        entry.ActorId,
+			ImpersonatorID: entry.ImpersonatorId,
+			TargetID:       entry.TargetId,
+			Operation:      entry.Operation,
+			RequestID:      entry.RequestId,
+			SourceIP:       entry.SourceIp,
+			Metadata:       json.RawMessage(entry.MetadataJson),
+			Changes:        json.RawMessage(entry.ChangesJson),
+			CreatedAt:      time.Unix(entry.CreatedAt, 0).UTC(),
+		})
+	}
+	return res
+}
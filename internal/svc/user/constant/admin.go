@@ -0,0 +1,14 @@
+package constant
+
+const (
+	PermissionForceEmailChange = "users:force_email_change"
+	PermissionDeleteUsers      = "users:delete"
+
+	// MaxBulkUsers bounds a bulk admin operation. Each user is changed in its
+	// own transaction, so a large batch holds no lock for long.
+	MaxBulkUsers = 100
+)
+
+// AdminSettableStatuses are the statuses an administrator may set directly.
+// Pending deletion is only reached through DeleteUser.
+var AdminSettableStatuses = []string{UserStatusActive, UserStatusSuspended, UserStatusBanned}
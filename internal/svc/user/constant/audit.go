@@ -0,0 +1,37 @@
+package constant
+
+const (
+	AuditOperationCreateUser         = "create_user"
+	AuditOperationUpdateUser         = "update_user"
+	AuditOperationDeleteUser         = "delete_user"
+	AuditOperationRestoreUser        = "restore_user"
+	AuditOperationSuspendUser        = "suspend_user"
+	AuditOperationReinstateUser      = "reinstate_user"
+	AuditOperationBanUser            = "ban_user"
+	AuditOperationForceEmailChange   = "force_email_change"
+	AuditOperationPurgeUser          = "purge_user"
+	AuditOperationRequestErasure     = "request_erasure"
+	AuditOperationSetAttributeSchema = "set_attribute_schema"
+	AuditOperationUploadAvatar       = "upload_avatar"
+	AuditOperationVerifyPhone        = "verify_phone"
+
+	AuditOperationCreateOrganization = "create_organization"
+	AuditOperationUpdateOrganization = "update_organization"
+	AuditOperationDeleteOrganization = "delete_organization"
+	AuditOperationTransferOwnership  = "transfer_organization_ownership"
+	AuditOperationUpdateMemberRole   = "update_member_role"
+	AuditOperationRemoveMember       = "remove_member"
+	AuditOperationInviteMember       = "invite_member"
+	AuditOperationRevokeInvitation   = "revoke_organization_invitation"
+	AuditOperationAcceptInvitation   = "accept_organization_invitation"
+
+	// AuditActorSystem stands in for the actor of changes made without a
+	// caller token, such as the purge worker or service-to-service calls.
+	AuditActorSystem = "system"
+
+	PermissionReadAudit = "audit:read"
+)
+
+// AuditRedactedUserFields are recorded as changed in audit diffs without
+// their values.
+var AuditRedactedUserFields = []string{"email", "phone"}
@@ -0,0 +1,21 @@
+package constant
+
+import "time"
+
+const (
+	DefaultAvatarMaxBytes = 5 << 20
+	AvatarMaxPixels       = 16_000_000
+
+	// AvatarRefFormat names an upload by user and content hash, so a new
+	// upload gets new URLs and served images can be cached forever.
+	AvatarRefFormat    = "avatars/%s/%s"
+	AvatarUserPrefix   = "avatars/%s/"
+	AvatarHTTPPath     = "/avatars/"
+	AvatarCacheControl = "public, max-age=31536000, immutable"
+	AvatarStoreTimeout = 30 * time.Second
+
+	PermissionManageAvatars = "users:manage_avatars"
+)
+
+// AvatarSizes are the square thumbnail edges rendered for every upload.
+var AvatarSizes = []int{64, 128, 256, 512}
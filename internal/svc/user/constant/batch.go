@@ -0,0 +1,13 @@
+package constant
+
+import "time"
+
+const (
+	MaxBatchGetUsers = 100
+
+	// The loader holds a batch open for UserLoaderWait so concurrent lookups
+	// can join it, and dispatches early once it reaches UserLoaderMaxBatch.
+	UserLoaderWait         = 2 * time.Millisecond
+	UserLoaderMaxBatch     = 500
+	UserLoaderFetchTimeout = 5 * time.Second
+)
@@ -0,0 +1,16 @@
+package constant
+
+import "time"
+
+// Email policies decide whether a soft-deleted user's address stays reserved
+// until the purge or may be registered again straight away.
+const (
+	EmailPolicyReserve = "reserve"
+	EmailPolicyRelease = "release"
+)
+
+const (
+	DefaultDeletionGracePeriod = 30 * 24 * time.Hour
+	DefaultPurgeInterval       = time.Hour
+	PurgeBatchSize             = 100
+)
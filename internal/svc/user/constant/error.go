@@ -1,10 +1,82 @@
 package constant
 
 const (
-	UserNotFoundErrorMessage     = "user not found"
-	EmailExistsErrorMessage      = "email already exists"
-	InternalServerErrorMessage   = "internal server error"
-	ServiceUnavailableMessage    = "service unavailable"
-	CacheSetError = "failed to set cache"
-	CacheDeleteError = "failed to delete cache"
+	UserNotFoundErrorMessage         = "user not found"
+	EmailExistsErrorMessage          = "email already exists"
+	InternalServerErrorMessage       = "internal server error"
+	ServiceUnavailableMessage        = "service unavailable"
+	CacheSetError                    = "failed to set cache"
+	CacheDeleteError                 = "failed to delete cache"
+	StatusReasonRequiredMessage      = "status change reason is required"
+	InvalidStatusTransitionMessage   = "cannot change status from %s to %s"
+	InvalidPageTokenMessage          = "invalid page token"
+	InvalidOrderByMessage            = "invalid order_by, expected one of created_at, -created_at, email, -email"
+	InvalidStatusFilterMessage       = "invalid status filter"
+	SearchQueryTooShortMessage       = "search query must be at least %d characters"
+	UserIDsRequiredMessage           = "at least one user id is required"
+	TooManyUserIDsMessage            = "at most %d user ids may be requested at once"
+	InvalidFieldMaskPathMessage      = "unknown field mask path %q"
+	FieldNotUpdatableMessage         = "field %q cannot be updated"
+	EmailRequiredMessage             = "email cannot be cleared"
+	VersionMismatchMessage           = "user was modified concurrently, current version is %d"
+	RestoreWindowExpiredMessage      = "user can no longer be restored, the grace period ended at %s"
+	UnauthenticatedMessage           = "authentication required"
+	PermissionDeniedMessage          = "permission denied"
+	InvalidExportFormatMessage       = "invalid export format, expected json or zip"
+	OperationNotFoundMessage         = "operation not found"
+	InvalidPhoneMessage              = "invalid phone number, expected international format such as +14155550123"
+	InvalidLocaleMessage             = "invalid locale %q, expected a BCP 47 tag such as en-US"
+	InvalidTimezoneMessage           = "invalid timezone %q, expected an IANA name such as Europe/Berlin"
+	InvalidAvatarRefMessage          = "invalid avatar reference, expected an https URL or a storage key"
+	InvalidAttributesMessage         = "custom attributes do not match the tenant schema"
+	AttributeSchemaMissingMessage    = "custom attributes are not enabled, no attribute schema is defined"
+	InvalidAttributeSchemaMessage    = "invalid attribute schema: %s"
+	AttributeSchemaNotFoundMessage   = "attribute schema not found"
+	AvatarMetadataRequiredMessage    = "an avatar upload must send its metadata first and only chunks after it"
+	AvatarEmptyMessage               = "avatar image is empty"
+	AvatarTooLargeMessage            = "avatar image exceeds %d bytes"
+	UnsupportedAvatarTypeMessage     = "unsupported avatar image, expected JPEG, PNG or GIF"
+	AvatarDimensionsTooLargeMessage  = "avatar image exceeds %d pixels"
+	InvalidPhonePurposeMessage       = "invalid verification purpose, expected verify, second_factor or recovery"
+	PhoneRequiredMessage             = "the profile has no phone number"
+	PhoneNotVerifiedMessage          = "the phone number is not verified"
+	InvalidPhoneCodeMessage          = "invalid or expired verification code"
+	PhoneCodeAttemptsExceededMessage = "too many incorrect codes, request a new one"
+	PhoneRateLimitedMessage          = "too many codes requested, retry in %d seconds"
+	SMSUnavailableMessage            = "the code could not be sent, try again later"
+	InvalidUsernameMessage           = "invalid username, expected 3 to 30 letters, digits, dots or underscores starting with a letter"
+	UsernameReservedMessage          = "username is reserved"
+	UsernameExistsMessage            = "username already exists"
+	UsernameRequiredMessage          = "username cannot be cleared"
+	UsernameChangeCooldownMessage    = "username was changed recently, it can be changed again after %s"
+	OrganizationNotFoundMessage      = "organization not found"
+	InvalidOrganizationNameMessage   = "organization name is required and must be at most 128 characters"
+	InvalidOrganizationSlugMessage   = "invalid slug, expected 3 to 64 lowercase letters, digits or hyphens"
+	OrganizationSlugExistsMessage    = "organization slug already exists"
+	InvalidOrganizationRoleMessage   = "invalid role, expected admin or member"
+	MemberNotFoundMessage            = "member not found"
+	MemberExistsMessage              = "user is already a member of the organization"
+	OwnerCannotLeaveMessage          = "the owner cannot leave the organization, transfer ownership first"
+	OwnsOrganizationsMessage         = "user owns organizations, transfer or delete them first"
+	InvitationNotFoundMessage        = "invitation not found"
+	InvitationExistsMessage          = "an invitation is already pending for this email"
+	InvitationClosedMessage          = "invitation is no longer valid"
+	InvalidInvitationTokenMessage    = "invalid invitation token"
+	UnsupportedStatusMessage         = "status %q cannot be set, expected active, suspended or banned"
+	ReasonRequiredMessage            = "reason is required"
+	InvalidEmailMessage              = "invalid email address"
+	InvalidImportFormatMessage       = "invalid import format, expected csv or ndjson"
+	ImportOptionsRequiredMessage     = "the first message of an import must carry its options"
+	InvalidImportHeaderMessage       = "invalid CSV header: %s"
+	InvalidImportRowMessage          = "cannot parse row: %s"
+	ImportLineTooLongMessage         = "line %d is longer than %d bytes"
+	DuplicateImportEmailMessage      = "email already appears on line %d"
+	DuplicateImportUsernameMessage   = "username already appears on line %d"
+	QuotaExceededMessage             = "tenant quota exceeded: %s limit is %d"
+	InvalidOperationTypeMessage      = "operation type %q cannot be created"
+	InvalidOperationStateMessage     = "invalid operation state"
+	OperationNotCancellableMessage   = "operation %s cannot be cancelled"
+	OperationCancelledMessage        = "operation was cancelled"
+	OperationAbandonedMessage        = "operation was abandoned after %d attempts"
+	ImportUploadTooLargeMessage      = "import upload is larger than %d bytes"
 )
@@ -0,0 +1,7 @@
+package constant
+
+const FieldMaskWildcard = "*"
+
+// UpdatableUserFields are the User field paths UpdateUser accepts in its
+// update mask. Status changes go through SuspendUser and ReinstateUser.
+var UpdatableUserFields = []string{"email", "first_name", "last_name", "phone", "locale", "timezone", "avatar_ref", "attributes", "username"}
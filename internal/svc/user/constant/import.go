@@ -0,0 +1,22 @@
+package constant
+
+const (
+	PermissionImportUsers = "users:import"
+	PermissionExportUsers = "users:export"
+
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+
+	// Valid rows are inserted ImportBatchSize at a time, each batch in its
+	// own transaction. Only the first MaxImportRowErrors failures are
+	// described in the response; the rest are counted.
+	ImportBatchSize    = 500
+	MaxImportRowErrors = 1000
+	MaxImportLineBytes = 1 << 20
+
+	ExportFetchSize = 500
+)
+
+// ImportColumns are the fields an imported row may set, as CSV header names
+// and NDJSON keys. Only email is required.
+var ImportColumns = []string{"email", "first_name", "last_name", "phone", "locale", "timezone", "username", "attributes"}
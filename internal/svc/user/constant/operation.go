@@ -0,0 +1,52 @@
+package constant
+
+import "time"
+
+const (
+	PermissionManageOperations = "operations:manage"
+	PermissionPurgeUsers       = "users:purge"
+
+	OperationStatePending   = "pending"
+	OperationStateRunning   = "running"
+	OperationStateSucceeded = "succeeded"
+	OperationStateFailed    = "failed"
+	OperationStateCancelled = "cancelled"
+
+	OperationTypePurgeDeletedUsers = "purge_deleted_users"
+	OperationTypeImportUsers       = "import_users"
+	OperationTypeExportUserData    = "export_user_data"
+	OperationTypeErasure           = "erasure"
+
+	OperationNamePrefix = "operations/"
+
+	// A worker holds an operation for OperationLeaseDuration and renews the
+	// lease every OperationHeartbeatInterval. An operation whose lease ran
+	// out is taken over by another worker, at most MaxOperationAttempts
+	// times in all.
+	OperationLeaseDuration     = time.Minute
+	OperationHeartbeatInterval = 20 * time.Second
+	MaxOperationAttempts       = 5
+
+	DefaultOperationWorkers      = 4
+	DefaultOperationPollInterval = 5 * time.Second
+	OperationClaimCandidates     = 10
+
+	DefaultOperationWaitTimeout = 30 * time.Second
+	MaxOperationWaitTimeout     = time.Minute
+	OperationWaitPollInterval   = 500 * time.Millisecond
+
+	// Uploads for import operations are kept whole in the blob store until
+	// the operation ends.
+	MaxImportUploadBytes  = 64 << 20
+	ImportUploadKeyFormat = "imports/%s/%s"
+)
+
+// CreatableOperationTypes can be started through CreateOperation. Imports
+// need an upload and are started through StartImportUsers, exports through
+// StartExportUserData.
+var CreatableOperationTypes = map[string]string{
+	OperationTypePurgeDeletedUsers: PermissionPurgeUsers,
+}
+
+// OperationStates are the states ListOperations can filter on.
+var OperationStates = []string{OperationStatePending, OperationStateRunning, OperationStateSucceeded, OperationStateFailed, OperationStateCancelled}
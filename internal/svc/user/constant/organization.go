@@ -0,0 +1,29 @@
+package constant
+
+import "time"
+
+const (
+	OrganizationRoleOwner  = "owner"
+	OrganizationRoleAdmin  = "admin"
+	OrganizationRoleMember = "member"
+
+	MaxOrganizationNameLength = 128
+
+	OrganizationInvitationTTL = 7 * 24 * time.Hour
+	// MinInvitationSecretBytes is the shortest secret invitation links may be
+	// signed with.
+	MinInvitationSecretBytes = 32
+
+	OrganizationInvitationEmailSubject = "You have been invited to an organization"
+	// OrganizationInvitationEmailBody is filled with the role, the accept
+	// link and the expiry.
+	OrganizationInvitationEmailBody = "You have been invited to join an organization as %s.\n\nAccept the invitation here: %s\n\nThe link expires on %s."
+)
+
+// OrganizationRoleRanks orders the roles. A member may only manage members
+// ranked below them, and grant roles ranked below their own.
+var OrganizationRoleRanks = map[string]int{
+	OrganizationRoleMember: 1,
+	OrganizationRoleAdmin:  2,
+	OrganizationRoleOwner:  3,
+}
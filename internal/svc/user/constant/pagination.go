@@ -0,0 +1,12 @@
+package constant
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+
+	DefaultUserOrderBy = "created_at"
+
+	DefaultSearchPageSize = 20
+	MaxSearchPageSize     = 100
+	MinSearchQueryLength  = 2
+)
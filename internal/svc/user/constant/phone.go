@@ -0,0 +1,24 @@
+package constant
+
+import "time"
+
+const (
+	PhonePurposeVerify       = "verify"
+	PhonePurposeSecondFactor = "second_factor"
+	PhonePurposeRecovery     = "recovery"
+
+	PhoneCodeAlphabet    = "0123456789"
+	PhoneCodeLength      = 6
+	PhoneCodeTTL         = 10 * time.Minute
+	PhoneCodeMaxAttempts = 5
+
+	// PhoneResendCooldown spaces out codes for one user; PhoneMaxSendsPerWindow
+	// caps what any one number receives, whoever asks for it.
+	PhoneResendCooldown    = time.Minute
+	PhoneSendWindow        = time.Hour
+	PhoneMaxSendsPerWindow = 5
+
+	PhoneCodeMessage = "Your verification code is %s. It expires in %d minutes."
+)
+
+var PhonePurposes = []string{PhonePurposeVerify, PhonePurposeSecondFactor, PhonePurposeRecovery}
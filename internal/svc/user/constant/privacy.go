@@ -0,0 +1,20 @@
+package constant
+
+import "github.com/hailsayan/achilles/internal/pkg/events"
+
+const (
+	PermissionExportUserData = "users:export_data"
+	PermissionEraseUsers     = "users:erase"
+
+	ExportFormatJSON = "json"
+	ExportFormatZIP  = "zip"
+
+	ErasureStateRunning   = "running"
+	ErasureStateSucceeded = "succeeded"
+
+	ErasureOperationPrefix = "operations/erasure-"
+)
+
+// ErasureServices must all confirm before an erasure request is done. The
+// user service erases its own data when the request is accepted.
+var ErasureServices = []string{events.ErasureServiceUser, events.ErasureServiceAuth}
@@ -0,0 +1,7 @@
+package constant
+
+const (
+	MaxAvatarRefLength = 512
+
+	PermissionManageAttributeSchema = "users:manage_attribute_schema"
+)
@@ -0,0 +1,9 @@
+package constant
+
+import "time"
+
+const (
+	PermissionReadUsage = "usage:read"
+
+	DefaultUsageRollupInterval = 5 * time.Minute
+)
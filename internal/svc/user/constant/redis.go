@@ -2,7 +2,19 @@ package constant
 
 import "time"
 
+// UserCachePrefix is versioned so a change to the cached User shape starts
+// from an empty cache instead of serving entries without the new fields. It
+// takes the tenant, then the user ID.
 const (
-	UserCachePrefix    = "user:%s"
+	UserCachePrefix    = "user:v5:%s:%s"
 	UserCacheTTL       = time.Hour * 24
 )
+
+// Phone verification keys are scoped by purpose and then user, so a code
+// sent for one purpose cannot be redeemed for another.
+const (
+	PhoneChallengePrefix = "phone_challenge:%s:%s"
+	PhoneAttemptsPrefix  = "phone_challenge_attempts:%s:%s"
+	PhoneCooldownPrefix  = "phone_cooldown:%s"
+	PhoneSendCountPrefix = "phone_sends:%s"
+)
@@ -0,0 +1,26 @@
+package constant
+
+const (
+	UserStatusActive          = "active"
+	UserStatusSuspended       = "suspended"
+	UserStatusBanned          = "banned"
+	UserStatusPendingDeletion = "pending_deletion"
+
+	PermissionWriteUsers   = "users:write"
+	PermissionManageStatus = "users:manage_status"
+	PermissionListUsers    = "users:list"
+	PermissionSearchUsers  = "users:search"
+	PermissionReadPII      = "users:read_pii"
+	PermissionRestoreUsers = "users:restore"
+	PermissionReadHistory  = "users:read_history"
+)
+
+// UserStatusTransitions lists the statuses each status may move to. Anything
+// not listed is rejected so a banned account cannot be quietly suspended
+// instead, for example.
+var UserStatusTransitions = map[string][]string{
+	UserStatusActive:          {UserStatusSuspended, UserStatusBanned, UserStatusPendingDeletion},
+	UserStatusSuspended:       {UserStatusActive, UserStatusBanned},
+	UserStatusBanned:          {UserStatusActive},
+	UserStatusPendingDeletion: {UserStatusActive},
+}
@@ -1,5 +1,8 @@
 package constant
 
 const (
-	UserDeletedSuccessfully = "user deleted successfully"
+	UserDeletedSuccessfully = "user deleted successfully, it can be restored until %s"
+	PhoneCodeSent           = "if the account has a phone number for this purpose, a code has been sent to it"
+	OrganizationDeleted     = "organization deleted successfully"
+	MemberRemoved           = "member removed successfully"
 )
@@ -0,0 +1,52 @@
+package constant
+
+import "time"
+
+const (
+	UsernameMinLength = 3
+	UsernameMaxLength = 30
+
+	// UsernameChangeCooldown is how long a user waits between renames, and
+	// UsernameReservationPeriod how long a released name stays reserved for
+	// the user who released it.
+	UsernameChangeCooldown    = 30 * 24 * time.Hour
+	UsernameReservationPeriod = 90 * 24 * time.Hour
+
+	UsernameUnavailableInvalid  = "invalid"
+	UsernameUnavailableReserved = "reserved"
+	UsernameUnavailableTaken    = "taken"
+)
+
+// ReservedUsernames cannot be registered by anyone, in any case, because
+// they would pass for the service itself or collide with routes.
+var ReservedUsernames = map[string]struct{}{
+	"about":         {},
+	"account":       {},
+	"achilles":      {},
+	"admin":         {},
+	"administrator": {},
+	"api":           {},
+	"auth":          {},
+	"billing":       {},
+	"help":          {},
+	"login":         {},
+	"logout":        {},
+	"me":            {},
+	"moderator":     {},
+	"null":          {},
+	"official":      {},
+	"owner":         {},
+	"register":      {},
+	"root":          {},
+	"security":      {},
+	"settings":      {},
+	"signin":        {},
+	"signup":        {},
+	"staff":         {},
+	"support":       {},
+	"system":        {},
+	"undefined":     {},
+	"user":          {},
+	"users":         {},
+	"www":           {},
+}
@@ -0,0 +1,9 @@
+package constant
+
+const (
+	ErrorDomain           = "user.achilles"
+	VersionMismatchReason = "VERSION_MISMATCH"
+
+	// UserETagFormat renders a user's version as a strong HTTP-style etag.
+	UserETagFormat = `"%d"`
+)
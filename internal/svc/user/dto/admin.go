@@ -0,0 +1,42 @@
+package dto
+
+import "google.golang.org/grpc/codes"
+
+type ForceEmailChangeRequest struct {
+	ID      string `json:"id" validate:"required"`
+	Email   string `json:"email" validate:"required,email"`
+	Reason  string `json:"reason" validate:"required,max=512"`
+	ActorID string `json:"-"`
+}
+
+type SetUserStatusRequest struct {
+	ID      string `json:"id" validate:"required"`
+	Status  string `json:"status" validate:"required"`
+	Reason  string `json:"reason" validate:"required,max=512"`
+	ActorID string `json:"-"`
+}
+
+type BulkSetUserStatusRequest struct {
+	IDs     []string `json:"ids" validate:"required"`
+	Status  string   `json:"status" validate:"required"`
+	Reason  string   `json:"reason" validate:"required,max=512"`
+	ActorID string   `json:"-"`
+}
+
+type BulkDeleteUsersRequest struct {
+	IDs     []string `json:"ids" validate:"required"`
+	Reason  string   `json:"reason" validate:"required,max=512"`
+	ActorID string   `json:"-"`
+}
+
+// BulkOperationResult reports one user of a bulk operation. Code is OK when
+// the user was handled.
+type BulkOperationResult struct {
+	UserID  string     `json:"user_id"`
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+}
+
+type BulkOperationResponse struct {
+	Results []*BulkOperationResult `json:"results"`
+}
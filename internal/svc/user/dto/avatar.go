@@ -0,0 +1,21 @@
+package dto
+
+import "io"
+
+// UploadAvatarRequest reads the image from Content so the size limit can
+// stop a stream as soon as it is exceeded.
+type UploadAvatarRequest struct {
+	UserID  string    `json:"user_id" validate:"required"`
+	Content io.Reader `json:"-"`
+}
+
+type AvatarImage struct {
+	Size        int    `json:"size"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+}
+
+type UploadAvatarResponse struct {
+	User   *UserResponse  `json:"user"`
+	Images []*AvatarImage `json:"images"`
+}
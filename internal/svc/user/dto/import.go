@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"io"
+
+	"google.golang.org/grpc/codes"
+)
+
+// ImportUsersRequest imports Content. Resume skips the rows up to and
+// including its line and carries on from its result. Checkpoint, when set,
+// is called after every batch with the position reached.
+type ImportUsersRequest struct {
+	Format     string                                   `json:"format" validate:"required,oneof=csv ndjson"`
+	DryRun     bool                                     `json:"dry_run"`
+	Content    io.Reader                                `json:"-"`
+	ActorID    string                                   `json:"-"`
+	Operation  string                                   `json:"-"`
+	Resume     *ImportCheckpoint                        `json:"-"`
+	Checkpoint func(checkpoint *ImportCheckpoint) error `json:"-"`
+}
+
+// ImportCheckpoint records how far an import got. Every row up to and
+// including Line has been settled and is counted in Result.
+type ImportCheckpoint struct {
+	Line   int64                `json:"line"`
+	Result *ImportUsersResponse `json:"result"`
+}
+
+// ImportUserRow is one user as read from a CSV row or an NDJSON line.
+type ImportUserRow struct {
+	Email      string         `json:"email"`
+	FirstName  string         `json:"first_name"`
+	LastName   string         `json:"last_name"`
+	Phone      string         `json:"phone"`
+	Locale     string         `json:"locale"`
+	Timezone   string         `json:"timezone"`
+	Username   string         `json:"username"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+// ImportRowError reports a row that was not imported. Line is the 1-based
+// line of the input the row starts on.
+type ImportRowError struct {
+	Line    int64      `json:"line"`
+	Email   string     `json:"email"`
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+}
+
+// ImportUsersResponse counts every row read. In a dry run Imported is the
+// number of rows that would have been imported.
+type ImportUsersResponse struct {
+	Rows            int64             `json:"rows"`
+	Imported        int64             `json:"imported"`
+	Failed          int64             `json:"failed"`
+	Errors          []*ImportRowError `json:"errors"`
+	ErrorsTruncated bool              `json:"errors_truncated"`
+	DryRun          bool              `json:"dry_run"`
+}
+
+type ExportUsersRequest struct {
+	Status string `json:"status"`
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"io"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"google.golang.org/grpc/codes"
+)
+
+type CreateOperationRequest struct {
+	Type    string `json:"type" validate:"required"`
+	ActorID string `json:"-"`
+}
+
+type ListOperationsRequest struct {
+	PageSize  int    `json:"page_size" validate:"omitempty,min=1"`
+	PageToken string `json:"page_token"`
+	Type      string `json:"type"`
+	State     string `json:"state"`
+}
+
+type ListOperationsResponse struct {
+	Operations    []*OperationResponse `json:"operations"`
+	NextPageToken string               `json:"next_page_token"`
+}
+
+type CancelOperationRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// WaitOperationRequest waits at most Timeout, capped at
+// constant.MaxOperationWaitTimeout. Zero means
+// constant.DefaultOperationWaitTimeout.
+type WaitOperationRequest struct {
+	Name    string        `json:"name" validate:"required"`
+	Timeout time.Duration `json:"timeout"`
+}
+
+// StartImportUsersRequest is an ImportUsersRequest run as an operation.
+// Content is read in full before the operation is created.
+type StartImportUsersRequest struct {
+	Format  string    `json:"format" validate:"required,oneof=csv ndjson"`
+	DryRun  bool      `json:"dry_run"`
+	Content io.Reader `json:"-"`
+	ActorID string    `json:"-"`
+}
+
+func ToOperationResponse(operation *entity.Operation) *OperationResponse {
+	done := operation.State == constant.OperationStateSucceeded ||
+		operation.State == constant.OperationStateFailed ||
+		operation.State == constant.OperationStateCancelled
+
+	return &OperationResponse{
+		Name:            constant.OperationNamePrefix + operation.ID,
+		Type:            operation.Type,
+		Done:            done,
+		State:           operation.State,
+		Progress:        operation.Progress,
+		Result:          operation.Result,
+		ErrorCode:       codes.Code(operation.ErrorCode),
+		ErrorMessage:    operation.ErrorMessage,
+		CreatedBy:       operation.CreatedBy,
+		CancelRequested: operation.CancelRequested,
+		CreatedAt:       operation.CreatedAt,
+		UpdatedAt:       operation.UpdatedAt,
+		CompletedAt:     operation.FinishedAt,
+	}
+}
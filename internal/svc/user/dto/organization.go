@@ -0,0 +1,172 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,max=128"`
+	Slug string `json:"slug" validate:"required"`
+}
+
+type GetOrganizationRequest struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// UpdateOrganizationRequest writes only the non-nil fields.
+type UpdateOrganizationRequest struct {
+	ID   string  `json:"id" validate:"required"`
+	Name *string `json:"name,omitempty"`
+	Slug *string `json:"slug,omitempty"`
+}
+
+type DeleteOrganizationRequest struct {
+	ID string `json:"id" validate:"required"`
+}
+
+type DeleteOrganizationResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type TransferOwnershipRequest struct {
+	OrganizationID string `json:"organization_id" validate:"required"`
+	NewOwnerID     string `json:"new_owner_id" validate:"required"`
+}
+
+type GetMembershipRequest struct {
+	OrganizationID string `json:"organization_id" validate:"required"`
+	UserID         string `json:"user_id" validate:"required"`
+}
+
+type ListMembersRequest struct {
+	OrganizationID string `json:"organization_id" validate:"required"`
+	PageSize       int    `json:"page_size" validate:"omitempty,min=1"`
+	PageToken      string `json:"page_token"`
+}
+
+type UpdateMemberRoleRequest struct {
+	OrganizationID string `json:"organization_id" validate:"required"`
+	UserID         string `json:"user_id" validate:"required"`
+	Role           string `json:"role" validate:"required"`
+}
+
+type RemoveMemberRequest struct {
+	OrganizationID string `json:"organization_id" validate:"required"`
+	UserID         string `json:"user_id" validate:"required"`
+}
+
+type RemoveMemberResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type InviteMemberRequest struct {
+	OrganizationID string `json:"organization_id" validate:"required"`
+	Email          string `json:"email" validate:"required,email"`
+	Role           string `json:"role" validate:"required"`
+}
+
+// ListOrganizationInvitationsRequest lists an organization's pending
+// invitations, or the caller's own when OrganizationID is empty.
+type ListOrganizationInvitationsRequest struct {
+	OrganizationID string `json:"organization_id"`
+}
+
+type OrganizationInvitationRequest struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// AcceptOrganizationInvitationRequest carries the token from the invitation
+// email.
+type AcceptOrganizationInvitationRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// OrganizationResponse carries the caller's role in the organization.
+type OrganizationResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	OwnerID   string    `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Role      string    `json:"role"`
+}
+
+type ListOrganizationsResponse struct {
+	Organizations []*OrganizationResponse `json:"organizations"`
+}
+
+type MembershipResponse struct {
+	OrganizationID string    `json:"organization_id"`
+	UserID         string    `json:"user_id"`
+	Role           string    `json:"role"`
+	CreatedAt      time.Time `json:"created_at"`
+	Email          string    `json:"email,omitempty"`
+	FirstName      string    `json:"first_name,omitempty"`
+	LastName       string    `json:"last_name,omitempty"`
+	Username       string    `json:"username,omitempty"`
+}
+
+type ListMembersResponse struct {
+	Members       []*MembershipResponse `json:"members"`
+	NextPageToken string                `json:"next_page_token"`
+}
+
+type OrganizationInvitationResponse struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organization_id"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	InvitedBy      string     `json:"invited_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
+
+type ListOrganizationInvitationsResponse struct {
+	Invitations []*OrganizationInvitationResponse `json:"invitations"`
+}
+
+func ToOrganizationResponse(organization *entity.Organization, role string) *OrganizationResponse {
+	return &OrganizationResponse{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		Slug:      organization.Slug,
+		OwnerID:   organization.OwnerID,
+		CreatedAt: organization.CreatedAt,
+		UpdatedAt: organization.UpdatedAt,
+		Role:      role,
+	}
+}
+
+func ToMembershipResponse(membership *entity.Membership) *MembershipResponse {
+	return &MembershipResponse{
+		OrganizationID: membership.OrganizationID,
+		UserID:         membership.UserID,
+		Role:           membership.Role,
+		CreatedAt:      membership.CreatedAt,
+		Email:          membership.Email,
+		FirstName:      membership.FirstName,
+		LastName:       membership.LastName,
+		Username:       membership.Username,
+	}
+}
+
+func ToOrganizationInvitationResponse(invitation *entity.OrganizationInvitation) *OrganizationInvitationResponse {
+	return &OrganizationInvitationResponse{
+		ID:             invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		InvitedBy:      invitation.InvitedBy,
+		CreatedAt:      invitation.CreatedAt,
+		ExpiresAt:      invitation.ExpiresAt,
+		AcceptedAt:     invitation.AcceptedAt,
+		RevokedAt:      invitation.RevokedAt,
+	}
+}
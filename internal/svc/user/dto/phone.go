@@ -0,0 +1,28 @@
+package dto
+
+// StartPhoneVerificationRequest names the user by ID, or by Email for
+// recovery, where the caller is not signed in.
+type StartPhoneVerificationRequest struct {
+	UserID  string `json:"user_id"`
+	Email   string `json:"email" validate:"omitempty,email"`
+	Purpose string `json:"purpose"`
+}
+
+type StartPhoneVerificationResponse struct {
+	Message    string `json:"message"`
+	ExpiresIn  int64  `json:"expires_in"`
+	RetryAfter int64  `json:"retry_after"`
+}
+
+type ConfirmPhoneVerificationRequest struct {
+	UserID  string `json:"user_id" validate:"required"`
+	Purpose string `json:"purpose"`
+	Code    string `json:"code" validate:"required"`
+}
+
+// ConfirmPhoneVerificationResponse carries the updated user only for the
+// verify purpose, the one that changes the profile.
+type ConfirmPhoneVerificationResponse struct {
+	Verified bool          `json:"verified"`
+	User     *UserResponse `json:"user,omitempty"`
+}
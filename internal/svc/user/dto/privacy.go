@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"google.golang.org/grpc/codes"
+)
+
+type ExportUserDataRequest struct {
+	ID     string `json:"id" validate:"required"`
+	Format string `json:"format" validate:"omitempty,oneof=json zip"`
+}
+
+type ExportUserDataResponse struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"`
+}
+
+// StartExportUserDataRequest is an ExportUserDataRequest run as an
+// operation.
+type StartExportUserDataRequest struct {
+	ID      string `json:"id" validate:"required"`
+	Format  string `json:"format" validate:"omitempty,oneof=json zip"`
+	ActorID string `json:"-"`
+}
+
+type RequestErasureRequest struct {
+	ID      string `json:"id" validate:"required"`
+	ActorID string `json:"-"`
+}
+
+type GetOperationRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// OperationResponse describes an erasure request or a background
+// operation. UserID, Services and ConfirmedServices are set for erasures
+// only; Progress, Result and the error for background operations only.
+type OperationResponse struct {
+	Name              string          `json:"name"`
+	Type              string          `json:"type"`
+	Done              bool            `json:"done"`
+	UserID            string          `json:"user_id"`
+	State             string          `json:"state"`
+	Services          []string        `json:"services"`
+	ConfirmedServices []string        `json:"confirmed_services"`
+	Progress          json.RawMessage `json:"progress,omitempty"`
+	Result            json.RawMessage `json:"result,omitempty"`
+	ErrorCode         codes.Code      `json:"error_code"`
+	ErrorMessage      string          `json:"error_message"`
+	CreatedBy         string          `json:"created_by"`
+	CancelRequested   bool            `json:"cancel_requested"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	CompletedAt       *time.Time      `json:"completed_at,omitempty"`
+}
+
+func ToErasureOperation(request *entity.ErasureRequest) *OperationResponse {
+	return &OperationResponse{
+		Name:              constant.ErasureOperationPrefix + request.ID,
+		Type:              constant.OperationTypeErasure,
+		Done:              request.State == constant.ErasureStateSucceeded,
+		UserID:            request.UserID,
+		State:             request.State,
+		Services:          request.Services,
+		ConfirmedServices: request.ConfirmedServices,
+		CreatedBy:         request.RequestedBy,
+		CreatedAt:         request.CreatedAt,
+		UpdatedAt:         request.UpdatedAt,
+		CompletedAt:       request.CompletedAt,
+	}
+}
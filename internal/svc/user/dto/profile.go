@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type SetAttributeSchemaRequest struct {
+	Schema  json.RawMessage `json:"schema" validate:"required"`
+	ActorID string          `json:"actor_id"`
+}
+
+type AttributeSchemaResponse struct {
+	TenantID  string          `json:"tenant_id"`
+	Schema    json.RawMessage `json:"schema"`
+	UpdatedBy string          `json:"updated_by"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+func ToAttributeSchemaResponse(schema *entity.AttributeSchema) *AttributeSchemaResponse {
+	return &AttributeSchemaResponse{
+		TenantID:  schema.TenantID,
+		Schema:    schema.Schema,
+		UpdatedBy: schema.UpdatedBy,
+		UpdatedAt: schema.UpdatedAt,
+	}
+}
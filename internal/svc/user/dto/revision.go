@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+)
+
+type ListUserRevisionsRequest struct {
+	ID         string `json:"id" validate:"required"`
+	PageSize   int    `json:"page_size" validate:"omitempty,min=1"`
+	PageToken  string `json:"page_token"`
+	IncludePII bool   `json:"-"`
+}
+
+// UserRevision describes one version of a user by what changed from the
+// version before it. The first revision lists every field.
+type UserRevision struct {
+	Version   int64                    `json:"version"`
+	ValidFrom time.Time                `json:"valid_from"`
+	ValidTo   *time.Time               `json:"valid_to,omitempty"`
+	Changes   map[string]*audit.Change `json:"changes"`
+}
+
+type ListUserRevisionsResponse struct {
+	Revisions     []*UserRevision `json:"revisions"`
+	NextPageToken string          `json:"next_page_token"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// UsageMetric reports consumption of one metric. A zero Limit means the
+// tenant has no limit on it.
+type UsageMetric struct {
+	Metric string `json:"metric"`
+	Used   int64  `json:"used"`
+	Limit  int64  `json:"limit"`
+}
+
+// UsageResponse covers the calendar month that started at PeriodStart.
+type UsageResponse struct {
+	TenantID    string         `json:"tenant_id"`
+	PeriodStart time.Time      `json:"period_start"`
+	Metrics     []*UsageMetric `json:"metrics"`
+}
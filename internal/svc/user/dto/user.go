@@ -1,57 +1,149 @@
 package dto
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
 	"github.com/hailsayan/achilles/internal/svc/user/entity"
 )
 
 type CreateUserRequest struct {
-	Email     string `json:"email" validate:"required,email"`
-	FirstName string `json:"first_name" validate:"required,max=64"`
-	LastName  string `json:"last_name" validate:"required,max=64"`
+	Email      string         `json:"email" validate:"required,email"`
+	FirstName  string         `json:"first_name" validate:"required,max=64"`
+	LastName   string         `json:"last_name" validate:"required,max=64"`
+	Phone      string         `json:"phone,omitempty"`
+	Locale     string         `json:"locale,omitempty"`
+	Timezone   string         `json:"timezone,omitempty"`
+	AvatarRef  string         `json:"avatar_ref,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Username   string         `json:"username,omitempty"`
 }
 
 type GetUserRequest struct {
-	ID string `json:"id" validate:"required"`
+	ID       string     `json:"id" validate:"required"`
+	ReadMask []string   `json:"read_mask,omitempty"`
+	AsOf     *time.Time `json:"as_of,omitempty"`
 }
 
+type GetUserByEmailRequest struct {
+	Email    string   `json:"email" validate:"required,email"`
+	ReadMask []string `json:"read_mask,omitempty"`
+}
+
+// UpdateUserRequest carries an optional UpdateMask. When it is set, every
+// listed field is written, and a nil value clears it; without a mask only
+// the non-nil fields are written.
 type UpdateUserRequest struct {
-	ID        string  `json:"id" validate:"required"`
-	Email     *string `json:"email,omitempty" validate:"omitempty,email"`
-	FirstName *string `json:"first_name,omitempty" validate:"omitempty,max=64"`
-	LastName  *string `json:"last_name,omitempty" validate:"omitempty,max=64"`
+	ID         string   `json:"id" validate:"required"`
+	Email      *string  `json:"email,omitempty" validate:"omitempty,email"`
+	FirstName  *string  `json:"first_name,omitempty" validate:"omitempty,max=64"`
+	LastName   *string  `json:"last_name,omitempty" validate:"omitempty,max=64"`
+	Phone      *string  `json:"phone,omitempty"`
+	Locale     *string  `json:"locale,omitempty"`
+	Timezone   *string  `json:"timezone,omitempty"`
+	AvatarRef  *string  `json:"avatar_ref,omitempty"`
+	Username   *string  `json:"username,omitempty"`
+	UpdateMask []string `json:"update_mask,omitempty"`
+
+	Attributes map[string]any `json:"attributes,omitempty"`
+
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
+}
+
+type ChangeUserStatusRequest struct {
+	ID      string `json:"id" validate:"required"`
+	Reason  string `json:"reason" validate:"required,max=512"`
+	ActorID string `json:"actor_id"`
+}
+
+type ListUsersRequest struct {
+	PageSize      int        `json:"page_size" validate:"omitempty,min=1"`
+	PageToken     string     `json:"page_token"`
+	EmailPrefix   string     `json:"email_prefix"`
+	Name          string     `json:"name"`
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+	Status        string     `json:"status"`
+	OrderBy       string     `json:"order_by"`
+	ReadMask      []string   `json:"read_mask,omitempty"`
+
+	OrganizationID string `json:"organization_id"`
+	IncludePII     bool   `json:"-"`
+}
+
+type BatchGetUsersRequest struct {
+	IDs      []string `json:"ids" validate:"required,min=1"`
+	ReadMask []string `json:"read_mask,omitempty"`
+}
+
+type SearchUsersRequest struct {
+	Query      string `json:"query" validate:"required,min=2"`
+	PageSize   int    `json:"page_size" validate:"omitempty,min=1"`
+	IncludePII bool   `json:"-"`
 }
 
 type DeleteUserRequest struct {
-	ID string `json:"id" validate:"required"`
+	ID              string `json:"id" validate:"required"`
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
+	Reason          string `json:"-"`
+	ActorID         string `json:"-"`
+}
+
+type RestoreUserRequest struct {
+	ID      string `json:"id" validate:"required"`
+	ActorID string `json:"-"`
+}
+
+type UserResponse struct {
+	ID           string    `json:"id"`
+	Email        string    `json:"email"`
+	FirstName    string    `json:"first_name"`
+	LastName     string    `json:"last_name"`
+	Status       string    `json:"status"`
+	StatusReason string    `json:"status_reason"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Version      int64     `json:"version"`
+	ETag         string    `json:"etag"`
+
+	Phone      string         `json:"phone"`
+	Locale     string         `json:"locale"`
+	Timezone   string         `json:"timezone"`
+	AvatarRef  string         `json:"avatar_ref"`
+	Attributes map[string]any `json:"attributes"`
+
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at,omitempty"`
+
+	Username          string     `json:"username"`
+	UsernameChangedAt *time.Time `json:"username_changed_at,omitempty"`
+}
+
+type CreateUserResponse = UserResponse
+
+type GetUserResponse = UserResponse
+
+type UpdateUserResponse = UserResponse
+
+type ListUsersResponse struct {
+	Users         []*UserResponse `json:"users"`
+	NextPageToken string          `json:"next_page_token"`
 }
 
-type CreateUserResponse struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+type BatchGetUsersResponse struct {
+	Users      []*UserResponse `json:"users"`
+	MissingIDs []string        `json:"missing_ids"`
 }
 
-type GetUserResponse struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+type UserSearchResult struct {
+	User           *UserResponse `json:"user"`
+	Score          float64       `json:"score"`
+	NameHighlight  string        `json:"name_highlight"`
+	EmailHighlight string        `json:"email_highlight,omitempty"`
 }
 
-type UpdateUserResponse struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+type SearchUsersResponse struct {
+	Results []*UserSearchResult `json:"results"`
 }
 
 type DeleteUserResponse struct {
@@ -59,37 +151,115 @@ type DeleteUserResponse struct {
 	Message string `json:"message"`
 }
 
-func ToCreateUserResponse(user *entity.User) *CreateUserResponse {
-	return &CreateUserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+func ToUserResponse(user *entity.User) *UserResponse {
+	return &UserResponse{
+		ID:           user.ID,
+		Email:        user.Email,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		Status:       user.Status,
+		StatusReason: user.StatusReason,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+		Version:      user.Version,
+		ETag:         fmt.Sprintf(constant.UserETagFormat, user.Version),
+		Phone:        user.Phone,
+		Locale:       user.Locale,
+		Timezone:     user.Timezone,
+		AvatarRef:    user.AvatarRef,
+		Attributes:   user.Attributes,
+
+		PhoneVerifiedAt: user.PhoneVerifiedAt,
+
+		Username:          user.Username,
+		UsernameChangedAt: user.UsernameChangedAt,
 	}
 }
 
-func ToGetUserResponse(user *entity.User) *GetUserResponse {
-	return &GetUserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+// MaskUserResponse keeps only the fields named in paths, plus the ID. An
+// empty mask returns the response unchanged.
+func MaskUserResponse(res *UserResponse, paths []string) *UserResponse {
+	if len(paths) == 0 {
+		return res
 	}
+
+	masked := &UserResponse{ID: res.ID}
+	for _, path := range paths {
+		switch path {
+		case "email":
+			masked.Email = res.Email
+		case "first_name":
+			masked.FirstName = res.FirstName
+		case "last_name":
+			masked.LastName = res.LastName
+		case "status":
+			masked.Status = res.Status
+		case "status_reason":
+			masked.StatusReason = res.StatusReason
+		case "created_at":
+			masked.CreatedAt = res.CreatedAt
+		case "updated_at":
+			masked.UpdatedAt = res.UpdatedAt
+		case "version":
+			masked.Version = res.Version
+			masked.ETag = res.ETag
+		case "phone":
+			masked.Phone = res.Phone
+		case "locale":
+			masked.Locale = res.Locale
+		case "timezone":
+			masked.Timezone = res.Timezone
+		case "avatar_ref":
+			masked.AvatarRef = res.AvatarRef
+		case "attributes":
+			masked.Attributes = res.Attributes
+		case "phone_verified_at":
+			masked.PhoneVerifiedAt = res.PhoneVerifiedAt
+		case "username":
+			masked.Username = res.Username
+		case "username_changed_at":
+			masked.UsernameChangedAt = res.UsernameChangedAt
+		}
+	}
+	return masked
+}
+
+func ToCreateUserResponse(user *entity.User) *CreateUserResponse {
+	return ToUserResponse(user)
+}
+
+func ToGetUserResponse(user *entity.User) *GetUserResponse {
+	return ToUserResponse(user)
 }
 
 func ToUpdateUserResponse(user *entity.User) *UpdateUserResponse {
-	return &UpdateUserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+	return ToUserResponse(user)
+}
+
+// ToUserSearchResult drops fields the caller may not see. Without PII access
+// the email, its highlight and the status reason are omitted.
+func ToUserSearchResult(result *entity.UserSearchResult, includePII bool) *UserSearchResult {
+	res := &UserSearchResult{
+		User:          ToUserResponse(result.User),
+		Score:         result.Score,
+		NameHighlight: result.NameHighlight,
+	}
+	if includePII {
+		res.EmailHighlight = result.EmailHighlight
+	} else {
+		RedactPII(res.User)
 	}
+	return res
+}
+
+// RedactPII clears the fields only holders of constant.PermissionReadPII
+// may see.
+func RedactPII(res *UserResponse) {
+	res.Email = ""
+	res.StatusReason = ""
+	res.Phone = ""
+	res.PhoneVerifiedAt = nil
+	res.Attributes = nil
 }
 
 func ToDeleteUserResponse(success bool, message string) *DeleteUserResponse {
@@ -0,0 +1,18 @@
+package dto
+
+type CheckUsernameAvailabilityRequest struct {
+	Username string `json:"username" validate:"required"`
+}
+
+// CheckUsernameAvailabilityResponse gives the reason a username cannot be
+// used: invalid, reserved or taken.
+type CheckUsernameAvailabilityResponse struct {
+	Username  string `json:"username"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type GetUserByUsernameRequest struct {
+	Username string   `json:"username" validate:"required"`
+	ReadMask []string `json:"read_mask,omitempty"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Attributes holds the tenant-defined custom fields of a user. It is stored
+// as a JSONB object and is never nil once read from the database.
+type Attributes map[string]any
+
+func (a *Attributes) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*a = Attributes{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Attributes", src)
+	}
+
+	attributes := Attributes{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return err
+	}
+	*a = attributes
+	return nil
+}
+
+func (a Attributes) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(a)
+}
+
+type AttributeSchema struct {
+	TenantID  string          `json:"tenant_id"`
+	Schema    json.RawMessage `json:"schema"`
+	UpdatedBy string          `json:"updated_by"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// AvatarDeletion queues the images of a removed user for deletion from the
+// blob store.
+type AvatarDeletion struct {
+	TenantID  string    `json:"tenant_id"`
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// ErasureRequest tracks a right-to-erasure request until every service in
+// Services has confirmed it deleted or anonymized the user's data.
+type ErasureRequest struct {
+	ID                string     `json:"id"`
+	UserID            string     `json:"user_id"`
+	RequestedBy       string     `json:"requested_by"`
+	State             string     `json:"state"`
+	Services          []string   `json:"services"`
+	ConfirmedServices []string   `json:"confirmed_services"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	CompletedAt       *time.Time `json:"completed_at"`
+}
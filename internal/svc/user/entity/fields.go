@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"reflect"
+	"strings"
+)
+
+// UserFieldPaths is the set of field mask paths a User exposes, taken from
+// its json tags so masks stay in step with the model as it grows.
+var UserFieldPaths = fieldPaths(reflect.TypeOf(User{}))
+
+func fieldPaths(t reflect.Type) map[string]struct{} {
+	paths := map[string]struct{}{}
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		paths[name] = struct{}{}
+	}
+	return paths
+}
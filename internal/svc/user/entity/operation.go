@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Operation is a job run in the background by an operation worker. Params,
+// Progress and Result are JSON documents whose shape depends on Type.
+type Operation struct {
+	ID              string          `json:"id"`
+	TenantID        string          `json:"tenant_id"`
+	Type            string          `json:"type"`
+	State           string          `json:"state"`
+	Params          json.RawMessage `json:"params"`
+	Progress        json.RawMessage `json:"progress"`
+	Result          json.RawMessage `json:"result"`
+	ErrorCode       int             `json:"error_code"`
+	ErrorMessage    string          `json:"error_message"`
+	CreatedBy       string          `json:"created_by"`
+	CancelRequested bool            `json:"cancel_requested"`
+	Attempts        int             `json:"attempts"`
+	LeaseOwner      string          `json:"lease_owner"`
+	LeaseExpiresAt  *time.Time      `json:"lease_expires_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	StartedAt       *time.Time      `json:"started_at"`
+	FinishedAt      *time.Time      `json:"finished_at"`
+}
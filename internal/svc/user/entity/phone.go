@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// PhoneChallenge is an outstanding one-time code sent to Phone. Only a hash
+// of the code is kept.
+type PhoneChallenge struct {
+	Phone     string    `json:"phone"`
+	CodeHash  string    `json:"code_hash"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
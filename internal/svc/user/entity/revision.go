@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// UserRevision is the state of a user between ValidFrom and ValidTo. The
+// current revision has no ValidTo.
+type UserRevision struct {
+	User      *User
+	ValidFrom time.Time
+	ValidTo   *time.Time
+}
@@ -0,0 +1,8 @@
+package entity
+
+type UserSearchResult struct {
+	User           *User   `json:"user"`
+	Score          float64 `json:"score"`
+	NameHighlight  string  `json:"name_highlight"`
+	EmailHighlight string  `json:"email_highlight"`
+}
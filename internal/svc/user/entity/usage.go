@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// UsageRecord is one tenant's consumption of a metric on one UTC day.
+type UsageRecord struct {
+	Metric    string    `json:"metric"`
+	Day       time.Time `json:"day"`
+	Value     int64     `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
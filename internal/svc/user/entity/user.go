@@ -3,10 +3,25 @@ package entity
 import "time"
 
 type User struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              string     `json:"id"`
+	Email           string     `json:"email"`
+	FirstName       string     `json:"first_name"`
+	LastName        string     `json:"last_name"`
+	Status          string     `json:"status"`
+	StatusReason    string     `json:"status_reason"`
+	StatusChangedBy string     `json:"status_changed_by"`
+	StatusChangedAt *time.Time `json:"status_changed_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	Version         int64      `json:"version"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+	Phone           string     `json:"phone"`
+	Locale          string     `json:"locale"`
+	Timezone        string     `json:"timezone"`
+	AvatarRef       string     `json:"avatar_ref"`
+	Attributes      Attributes `json:"attributes"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at"`
+
+	Username          string     `json:"username"`
+	UsernameChangedAt *time.Time `json:"username_changed_at"`
 }
@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+type UsernameReservation struct {
+	Username      string    `json:"username"`
+	UserID        string    `json:"user_id"`
+	ReservedUntil time.Time `json:"reserved_until"`
+}
@@ -3,6 +3,7 @@ module github.com/hailsayan/achilles/internal/svc/user
 go 1.24
 
 require (
+	golang.org/x/text v0.25.0
 	google.golang.org/grpc v1.72.0
 	google.golang.org/protobuf v1.36.6
 )
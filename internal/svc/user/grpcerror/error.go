@@ -1,9 +1,16 @@
 package grpcerror
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func NewUserNotFoundError() error {
@@ -29,3 +36,331 @@ func NewCacheSetError() error {
 func NewCacheDeleteError() error {
 	return status.Error(codes.Internal, constant.CacheDeleteError)
 }
+
+func NewStatusReasonRequiredError() error {
+	return status.Error(codes.InvalidArgument, constant.StatusReasonRequiredMessage)
+}
+
+func NewInvalidStatusTransitionError(from, to string) error {
+	return status.Errorf(codes.FailedPrecondition, constant.InvalidStatusTransitionMessage, from, to)
+}
+
+func NewInvalidPageTokenError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidPageTokenMessage)
+}
+
+func NewInvalidOrderByError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidOrderByMessage)
+}
+
+func NewInvalidStatusFilterError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidStatusFilterMessage)
+}
+
+func NewSearchQueryTooShortError(minLength int) error {
+	return status.Errorf(codes.InvalidArgument, constant.SearchQueryTooShortMessage, minLength)
+}
+
+func NewUserIDsRequiredError() error {
+	return status.Error(codes.InvalidArgument, constant.UserIDsRequiredMessage)
+}
+
+func NewTooManyUserIDsError(max int) error {
+	return status.Errorf(codes.InvalidArgument, constant.TooManyUserIDsMessage, max)
+}
+
+func NewInvalidFieldMaskPathError(path string) error {
+	return status.Errorf(codes.InvalidArgument, constant.InvalidFieldMaskPathMessage, path)
+}
+
+func NewFieldNotUpdatableError(path string) error {
+	return status.Errorf(codes.InvalidArgument, constant.FieldNotUpdatableMessage, path)
+}
+
+func NewEmailRequiredError() error {
+	return status.Error(codes.InvalidArgument, constant.EmailRequiredMessage)
+}
+
+// NewVersionMismatchError carries the current version in an ErrorInfo detail
+// so clients can refetch or retry against it without parsing the message.
+func NewVersionMismatchError(currentVersion int64) error {
+	st := status.New(codes.Aborted, fmt.Sprintf(constant.VersionMismatchMessage, currentVersion))
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: constant.VersionMismatchReason,
+		Domain: constant.ErrorDomain,
+		Metadata: map[string]string{
+			"current_version": strconv.FormatInt(currentVersion, 10),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func NewRestoreWindowExpiredError(deadline time.Time) error {
+	return status.Errorf(codes.FailedPrecondition, constant.RestoreWindowExpiredMessage, deadline.Format(time.RFC3339))
+}
+
+func NewUnauthenticatedError() error {
+	return status.Error(codes.Unauthenticated, constant.UnauthenticatedMessage)
+}
+
+func NewPermissionDeniedError() error {
+	return status.Error(codes.PermissionDenied, constant.PermissionDeniedMessage)
+}
+
+func NewInvalidExportFormatError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidExportFormatMessage)
+}
+
+func NewOperationNotFoundError() error {
+	return status.Error(codes.NotFound, constant.OperationNotFoundMessage)
+}
+
+func NewInvalidPhoneError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidPhoneMessage)
+}
+
+func NewInvalidLocaleError(locale string) error {
+	return status.Error(codes.InvalidArgument, fmt.Sprintf(constant.InvalidLocaleMessage, locale))
+}
+
+func NewInvalidTimezoneError(timezone string) error {
+	return status.Error(codes.InvalidArgument, fmt.Sprintf(constant.InvalidTimezoneMessage, timezone))
+}
+
+func NewInvalidAvatarRefError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidAvatarRefMessage)
+}
+
+// NewInvalidAttributesError lists each schema violation as a field violation
+// keyed by its JSON pointer within the attributes.
+func NewInvalidAttributesError(problems []string) error {
+	st := status.New(codes.InvalidArgument, constant.InvalidAttributesMessage)
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(problems))
+	for _, problem := range problems {
+		pointer, description, _ := strings.Cut(problem, ": ")
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "attributes" + strings.TrimSuffix(pointer, "/"),
+			Description: description,
+		})
+	}
+
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func NewAttributeSchemaMissingError() error {
+	return status.Error(codes.FailedPrecondition, constant.AttributeSchemaMissingMessage)
+}
+
+func NewInvalidAttributeSchemaError(reason string) error {
+	return status.Error(codes.InvalidArgument, fmt.Sprintf(constant.InvalidAttributeSchemaMessage, reason))
+}
+
+func NewAttributeSchemaNotFoundError() error {
+	return status.Error(codes.NotFound, constant.AttributeSchemaNotFoundMessage)
+}
+
+func NewAvatarMetadataRequiredError() error {
+	return status.Error(codes.InvalidArgument, constant.AvatarMetadataRequiredMessage)
+}
+
+func NewAvatarEmptyError() error {
+	return status.Error(codes.InvalidArgument, constant.AvatarEmptyMessage)
+}
+
+func NewAvatarTooLargeError(maxBytes int64) error {
+	return status.Errorf(codes.InvalidArgument, constant.AvatarTooLargeMessage, maxBytes)
+}
+
+func NewUnsupportedAvatarTypeError() error {
+	return status.Error(codes.InvalidArgument, constant.UnsupportedAvatarTypeMessage)
+}
+
+func NewAvatarDimensionsTooLargeError(maxPixels int) error {
+	return status.Errorf(codes.InvalidArgument, constant.AvatarDimensionsTooLargeMessage, maxPixels)
+}
+
+func NewInvalidPhonePurposeError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidPhonePurposeMessage)
+}
+
+func NewPhoneRequiredError() error {
+	return status.Error(codes.FailedPrecondition, constant.PhoneRequiredMessage)
+}
+
+func NewPhoneNotVerifiedError() error {
+	return status.Error(codes.FailedPrecondition, constant.PhoneNotVerifiedMessage)
+}
+
+func NewInvalidPhoneCodeError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidPhoneCodeMessage)
+}
+
+func NewPhoneCodeAttemptsExceededError() error {
+	return status.Error(codes.ResourceExhausted, constant.PhoneCodeAttemptsExceededMessage)
+}
+
+// NewPhoneRateLimitedError carries a RetryInfo detail so clients can wait
+// out the limit without parsing the message.
+func NewPhoneRateLimitedError(retryAfter time.Duration) error {
+	seconds := int64(retryAfter.Round(time.Second).Seconds())
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf(constant.PhoneRateLimitedMessage, seconds))
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func NewInvalidUsernameError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidUsernameMessage)
+}
+
+func NewUsernameReservedError() error {
+	return status.Error(codes.InvalidArgument, constant.UsernameReservedMessage)
+}
+
+func NewUsernameExistsError() error {
+	return status.Error(codes.AlreadyExists, constant.UsernameExistsMessage)
+}
+
+func NewUsernameRequiredError() error {
+	return status.Error(codes.InvalidArgument, constant.UsernameRequiredMessage)
+}
+
+func NewUsernameChangeCooldownError(next time.Time) error {
+	return status.Errorf(codes.FailedPrecondition, constant.UsernameChangeCooldownMessage, next.Format(time.RFC3339))
+}
+
+func NewOrganizationNotFoundError() error {
+	return status.Error(codes.NotFound, constant.OrganizationNotFoundMessage)
+}
+
+func NewInvalidOrganizationNameError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidOrganizationNameMessage)
+}
+
+func NewInvalidOrganizationSlugError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidOrganizationSlugMessage)
+}
+
+func NewOrganizationSlugExistsError() error {
+	return status.Error(codes.AlreadyExists, constant.OrganizationSlugExistsMessage)
+}
+
+func NewInvalidOrganizationRoleError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidOrganizationRoleMessage)
+}
+
+func NewMemberNotFoundError() error {
+	return status.Error(codes.NotFound, constant.MemberNotFoundMessage)
+}
+
+func NewMemberExistsError() error {
+	return status.Error(codes.AlreadyExists, constant.MemberExistsMessage)
+}
+
+func NewOwnerCannotLeaveError() error {
+	return status.Error(codes.FailedPrecondition, constant.OwnerCannotLeaveMessage)
+}
+
+func NewOwnsOrganizationsError() error {
+	return status.Error(codes.FailedPrecondition, constant.OwnsOrganizationsMessage)
+}
+
+func NewInvitationNotFoundError() error {
+	return status.Error(codes.NotFound, constant.InvitationNotFoundMessage)
+}
+
+func NewInvitationExistsError() error {
+	return status.Error(codes.AlreadyExists, constant.InvitationExistsMessage)
+}
+
+func NewInvitationClosedError() error {
+	return status.Error(codes.FailedPrecondition, constant.InvitationClosedMessage)
+}
+
+func NewInvalidInvitationTokenError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidInvitationTokenMessage)
+}
+
+func NewSMSUnavailableError() error {
+	return status.Error(codes.Unavailable, constant.SMSUnavailableMessage)
+}
+
+func NewQuotaExceededError(metric string, limit int64) error {
+	return status.Errorf(codes.ResourceExhausted, constant.QuotaExceededMessage, metric, limit)
+}
+
+func NewUnsupportedStatusError(userStatus string) error {
+	return status.Errorf(codes.InvalidArgument, constant.UnsupportedStatusMessage, userStatus)
+}
+
+func NewReasonRequiredError() error {
+	return status.Error(codes.InvalidArgument, constant.ReasonRequiredMessage)
+}
+
+func NewInvalidEmailError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidEmailMessage)
+}
+
+func NewInvalidImportFormatError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidImportFormatMessage)
+}
+
+func NewImportOptionsRequiredError() error {
+	return status.Error(codes.InvalidArgument, constant.ImportOptionsRequiredMessage)
+}
+
+func NewInvalidImportHeaderError(problem string) error {
+	return status.Errorf(codes.InvalidArgument, constant.InvalidImportHeaderMessage, problem)
+}
+
+func NewInvalidImportRowError(problem string) error {
+	return status.Errorf(codes.InvalidArgument, constant.InvalidImportRowMessage, problem)
+}
+
+func NewImportLineTooLongError(line int64, max int) error {
+	return status.Errorf(codes.InvalidArgument, constant.ImportLineTooLongMessage, line, max)
+}
+
+func NewDuplicateImportEmailError(firstLine int64) error {
+	return status.Errorf(codes.AlreadyExists, constant.DuplicateImportEmailMessage, firstLine)
+}
+
+func NewDuplicateImportUsernameError(firstLine int64) error {
+	return status.Errorf(codes.AlreadyExists, constant.DuplicateImportUsernameMessage, firstLine)
+}
+
+func NewInvalidOperationTypeError(operationType string) error {
+	return status.Errorf(codes.InvalidArgument, constant.InvalidOperationTypeMessage, operationType)
+}
+
+func NewInvalidOperationStateError() error {
+	return status.Error(codes.InvalidArgument, constant.InvalidOperationStateMessage)
+}
+
+func NewOperationNotCancellableError(name string) error {
+	return status.Errorf(codes.FailedPrecondition, constant.OperationNotCancellableMessage, name)
+}
+
+func NewOperationCancelledError() error {
+	return status.Error(codes.Canceled, constant.OperationCancelledMessage)
+}
+
+func NewOperationAbandonedError(attempts int) error {
+	return status.Errorf(codes.Aborted, constant.OperationAbandonedMessage, attempts)
+}
+
+func NewImportUploadTooLargeError(max int) error {
+	return status.Errorf(codes.ResourceExhausted, constant.ImportUploadTooLargeMessage, max)
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	pb "github.com/hailsayan/achilles/internal/svc/user/pb/user"
+	"github.com/hailsayan/achilles/internal/svc/user/usecase"
+)
+
+// UserAdminHandler serves UserAdminService. The audit views share their
+// messages with UserService, so they are answered by the same code.
+type UserAdminHandler struct {
+	pb.UnimplementedUserAdminServiceServer
+	userUseCase usecase.UserUseCase
+	users       *UserHandler
+}
+
+func NewUserAdminHandler(userUseCase usecase.UserUseCase) *UserAdminHandler {
+	return &UserAdminHandler{
+		userUseCase: userUseCase,
+		users:       NewUserHandler(userUseCase),
+	}
+}
+
+func (h *UserAdminHandler) ForceEmailChange(ctx context.Context, req *pb.ForceEmailChangeRequest) (*pb.UserResponse, error) {
+	changeReq := &dto.ForceEmailChangeRequest{
+		ID:     req.UserId,
+		Email:  req.Email,
+		Reason: req.Reason,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		changeReq.ActorID = claims.UserID
+	}
+
+	res, err := h.userUseCase.ForceEmailChange(ctx, changeReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.users.toUserResponse(res), nil
+}
+
+func (h *UserAdminHandler) SetUserStatus(ctx context.Context, req *pb.SetUserStatusRequest) (*pb.UserResponse, error) {
+	statusReq := &dto.SetUserStatusRequest{
+		ID:     req.UserId,
+		Status: req.Status,
+		Reason: req.Reason,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		statusReq.ActorID = claims.UserID
+	}
+
+	res, err := h.userUseCase.SetUserStatus(ctx, statusReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.users.toUserResponse(res), nil
+}
+
+func (h *UserAdminHandler) BulkSetUserStatus(ctx context.Context, req *pb.BulkSetUserStatusRequest) (*pb.BulkOperationResponse, error) {
+	statusReq := &dto.BulkSetUserStatusRequest{
+		IDs:    req.UserIds,
+		Status: req.Status,
+		Reason: req.Reason,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		statusReq.ActorID = claims.UserID
+	}
+
+	res, err := h.userUseCase.BulkSetUserStatus(ctx, statusReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBulkOperationResponse(res), nil
+}
+
+func (h *UserAdminHandler) BulkDeleteUsers(ctx context.Context, req *pb.BulkDeleteUsersRequest) (*pb.BulkOperationResponse, error) {
+	deleteReq := &dto.BulkDeleteUsersRequest{
+		IDs:    req.UserIds,
+		Reason: req.Reason,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		deleteReq.ActorID = claims.UserID
+	}
+
+	res, err := h.userUseCase.BulkDeleteUsers(ctx, deleteReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBulkOperationResponse(res), nil
+}
+
+func (h *UserAdminHandler) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	return h.users.ListAuditEvents(ctx, req)
+}
+
+func (h *UserAdminHandler) ListUserRevisions(ctx context.Context, req *pb.ListUserRevisionsRequest) (*pb.ListUserRevisionsResponse, error) {
+	return h.users.ListUserRevisions(ctx, req)
+}
+
+func (h *UserAdminHandler) ImportUsers(stream pb.UserAdminService_ImportUsersServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	options := first.GetOptions()
+	if options == nil {
+		return grpcerror.NewImportOptionsRequiredError()
+	}
+
+	importReq := &dto.ImportUsersRequest{
+		Format:  options.Format,
+		DryRun:  options.DryRun,
+		Content: &importChunkReader{stream: stream},
+	}
+	if claims, ok := interceptor.ClaimsFromContext(stream.Context()); ok {
+		importReq.ActorID = claims.UserID
+	}
+
+	res, err := h.userUseCase.ImportUsers(stream.Context(), importReq)
+	if err != nil {
+		return err
+	}
+
+	rowErrors := make([]*pb.ImportRowError, 0, len(res.Errors))
+	for _, rowErr := range res.Errors {
+		rowErrors = append(rowErrors, &pb.ImportRowError{
+			Line:    rowErr.Line,
+			Email:   rowErr.Email,
+			Code:    int32(rowErr.Code),
+			Message: rowErr.Message,
+		})
+	}
+
+	return stream.SendAndClose(&pb.ImportUsersResponse{
+		Rows:            res.Rows,
+		Imported:        res.Imported,
+		Failed:          res.Failed,
+		Errors:          rowErrors,
+		ErrorsTruncated: res.ErrorsTruncated,
+		DryRun:          res.DryRun,
+	})
+}
+
+func (h *UserAdminHandler) StartImportUsers(stream pb.UserAdminService_StartImportUsersServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	options := first.GetOptions()
+	if options == nil {
+		return grpcerror.NewImportOptionsRequiredError()
+	}
+
+	startReq := &dto.StartImportUsersRequest{
+		Format:  options.Format,
+		DryRun:  options.DryRun,
+		Content: &importChunkReader{stream: stream},
+	}
+	if claims, ok := interceptor.ClaimsFromContext(stream.Context()); ok {
+		startReq.ActorID = claims.UserID
+	}
+
+	res, err := h.userUseCase.StartImportUsers(stream.Context(), startReq)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(toOperation(res))
+}
+
+// importChunkStream is the receiving side of ImportUsers and
+// StartImportUsers.
+type importChunkStream interface {
+	Recv() (*pb.ImportUsersRequest, error)
+}
+
+// importChunkReader reads the chunks of an import stream as one byte stream.
+type importChunkReader struct {
+	stream importChunkStream
+	buf    []byte
+}
+
+func (r *importChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if req.GetOptions() != nil {
+			return 0, grpcerror.NewImportOptionsRequiredError()
+		}
+		r.buf = req.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (h *UserAdminHandler) ExportUsers(req *pb.ExportUsersRequest, stream pb.UserAdminService_ExportUsersServer) error {
+	return h.userUseCase.ExportUsers(stream.Context(), &dto.ExportUsersRequest{
+		Status: req.Status,
+	}, func(user *dto.UserResponse) error {
+		return stream.Send(h.users.toUserResponse(user))
+	})
+}
+
+func toBulkOperationResponse(res *dto.BulkOperationResponse) *pb.BulkOperationResponse {
+	results := make([]*pb.BulkOperationResult, 0, len(res.Results))
+	for _, result := range res.Results {
+		results = append(results, &pb.BulkOperationResult{
+			UserId:  result.UserID,
+			Code:    int32(result.Code),
+			Message: result.Message,
+		})
+	}
+	return &pb.BulkOperationResponse{
+		Results: results,
+	}
+}
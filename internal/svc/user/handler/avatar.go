@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/hailsayan/achilles/internal/pkg/blob"
+	"github.com/hailsayan/achilles/internal/pkg/logger"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+)
+
+// AvatarHTTPHandler serves stored avatar images under constant.AvatarHTTPPath.
+// Keys embed a content hash, so responses are cacheable indefinitely;
+// http.ServeContent answers range and conditional requests.
+type AvatarHTTPHandler struct {
+	store blob.BlobStore
+	log   logger.Logger
+}
+
+func NewAvatarHTTPHandler(store blob.BlobStore, log logger.Logger) *AvatarHTTPHandler {
+	return &AvatarHTTPHandler{
+		store: store,
+		log:   log,
+	}
+}
+
+func (h *AvatarHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	if !strings.HasPrefix(r.URL.Path, constant.AvatarHTTPPath) || blob.ValidateKey(key) != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	object, err := h.store.Get(r.Context(), key)
+	if errors.Is(err, blob.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		h.log.Errorf("failed to read avatar %s: %v", key, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", object.ContentType)
+	header.Set("Cache-Control", constant.AvatarCacheControl)
+	header.Set("X-Content-Type-Options", "nosniff")
+	if object.ETag != "" {
+		header.Set("ETag", object.ETag)
+	}
+
+	http.ServeContent(w, r, "", object.ModTime, bytes.NewReader(object.Data))
+}
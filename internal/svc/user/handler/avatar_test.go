@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/blob"
+)
+
+type avatarStore struct {
+	blob.BlobStore
+
+	objects map[string]*blob.Object
+}
+
+func (s *avatarStore) Get(ctx context.Context, key string) (*blob.Object, error) {
+	object, ok := s.objects[key]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return object, nil
+}
+
+func TestAvatarHTTPHandler(t *testing.T) {
+	const key = "avatars/42/abcdef/128.png"
+	handler := NewAvatarHTTPHandler(&avatarStore{objects: map[string]*blob.Object{
+		key: {
+			Data:        []byte("0123456789"),
+			ContentType: "image/png",
+			ModTime:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			ETag:        `"abcdef"`,
+		},
+	}}, nil)
+
+	tests := map[string]struct {
+		method     string
+		path       string
+		header     http.Header
+		wantStatus int
+		wantBody   string
+	}{
+		"whole object":          {path: "/" + key, wantStatus: http.StatusOK, wantBody: "0123456789"},
+		"range":                 {path: "/" + key, header: http.Header{"Range": {"bytes=2-5"}}, wantStatus: http.StatusPartialContent, wantBody: "2345"},
+		"suffix range":          {path: "/" + key, header: http.Header{"Range": {"bytes=-3"}}, wantStatus: http.StatusPartialContent, wantBody: "789"},
+		"unsatisfiable range":   {path: "/" + key, header: http.Header{"Range": {"bytes=20-30"}}, wantStatus: http.StatusRequestedRangeNotSatisfiable},
+		"if-none-match current": {path: "/" + key, header: http.Header{"If-None-Match": {`"abcdef"`}}, wantStatus: http.StatusNotModified},
+		"if-none-match any":     {path: "/" + key, header: http.Header{"If-None-Match": {"*"}}, wantStatus: http.StatusNotModified},
+		"if-none-match stale":   {path: "/" + key, header: http.Header{"If-None-Match": {`"other"`}}, wantStatus: http.StatusOK, wantBody: "0123456789"},
+		// A stale ETag must not be overridden by a matching date.
+		"if-none-match stale with date": {path: "/" + key, header: http.Header{"If-None-Match": {`"other"`}, "If-Modified-Since": {"Tue, 02 Jan 2024 03:04:05 GMT"}}, wantStatus: http.StatusOK, wantBody: "0123456789"},
+		"head":                          {method: http.MethodHead, path: "/" + key, wantStatus: http.StatusOK},
+		"post":                          {method: http.MethodPost, path: "/" + key, wantStatus: http.StatusMethodNotAllowed},
+		"missing":                       {path: "/avatars/42/abcdef/64.png", wantStatus: http.StatusNotFound},
+		"outside avatars":               {path: "/users/42", wantStatus: http.StatusNotFound},
+		"dot segment":                   {path: "/avatars/../users/42", wantStatus: http.StatusNotFound},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "http://example.com/", nil)
+			req.URL.Path = tt.path
+			for name, values := range tt.header {
+				req.Header[name] = values
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+			body, _ := io.ReadAll(res.Body)
+			if tt.wantBody != "" && string(body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+			if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusPartialContent || res.StatusCode == http.StatusNotModified {
+				if got := res.Header.Get("ETag"); got != `"abcdef"` {
+					t.Errorf("ETag = %q, want %q", got, `"abcdef"`)
+				}
+			}
+			if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusPartialContent {
+				if got := res.Header.Get("X-Content-Type-Options"); got != "nosniff" {
+					t.Errorf("X-Content-Type-Options = %q, want nosniff", got)
+				}
+				if got := res.Header.Get("Content-Type"); got != "image/png" {
+					t.Errorf("Content-Type = %q, want image/png", got)
+				}
+			}
+		})
+	}
+}
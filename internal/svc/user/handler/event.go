@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hailsayan/achilles/internal/pkg/events"
+	"github.com/hailsayan/achilles/internal/pkg/mq"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/user/usecase"
+)
+
+func NewUserErasureConfirmedHandler(userUseCase usecase.UserUseCase) mq.KafkaHandler {
+	return func(ctx context.Context, body []byte) error {
+		event := &events.UserErasureConfirmedEvent{}
+		if err := json.Unmarshal(body, event); err != nil {
+			return err
+		}
+		ctx = tenant.WithTenant(ctx, event.TenantID)
+		return userUseCase.ConfirmErasure(ctx, event)
+	}
+}
@@ -2,10 +2,17 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 	
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
 	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
 	pb "github.com/hailsayan/achilles/internal/svc/user/pb/user"
 	"github.com/hailsayan/achilles/internal/svc/user/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type UserHandler struct {
@@ -21,9 +28,15 @@ func NewUserHandler(userUseCase usecase.UserUseCase) *UserHandler {
 
 func (h *UserHandler) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.UserResponse, error) {
 	createReq := &dto.CreateUserRequest{
-		Email:     req.Email,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
+		Email:      req.Email,
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		Phone:      req.Phone,
+		Locale:     req.Locale,
+		Timezone:   req.Timezone,
+		AvatarRef:  req.AvatarRef,
+		Attributes: req.GetAttributes().AsMap(),
+		Username:   req.Username,
 	}
 
 	res, err := h.userUseCase.CreateUser(ctx, createReq)
@@ -31,12 +44,17 @@ func (h *UserHandler) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 		return nil, err
 	}
 
-	return h.toUserResponse(res.ID, res.Email, res.FirstName, res.LastName, res.CreatedAt.Unix(), res.UpdatedAt.Unix()), nil
+	return h.toUserResponse(res), nil
 }
 
 func (h *UserHandler) GetUserByID(ctx context.Context, req *pb.GetUserRequest) (*pb.UserResponse, error) {
 	getUserReq := &dto.GetUserRequest{
-		ID: req.UserId,
+		ID:       req.UserId,
+		ReadMask: req.GetReadMask().GetPaths(),
+	}
+	if req.AsOf > 0 {
+		asOf := time.Unix(req.AsOf, 0).UTC()
+		getUserReq.AsOf = &asOf
 	}
 
 	res, err := h.userUseCase.GetUser(ctx, getUserReq)
@@ -44,12 +62,55 @@ func (h *UserHandler) GetUserByID(ctx context.Context, req *pb.GetUserRequest) (
 		return nil, err
 	}
 
-	return h.toUserResponse(res.ID, res.Email, res.FirstName, res.LastName, res.CreatedAt.Unix(), res.UpdatedAt.Unix()), nil
+	return h.toUserResponse(res), nil
+}
+
+func (h *UserHandler) GetUserByEmail(ctx context.Context, req *pb.GetUserByEmailRequest) (*pb.UserResponse, error) {
+	getUserReq := &dto.GetUserByEmailRequest{
+		Email:    req.Email,
+		ReadMask: req.GetReadMask().GetPaths(),
+	}
+
+	res, err := h.userUseCase.GetUserByEmail(ctx, getUserReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.toUserResponse(res), nil
+}
+
+func (h *UserHandler) GetUserByUsername(ctx context.Context, req *pb.GetUserByUsernameRequest) (*pb.UserResponse, error) {
+	res, err := h.userUseCase.GetUserByUsername(ctx, &dto.GetUserByUsernameRequest{
+		Username: req.Username,
+		ReadMask: req.GetReadMask().GetPaths(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h.toUserResponse(res), nil
+}
+
+func (h *UserHandler) CheckUsernameAvailability(ctx context.Context, req *pb.CheckUsernameAvailabilityRequest) (*pb.CheckUsernameAvailabilityResponse, error) {
+	res, err := h.userUseCase.CheckUsernameAvailability(ctx, &dto.CheckUsernameAvailabilityRequest{
+		Username: req.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CheckUsernameAvailabilityResponse{
+		Username:  res.Username,
+		Available: res.Available,
+		Reason:    res.Reason,
+	}, nil
 }
 
 func (h *UserHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UserResponse, error) {
 	updateReq := &dto.UpdateUserRequest{
-		ID: req.UserId,
+		ID:              req.UserId,
+		UpdateMask:      req.GetUpdateMask().GetPaths(),
+		ExpectedVersion: req.ExpectedVersion,
 	}
 
 	if req.Email != nil {
@@ -61,18 +122,40 @@ func (h *UserHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 	if req.LastName != nil {
 		updateReq.LastName = req.LastName
 	}
+	if req.Phone != nil {
+		updateReq.Phone = req.Phone
+	}
+	if req.Locale != nil {
+		updateReq.Locale = req.Locale
+	}
+	if req.Timezone != nil {
+		updateReq.Timezone = req.Timezone
+	}
+	if req.AvatarRef != nil {
+		updateReq.AvatarRef = req.AvatarRef
+	}
+	if req.Attributes != nil {
+		updateReq.Attributes = req.Attributes.AsMap()
+	}
+	if req.Username != nil {
+		updateReq.Username = req.Username
+	}
 
 	res, err := h.userUseCase.UpdateUser(ctx, updateReq)
 	if err != nil {
 		return nil, err
 	}
 
-	return h.toUserResponse(res.ID, res.Email, res.FirstName, res.LastName, res.CreatedAt.Unix(), res.UpdatedAt.Unix()), nil
+	return h.toUserResponse(res), nil
 }
 
 func (h *UserHandler) DeleteUserByID(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
 	deleteReq := &dto.DeleteUserRequest{
-		ID: req.UserId,
+		ID:              req.UserId,
+		ExpectedVersion: req.ExpectedVersion,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		deleteReq.ActorID = claims.UserID
 	}
 
 	res, err := h.userUseCase.DeleteUser(ctx, deleteReq)
@@ -86,13 +169,517 @@ func (h *UserHandler) DeleteUserByID(ctx context.Context, req *pb.DeleteUserRequ
 	}, nil
 }
 
-func (h *UserHandler) toUserResponse(id, email, firstName, lastName string, createdAt, updatedAt int64) *pb.UserResponse {
+func (h *UserHandler) RestoreUser(ctx context.Context, req *pb.RestoreUserRequest) (*pb.UserResponse, error) {
+	restoreReq := &dto.RestoreUserRequest{
+		ID: req.UserId,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		restoreReq.ActorID = claims.UserID
+	}
+
+	res, err := h.userUseCase.RestoreUser(ctx, restoreReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.toUserResponse(res), nil
+}
+
+func (h *UserHandler) SuspendUser(ctx context.Context, req *pb.ChangeUserStatusRequest) (*pb.UserResponse, error) {
+	res, err := h.userUseCase.SuspendUser(ctx, h.toChangeUserStatusRequest(ctx, req))
+	if err != nil {
+		return nil, err
+	}
+
+	return h.toUserResponse(res), nil
+}
+
+func (h *UserHandler) ReinstateUser(ctx context.Context, req *pb.ChangeUserStatusRequest) (*pb.UserResponse, error) {
+	res, err := h.userUseCase.ReinstateUser(ctx, h.toChangeUserStatusRequest(ctx, req))
+	if err != nil {
+		return nil, err
+	}
+
+	return h.toUserResponse(res), nil
+}
+
+func (h *UserHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	listReq := &dto.ListUsersRequest{
+		PageSize:    int(req.PageSize),
+		PageToken:   req.PageToken,
+		EmailPrefix: req.EmailPrefix,
+		Name:        req.Name,
+		Status:      req.Status,
+		OrderBy:     req.OrderBy,
+		ReadMask:    req.GetReadMask().GetPaths(),
+
+		OrganizationID: req.OrganizationId,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		listReq.IncludePII = claims.HasPermission(constant.PermissionReadPII)
+	}
+	if req.CreatedAfter > 0 {
+		createdAfter := time.Unix(req.CreatedAfter, 0).UTC()
+		listReq.CreatedAfter = &createdAfter
+	}
+	if req.CreatedBefore > 0 {
+		createdBefore := time.Unix(req.CreatedBefore, 0).UTC()
+		listReq.CreatedBefore = &createdBefore
+	}
+
+	res, err := h.userUseCase.ListUsers(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*pb.UserResponse, 0, len(res.Users))
+	for _, user := range res.Users {
+		users = append(users, h.toUserResponse(user))
+	}
+
+	return &pb.ListUsersResponse{
+		Users:         users,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+func (h *UserHandler) BatchGetUsers(ctx context.Context, req *pb.BatchGetUsersRequest) (*pb.BatchGetUsersResponse, error) {
+	res, err := h.userUseCase.BatchGetUsers(ctx, &dto.BatchGetUsersRequest{
+		IDs:      req.Ids,
+		ReadMask: req.GetReadMask().GetPaths(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*pb.UserResponse, 0, len(res.Users))
+	for _, user := range res.Users {
+		users = append(users, h.toUserResponse(user))
+	}
+
+	return &pb.BatchGetUsersResponse{
+		Users:      users,
+		MissingIds: res.MissingIDs,
+	}, nil
+}
+
+func (h *UserHandler) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	searchReq := &dto.SearchUsersRequest{
+		Query:    req.Query,
+		PageSize: int(req.PageSize),
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		searchReq.IncludePII = claims.HasPermission(constant.PermissionReadPII)
+	}
+
+	res, err := h.userUseCase.SearchUsers(ctx, searchReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*pb.UserSearchResult, 0, len(res.Results))
+	for _, result := range res.Results {
+		results = append(results, &pb.UserSearchResult{
+			User:           h.toUserResponse(result.User),
+			Score:          result.Score,
+			NameHighlight:  result.NameHighlight,
+			EmailHighlight: result.EmailHighlight,
+		})
+	}
+
+	return &pb.SearchUsersResponse{
+		Results: results,
+	}, nil
+}
+
+func (h *UserHandler) toChangeUserStatusRequest(ctx context.Context, req *pb.ChangeUserStatusRequest) *dto.ChangeUserStatusRequest {
+	statusReq := &dto.ChangeUserStatusRequest{
+		ID:     req.UserId,
+		Reason: req.Reason,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		statusReq.ActorID = claims.UserID
+	}
+	return statusReq
+}
+
+func (h *UserHandler) toUserResponse(res *dto.UserResponse) *pb.UserResponse {
 	return &pb.UserResponse{
-		Id:        id,
-		Email:     email,
-		FirstName: firstName,
-		LastName:  lastName,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
-	}
-}
\ No newline at end of file
+		Id:           res.ID,
+		Email:        res.Email,
+		FirstName:    res.FirstName,
+		LastName:     res.LastName,
+		Status:       res.Status,
+		StatusReason: res.StatusReason,
+		CreatedAt:    unixOrZero(res.CreatedAt),
+		UpdatedAt:    unixOrZero(res.UpdatedAt),
+		Version:      res.Version,
+		Etag:         res.ETag,
+		Phone:        res.Phone,
+		Locale:       res.Locale,
+		Timezone:     res.Timezone,
+		AvatarRef:    res.AvatarRef,
+		Attributes:   toStruct(res.Attributes),
+
+		PhoneVerifiedAt: unixOrZeroPtr(res.PhoneVerifiedAt),
+
+		Username:          res.Username,
+		UsernameChangedAt: unixOrZeroPtr(res.UsernameChangedAt),
+	}
+}
+
+// toStruct drops attributes a read mask left out, and any that protobuf
+// cannot represent, rather than failing the whole response.
+func toStruct(attributes map[string]any) *structpb.Struct {
+	if attributes == nil {
+		return nil
+	}
+	converted, err := structpb.NewStruct(attributes)
+	if err != nil {
+		return nil
+	}
+	return converted
+}
+
+func unixOrZeroPtr(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return unixOrZero(*t)
+}
+
+// unixOrZero keeps timestamps dropped by a read mask at 0 instead of the
+// Unix value of the zero time.
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func (h *UserHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	res, err := h.userUseCase.ExportUserData(ctx, &dto.ExportUserDataRequest{
+		ID:     req.UserId,
+		Format: req.Format,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ExportUserDataResponse{
+		Filename:    res.Filename,
+		ContentType: res.ContentType,
+		Data:        res.Data,
+	}, nil
+}
+
+func (h *UserHandler) StartExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.Operation, error) {
+	exportReq := &dto.StartExportUserDataRequest{
+		ID:     req.UserId,
+		Format: req.Format,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		exportReq.ActorID = claims.UserID
+	}
+
+	res, err := h.userUseCase.StartExportUserData(ctx, exportReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOperation(res), nil
+}
+
+func (h *UserHandler) RequestErasure(ctx context.Context, req *pb.RequestErasureRequest) (*pb.Operation, error) {
+	erasureReq := &dto.RequestErasureRequest{
+		ID: req.UserId,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		erasureReq.ActorID = claims.UserID
+	}
+
+	res, err := h.userUseCase.RequestErasure(ctx, erasureReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOperation(res), nil
+}
+
+func (h *UserHandler) GetOperation(ctx context.Context, req *pb.GetOperationRequest) (*pb.Operation, error) {
+	res, err := h.userUseCase.GetOperation(ctx, &dto.GetOperationRequest{
+		Name: req.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOperation(res), nil
+}
+
+func toOperation(res *dto.OperationResponse) *pb.Operation {
+	operation := &pb.Operation{
+		Name:            res.Name,
+		Done:            res.Done,
+		Type:            res.Type,
+		State:           res.State,
+		ProgressJson:    string(res.Progress),
+		CreatedBy:       res.CreatedBy,
+		CancelRequested: res.CancelRequested,
+		CreateTime:      res.CreatedAt.Unix(),
+		UpdateTime:      res.UpdatedAt.Unix(),
+	}
+	if res.CompletedAt != nil {
+		operation.EndTime = res.CompletedAt.Unix()
+	}
+	if res.Done && res.ErrorCode != codes.OK {
+		operation.Error = &pb.OperationError{
+			Code:    int32(res.ErrorCode),
+			Message: res.ErrorMessage,
+		}
+	} else if res.Done {
+		operation.ResponseJson = string(res.Result)
+	}
+
+	if res.Type == constant.OperationTypeErasure {
+		operation.Metadata = &pb.ErasureMetadata{
+			UserId:            res.UserID,
+			State:             res.State,
+			Services:          res.Services,
+			ConfirmedServices: res.ConfirmedServices,
+			CreateTime:        operation.CreateTime,
+			UpdateTime:        operation.UpdateTime,
+			EndTime:           operation.EndTime,
+		}
+	}
+
+	return operation
+}
+
+func (h *UserHandler) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	listReq := &dto.ListAuditEventsRequest{
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+		ActorID:   req.ActorId,
+		TargetID:  req.TargetId,
+		Operation: req.Operation,
+	}
+	if req.From > 0 {
+		from := time.Unix(req.From, 0).UTC()
+		listReq.From = &from
+	}
+	if req.To > 0 {
+		to := time.Unix(req.To, 0).UTC()
+		listReq.To = &to
+	}
+
+	res, err := h.userUseCase.ListAuditEvents(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*pb.AuditEvent, 0, len(res.Events))
+	for _, event := range res.Events {
+		metadata, _ := json.Marshal(event.Metadata)
+		changes, _ := json.Marshal(event.Changes)
+		events = append(events, &pb.AuditEvent{
+			Id:             event.ID,
+			ActorId:        event.ActorID,
+			ImpersonatorId: event.ImpersonatorID,
+			TargetId:       event.TargetID,
+			Operation:      event.Operation,
+			RequestId:      event.RequestID,
+			SourceIp:       event.SourceIP,
+			MetadataJson:   string(metadata),
+			ChangesJson:    string(changes),
+			CreatedAt:      event.CreatedAt.Unix(),
+		})
+	}
+
+	return &pb.ListAuditEventsResponse{
+		Events:        events,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+func (h *UserHandler) ListUserRevisions(ctx context.Context, req *pb.ListUserRevisionsRequest) (*pb.ListUserRevisionsResponse, error) {
+	listReq := &dto.ListUserRevisionsRequest{
+		ID:        req.UserId,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		listReq.IncludePII = claims.HasPermission(constant.PermissionReadPII)
+	}
+
+	res, err := h.userUseCase.ListUserRevisions(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*pb.UserRevision, 0, len(res.Revisions))
+	for _, revision := range res.Revisions {
+		changes, _ := json.Marshal(revision.Changes)
+		pbRevision := &pb.UserRevision{
+			Version:     revision.Version,
+			ValidFrom:   revision.ValidFrom.Unix(),
+			ChangesJson: string(changes),
+		}
+		if revision.ValidTo != nil {
+			pbRevision.ValidTo = revision.ValidTo.Unix()
+		}
+		revisions = append(revisions, pbRevision)
+	}
+
+	return &pb.ListUserRevisionsResponse{
+		Revisions:     revisions,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+func (h *UserHandler) GetAttributeSchema(ctx context.Context, req *pb.GetAttributeSchemaRequest) (*pb.AttributeSchema, error) {
+	res, err := h.userUseCase.GetAttributeSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAttributeSchema(res), nil
+}
+
+func (h *UserHandler) SetAttributeSchema(ctx context.Context, req *pb.SetAttributeSchemaRequest) (*pb.AttributeSchema, error) {
+	setReq := &dto.SetAttributeSchemaRequest{
+		Schema: json.RawMessage(req.SchemaJson),
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		setReq.ActorID = claims.UserID
+	}
+
+	res, err := h.userUseCase.SetAttributeSchema(ctx, setReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAttributeSchema(res), nil
+}
+
+func toAttributeSchema(res *dto.AttributeSchemaResponse) *pb.AttributeSchema {
+	return &pb.AttributeSchema{
+		TenantId:   res.TenantID,
+		SchemaJson: string(res.Schema),
+		UpdatedBy:  res.UpdatedBy,
+		UpdatedAt:  res.UpdatedAt.Unix(),
+	}
+}
+
+func (h *UserHandler) UploadAvatar(stream pb.UserService_UploadAvatarServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	metadata := first.GetMetadata()
+	if metadata == nil {
+		return grpcerror.NewAvatarMetadataRequiredError()
+	}
+
+	res, err := h.userUseCase.UploadAvatar(stream.Context(), &dto.UploadAvatarRequest{
+		UserID:  metadata.UserId,
+		Content: &avatarChunkReader{stream: stream},
+	})
+	if err != nil {
+		return err
+	}
+
+	images := make([]*pb.AvatarImage, 0, len(res.Images))
+	for _, image := range res.Images {
+		images = append(images, &pb.AvatarImage{
+			Size:        int32(image.Size),
+			Url:         image.URL,
+			ContentType: image.ContentType,
+		})
+	}
+
+	return stream.SendAndClose(&pb.UploadAvatarResponse{
+		User:   h.toUserResponse(res.User),
+		Images: images,
+	})
+}
+
+// avatarChunkReader reads the chunks of an upload stream as one byte stream.
+type avatarChunkReader struct {
+	stream pb.UserService_UploadAvatarServer
+	buf    []byte
+}
+
+func (r *avatarChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if req.GetMetadata() != nil {
+			return 0, grpcerror.NewAvatarMetadataRequiredError()
+		}
+		r.buf = req.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (h *UserHandler) StartPhoneVerification(ctx context.Context, req *pb.StartPhoneVerificationRequest) (*pb.StartPhoneVerificationResponse, error) {
+	res, err := h.userUseCase.StartPhoneVerification(ctx, &dto.StartPhoneVerificationRequest{
+		UserID:  req.UserId,
+		Email:   req.Email,
+		Purpose: req.Purpose,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.StartPhoneVerificationResponse{
+		Message:    res.Message,
+		ExpiresIn:  res.ExpiresIn,
+		RetryAfter: res.RetryAfter,
+	}, nil
+}
+
+func (h *UserHandler) ConfirmPhoneVerification(ctx context.Context, req *pb.ConfirmPhoneVerificationRequest) (*pb.ConfirmPhoneVerificationResponse, error) {
+	res, err := h.userUseCase.ConfirmPhoneVerification(ctx, &dto.ConfirmPhoneVerificationRequest{
+		UserID:  req.UserId,
+		Purpose: req.Purpose,
+		Code:    req.Code,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	confirmRes := &pb.ConfirmPhoneVerificationResponse{
+		Verified: res.Verified,
+	}
+	if res.User != nil {
+		confirmRes.User = h.toUserResponse(res.User)
+	}
+	return confirmRes, nil
+}
+
+func (h *UserHandler) GetUsage(ctx context.Context, req *pb.GetUsageRequest) (*pb.Usage, error) {
+	res, err := h.userUseCase.GetUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &pb.Usage{
+		TenantId:    res.TenantID,
+		PeriodStart: res.PeriodStart.Unix(),
+		Metrics:     make([]*pb.UsageMetric, 0, len(res.Metrics)),
+	}
+	for _, metric := range res.Metrics {
+		usage.Metrics = append(usage.Metrics, &pb.UsageMetric{
+			Metric: metric.Metric,
+			Used:   metric.Used,
+			Limit:  metric.Limit,
+		})
+	}
+	return usage, nil
+}
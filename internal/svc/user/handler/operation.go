@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	pb "github.com/hailsayan/achilles/internal/svc/user/pb/user"
+	"github.com/hailsayan/achilles/internal/svc/user/usecase"
+)
+
+type OperationHandler struct {
+	pb.UnimplementedOperationServiceServer
+	userUseCase usecase.UserUseCase
+}
+
+func NewOperationHandler(userUseCase usecase.UserUseCase) *OperationHandler {
+	return &OperationHandler{
+		userUseCase: userUseCase,
+	}
+}
+
+func (h *OperationHandler) CreateOperation(ctx context.Context, req *pb.CreateOperationRequest) (*pb.Operation, error) {
+	createReq := &dto.CreateOperationRequest{
+		Type: req.Type,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		createReq.ActorID = claims.UserID
+	}
+
+	res, err := h.userUseCase.CreateOperation(ctx, createReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOperation(res), nil
+}
+
+func (h *OperationHandler) GetOperation(ctx context.Context, req *pb.GetOperationRequest) (*pb.Operation, error) {
+	res, err := h.userUseCase.GetOperation(ctx, &dto.GetOperationRequest{
+		Name: req.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOperation(res), nil
+}
+
+func (h *OperationHandler) ListOperations(ctx context.Context, req *pb.ListOperationsRequest) (*pb.ListOperationsResponse, error) {
+	res, err := h.userUseCase.ListOperations(ctx, &dto.ListOperationsRequest{
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+		Type:      req.Type,
+		State:     req.State,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]*pb.Operation, 0, len(res.Operations))
+	for _, operation := range res.Operations {
+		operations = append(operations, toOperation(operation))
+	}
+
+	return &pb.ListOperationsResponse{
+		Operations:    operations,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+func (h *OperationHandler) CancelOperation(ctx context.Context, req *pb.CancelOperationRequest) (*pb.Operation, error) {
+	res, err := h.userUseCase.CancelOperation(ctx, &dto.CancelOperationRequest{
+		Name: req.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOperation(res), nil
+}
+
+func (h *OperationHandler) WaitOperation(ctx context.Context, req *pb.WaitOperationRequest) (*pb.Operation, error) {
+	res, err := h.userUseCase.WaitOperation(ctx, &dto.WaitOperationRequest{
+		Name:    req.Name,
+		Timeout: time.Duration(req.TimeoutSeconds) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOperation(res), nil
+}
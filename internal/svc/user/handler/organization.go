@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	pb "github.com/hailsayan/achilles/internal/svc/user/pb/user"
+	"github.com/hailsayan/achilles/internal/svc/user/usecase"
+)
+
+type OrganizationHandler struct {
+	pb.UnimplementedOrganizationServiceServer
+	organizationUseCase usecase.OrganizationUseCase
+}
+
+func NewOrganizationHandler(organizationUseCase usecase.OrganizationUseCase) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationUseCase: organizationUseCase,
+	}
+}
+
+func (h *OrganizationHandler) CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.Organization, error) {
+	res, err := h.organizationUseCase.CreateOrganization(ctx, &dto.CreateOrganizationRequest{
+		Name: req.Name,
+		Slug: req.Slug,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrganization(res), nil
+}
+
+func (h *OrganizationHandler) GetOrganization(ctx context.Context, req *pb.GetOrganizationRequest) (*pb.Organization, error) {
+	res, err := h.organizationUseCase.GetOrganization(ctx, &dto.GetOrganizationRequest{
+		ID: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrganization(res), nil
+}
+
+func (h *OrganizationHandler) UpdateOrganization(ctx context.Context, req *pb.UpdateOrganizationRequest) (*pb.Organization, error) {
+	res, err := h.organizationUseCase.UpdateOrganization(ctx, &dto.UpdateOrganizationRequest{
+		ID:   req.Id,
+		Name: req.Name,
+		Slug: req.Slug,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrganization(res), nil
+}
+
+func (h *OrganizationHandler) DeleteOrganization(ctx context.Context, req *pb.DeleteOrganizationRequest) (*pb.DeleteOrganizationResponse, error) {
+	res, err := h.organizationUseCase.DeleteOrganization(ctx, &dto.DeleteOrganizationRequest{
+		ID: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteOrganizationResponse{
+		Success: res.Success,
+		Message: res.Message,
+	}, nil
+}
+
+func (h *OrganizationHandler) ListOrganizations(ctx context.Context, req *pb.ListOrganizationsRequest) (*pb.ListOrganizationsResponse, error) {
+	res, err := h.organizationUseCase.ListOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations := make([]*pb.Organization, 0, len(res.Organizations))
+	for _, organization := range res.Organizations {
+		organizations = append(organizations, toOrganization(organization))
+	}
+	return &pb.ListOrganizationsResponse{Organizations: organizations}, nil
+}
+
+func (h *OrganizationHandler) TransferOwnership(ctx context.Context, req *pb.TransferOwnershipRequest) (*pb.Organization, error) {
+	res, err := h.organizationUseCase.TransferOwnership(ctx, &dto.TransferOwnershipRequest{
+		OrganizationID: req.OrganizationId,
+		NewOwnerID:     req.NewOwnerId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrganization(res), nil
+}
+
+func (h *OrganizationHandler) GetMembership(ctx context.Context, req *pb.GetMembershipRequest) (*pb.Membership, error) {
+	res, err := h.organizationUseCase.GetMembership(ctx, &dto.GetMembershipRequest{
+		OrganizationID: req.OrganizationId,
+		UserID:         req.UserId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toMembership(res), nil
+}
+
+func (h *OrganizationHandler) ListMembers(ctx context.Context, req *pb.ListMembersRequest) (*pb.ListMembersResponse, error) {
+	res, err := h.organizationUseCase.ListMembers(ctx, &dto.ListMembersRequest{
+		OrganizationID: req.OrganizationId,
+		PageSize:       int(req.PageSize),
+		PageToken:      req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*pb.Membership, 0, len(res.Members))
+	for _, member := range res.Members {
+		members = append(members, toMembership(member))
+	}
+	return &pb.ListMembersResponse{
+		Members:       members,
+		NextPageToken: res.NextPageToken,
+	}, nil
+}
+
+func (h *OrganizationHandler) UpdateMemberRole(ctx context.Context, req *pb.UpdateMemberRoleRequest) (*pb.Membership, error) {
+	res, err := h.organizationUseCase.UpdateMemberRole(ctx, &dto.UpdateMemberRoleRequest{
+		OrganizationID: req.OrganizationId,
+		UserID:         req.UserId,
+		Role:           req.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toMembership(res), nil
+}
+
+func (h *OrganizationHandler) RemoveMember(ctx context.Context, req *pb.RemoveMemberRequest) (*pb.RemoveMemberResponse, error) {
+	res, err := h.organizationUseCase.RemoveMember(ctx, &dto.RemoveMemberRequest{
+		OrganizationID: req.OrganizationId,
+		UserID:         req.UserId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RemoveMemberResponse{
+		Success: res.Success,
+		Message: res.Message,
+	}, nil
+}
+
+func (h *OrganizationHandler) InviteMember(ctx context.Context, req *pb.InviteMemberRequest) (*pb.OrganizationInvitation, error) {
+	res, err := h.organizationUseCase.InviteMember(ctx, &dto.InviteMemberRequest{
+		OrganizationID: req.OrganizationId,
+		Email:          req.Email,
+		Role:           req.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrganizationInvitation(res), nil
+}
+
+func (h *OrganizationHandler) ListOrganizationInvitations(ctx context.Context, req *pb.ListOrganizationInvitationsRequest) (*pb.ListOrganizationInvitationsResponse, error) {
+	res, err := h.organizationUseCase.ListOrganizationInvitations(ctx, &dto.ListOrganizationInvitationsRequest{
+		OrganizationID: req.OrganizationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]*pb.OrganizationInvitation, 0, len(res.Invitations))
+	for _, invitation := range res.Invitations {
+		invitations = append(invitations, toOrganizationInvitation(invitation))
+	}
+	return &pb.ListOrganizationInvitationsResponse{Invitations: invitations}, nil
+}
+
+func (h *OrganizationHandler) RevokeOrganizationInvitation(ctx context.Context, req *pb.OrganizationInvitationRequest) (*pb.OrganizationInvitation, error) {
+	res, err := h.organizationUseCase.RevokeOrganizationInvitation(ctx, &dto.OrganizationInvitationRequest{
+		ID: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrganizationInvitation(res), nil
+}
+
+func (h *OrganizationHandler) AcceptOrganizationInvitation(ctx context.Context, req *pb.AcceptOrganizationInvitationRequest) (*pb.Membership, error) {
+	res, err := h.organizationUseCase.AcceptOrganizationInvitation(ctx, &dto.AcceptOrganizationInvitationRequest{
+		Token: req.Token,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toMembership(res), nil
+}
+
+func toOrganization(organization *dto.OrganizationResponse) *pb.Organization {
+	return &pb.Organization{
+		Id:        organization.ID,
+		Name:      organization.Name,
+		Slug:      organization.Slug,
+		OwnerId:   organization.OwnerID,
+		CreatedAt: unixOrZero(organization.CreatedAt),
+		UpdatedAt: unixOrZero(organization.UpdatedAt),
+		Role:      organization.Role,
+	}
+}
+
+func toMembership(membership *dto.MembershipResponse) *pb.Membership {
+	return &pb.Membership{
+		OrganizationId: membership.OrganizationID,
+		UserId:         membership.UserID,
+		Role:           membership.Role,
+		CreatedAt:      unixOrZero(membership.CreatedAt),
+		Email:          membership.Email,
+		FirstName:      membership.FirstName,
+		LastName:       membership.LastName,
+		Username:       membership.Username,
+	}
+}
+
+func toOrganizationInvitation(invitation *dto.OrganizationInvitationResponse) *pb.OrganizationInvitation {
+	return &pb.OrganizationInvitation{
+		Id:             invitation.ID,
+		OrganizationId: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		InvitedBy:      invitation.InvitedBy,
+		CreatedAt:      unixOrZero(invitation.CreatedAt),
+		ExpiresAt:      unixOrZero(invitation.ExpiresAt),
+		AcceptedAt:     unixOrZeroPtr(invitation.AcceptedAt),
+		RevokedAt:      unixOrZeroPtr(invitation.RevokedAt),
+	}
+}
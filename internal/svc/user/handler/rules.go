@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	pb "github.com/hailsayan/achilles/internal/svc/user/pb/user"
+)
+
+var MethodRules = map[string]interceptor.MethodRule{
+	pb.UserService_CreateUser_FullMethodName:          {Mutating: true},
+	pb.UserService_GetUserByID_FullMethodName:         {Authenticated: true},
+	pb.UserService_GetUserByEmail_FullMethodName:      {Authenticated: true},
+	pb.UserService_GetUserByUsername_FullMethodName:   {Authenticated: true},
+	pb.UserService_BatchGetUsers_FullMethodName:       {Authenticated: true},
+	pb.UserService_UpdateUser_FullMethodName:          {Authenticated: true, Mutating: true},
+	pb.UserService_DeleteUserByID_FullMethodName:      {Authenticated: true, Mutating: true, ACR: jwtutils.ACRPassword, MaxAuthAge: 5 * time.Minute},
+	pb.UserService_RestoreUser_FullMethodName:         {Permission: constant.PermissionRestoreUsers, Mutating: true},
+	pb.UserService_SuspendUser_FullMethodName:         {Permission: constant.PermissionManageStatus, Mutating: true},
+	pb.UserService_ReinstateUser_FullMethodName:       {Permission: constant.PermissionManageStatus, Mutating: true},
+	pb.UserService_ListUsers_FullMethodName:           {Authenticated: true},
+	pb.UserService_SearchUsers_FullMethodName:         {Permission: constant.PermissionSearchUsers},
+	pb.UserService_ExportUserData_FullMethodName:      {Authenticated: true},
+	pb.UserService_StartExportUserData_FullMethodName: {Authenticated: true, Mutating: true},
+	pb.UserService_RequestErasure_FullMethodName:      {Mutating: true, ACR: jwtutils.ACRPassword, MaxAuthAge: 5 * time.Minute},
+	pb.UserService_GetOperation_FullMethodName:        {Authenticated: true},
+	pb.UserService_ListAuditEvents_FullMethodName:     {Permission: constant.PermissionReadAudit},
+	pb.UserService_ListUserRevisions_FullMethodName:   {Permission: constant.PermissionReadHistory},
+	pb.UserService_GetAttributeSchema_FullMethodName:  {Authenticated: true},
+	pb.UserService_SetAttributeSchema_FullMethodName:  {Permission: constant.PermissionManageAttributeSchema, Mutating: true},
+	pb.UserService_UploadAvatar_FullMethodName:        {Authenticated: true, Mutating: true},
+	// Recovery codes are requested without a token and redeemed by the auth
+	// service, so the phone verification methods authorize per purpose in
+	// the use case.
+	pb.UserService_StartPhoneVerification_FullMethodName:   {Mutating: true},
+	pb.UserService_ConfirmPhoneVerification_FullMethodName: {Mutating: true},
+	pb.UserService_GetUsage_FullMethodName:                 {Permission: constant.PermissionReadUsage},
+
+	// UserAdminService is only served on the admin listener.
+	pb.UserAdminService_ForceEmailChange_FullMethodName:  {Permission: constant.PermissionForceEmailChange, Mutating: true, ACR: jwtutils.ACRPassword, MaxAuthAge: 5 * time.Minute},
+	pb.UserAdminService_SetUserStatus_FullMethodName:     {Permission: constant.PermissionManageStatus, Mutating: true},
+	pb.UserAdminService_BulkSetUserStatus_FullMethodName: {Permission: constant.PermissionManageStatus, Mutating: true},
+	pb.UserAdminService_BulkDeleteUsers_FullMethodName:   {Permission: constant.PermissionDeleteUsers, Mutating: true, ACR: jwtutils.ACRPassword, MaxAuthAge: 5 * time.Minute},
+	pb.UserAdminService_ListAuditEvents_FullMethodName:   {Permission: constant.PermissionReadAudit},
+	pb.UserAdminService_ListUserRevisions_FullMethodName: {Permission: constant.PermissionReadHistory},
+	pb.UserAdminService_ImportUsers_FullMethodName:       {Permission: constant.PermissionImportUsers, Mutating: true},
+	pb.UserAdminService_ExportUsers_FullMethodName:       {Permission: constant.PermissionExportUsers},
+	pb.UserAdminService_StartImportUsers_FullMethodName:  {Permission: constant.PermissionImportUsers, Mutating: true},
+
+	// Operations are authorized per operation in the use case: their
+	// creator may see and cancel them, anyone else needs
+	// constant.PermissionManageOperations.
+	pb.OperationService_CreateOperation_FullMethodName: {Authenticated: true, Mutating: true},
+	pb.OperationService_GetOperation_FullMethodName:    {Authenticated: true},
+	pb.OperationService_ListOperations_FullMethodName:  {Authenticated: true},
+	pb.OperationService_CancelOperation_FullMethodName: {Authenticated: true, Mutating: true},
+	pb.OperationService_WaitOperation_FullMethodName:   {Authenticated: true},
+
+	pb.OrganizationService_CreateOrganization_FullMethodName:           {Authenticated: true, Mutating: true},
+	pb.OrganizationService_GetOrganization_FullMethodName:              {Authenticated: true},
+	pb.OrganizationService_UpdateOrganization_FullMethodName:           {Authenticated: true, Mutating: true},
+	pb.OrganizationService_DeleteOrganization_FullMethodName:           {Mutating: true, ACR: jwtutils.ACRPassword, MaxAuthAge: 5 * time.Minute},
+	pb.OrganizationService_ListOrganizations_FullMethodName:            {Authenticated: true},
+	pb.OrganizationService_TransferOwnership_FullMethodName:            {Mutating: true, ACR: jwtutils.ACRPassword, MaxAuthAge: 5 * time.Minute},
+	pb.OrganizationService_GetMembership_FullMethodName:                {Authenticated: true},
+	pb.OrganizationService_ListMembers_FullMethodName:                  {Authenticated: true},
+	pb.OrganizationService_UpdateMemberRole_FullMethodName:             {Authenticated: true, Mutating: true},
+	pb.OrganizationService_RemoveMember_FullMethodName:                 {Authenticated: true, Mutating: true},
+	pb.OrganizationService_InviteMember_FullMethodName:                 {Authenticated: true, Mutating: true},
+	pb.OrganizationService_ListOrganizationInvitations_FullMethodName:  {Authenticated: true},
+	pb.OrganizationService_RevokeOrganizationInvitation_FullMethodName: {Authenticated: true, Mutating: true},
+	pb.OrganizationService_AcceptOrganizationInvitation_FullMethodName: {Authenticated: true, Mutating: true},
+}
@@ -0,0 +1,846 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.19.6
+// source: user/user_admin.proto
+
+package userpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ForceEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceEmailChangeRequest) Reset() {
+	*x = ForceEmailChangeRequest{}
+	mi := &file_user_user_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceEmailChangeRequest) ProtoMessage() {}
+
+func (x *ForceEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*ForceEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ForceEmailChangeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ForceEmailChangeRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ForceEmailChangeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SetUserStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserStatusRequest) Reset() {
+	*x = SetUserStatusRequest{}
+	mi := &file_user_user_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserStatusRequest) ProtoMessage() {}
+
+func (x *SetUserStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetUserStatusRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetUserStatusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SetUserStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type BulkSetUserStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkSetUserStatusRequest) Reset() {
+	*x = BulkSetUserStatusRequest{}
+	mi := &file_user_user_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkSetUserStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkSetUserStatusRequest) ProtoMessage() {}
+
+func (x *BulkSetUserStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkSetUserStatusRequest.ProtoReflect.Descriptor instead.
+func (*BulkSetUserStatusRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BulkSetUserStatusRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *BulkSetUserStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BulkSetUserStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type BulkDeleteUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkDeleteUsersRequest) Reset() {
+	*x = BulkDeleteUsersRequest{}
+	mi := &file_user_user_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkDeleteUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteUsersRequest) ProtoMessage() {}
+
+func (x *BulkDeleteUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteUsersRequest.ProtoReflect.Descriptor instead.
+func (*BulkDeleteUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BulkDeleteUsersRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *BulkDeleteUsersRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Each user is handled on its own, so one failure does not undo the others.
+// code is a google.rpc.Code; 0 means the user was handled.
+type BulkOperationResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Code          int32                  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkOperationResult) Reset() {
+	*x = BulkOperationResult{}
+	mi := &file_user_user_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkOperationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkOperationResult) ProtoMessage() {}
+
+func (x *BulkOperationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkOperationResult.ProtoReflect.Descriptor instead.
+func (*BulkOperationResult) Descriptor() ([]byte, []int) {
+	return file_user_user_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BulkOperationResult) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BulkOperationResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BulkOperationResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BulkOperationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BulkOperationResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkOperationResponse) Reset() {
+	*x = BulkOperationResponse{}
+	mi := &file_user_user_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkOperationResponse) ProtoMessage() {}
+
+func (x *BulkOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkOperationResponse.ProtoReflect.Descriptor instead.
+func (*BulkOperationResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BulkOperationResponse) GetResults() []*BulkOperationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// format is "csv" or "ndjson". A CSV file starts with a header naming its
+// columns: email, first_name, last_name, phone, locale, timezone, username
+// and attributes, the last as a JSON object. NDJSON lines use the same keys.
+type ImportOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_user_user_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOptions) ProtoMessage() {}
+
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_user_user_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImportOptions) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// The first message carries the options and the rest the file, split into
+// chunks anywhere.
+type ImportUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*ImportUsersRequest_Options
+	//	*ImportUsersRequest_Chunk
+	Data          isImportUsersRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersRequest) Reset() {
+	*x = ImportUsersRequest{}
+	mi := &file_user_user_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersRequest) ProtoMessage() {}
+
+func (x *ImportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ImportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ImportUsersRequest) GetData() isImportUsersRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportUsersRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Data.(*ImportUsersRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportUsersRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*ImportUsersRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isImportUsersRequest_Data interface {
+	isImportUsersRequest_Data()
+}
+
+type ImportUsersRequest_Options struct {
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportUsersRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*ImportUsersRequest_Options) isImportUsersRequest_Data() {}
+
+func (*ImportUsersRequest_Chunk) isImportUsersRequest_Data() {}
+
+// line is the 1-based line of the file the row starts on. code is a
+// google.rpc.Code.
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int64                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Code          int32                  `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_user_user_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_user_user_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ImportRowError) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ImportRowError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// In a dry run imported counts the rows that would have been imported.
+type ImportUsersResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Rows            int64                  `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Imported        int64                  `protobuf:"varint,2,opt,name=imported,proto3" json:"imported,omitempty"`
+	Failed          int64                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors          []*ImportRowError      `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	ErrorsTruncated bool                   `protobuf:"varint,5,opt,name=errors_truncated,json=errorsTruncated,proto3" json:"errors_truncated,omitempty"`
+	DryRun          bool                   `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ImportUsersResponse) Reset() {
+	*x = ImportUsersResponse{}
+	mi := &file_user_user_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersResponse) ProtoMessage() {}
+
+func (x *ImportUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersResponse.ProtoReflect.Descriptor instead.
+func (*ImportUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ImportUsersResponse) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetImported() int64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportUsersResponse) GetErrorsTruncated() bool {
+	if x != nil {
+		return x.ErrorsTruncated
+	}
+	return false
+}
+
+func (x *ImportUsersResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ExportUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUsersRequest) Reset() {
+	*x = ExportUsersRequest{}
+	mi := &file_user_user_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsersRequest) ProtoMessage() {}
+
+func (x *ExportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ExportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExportUsersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+var File_user_user_admin_proto protoreflect.FileDescriptor
+
+const file_user_user_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x15user/user_admin.proto\x12\x04user\x1a\x0fuser/user.proto\"`\n" +
+	"\x17ForceEmailChangeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"_\n" +
+	"\x14SetUserStatusRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"e\n" +
+	"\x18BulkSetUserStatusRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"K\n" +
+	"\x16BulkDeleteUsersRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\\\n" +
+	"\x13BulkOperationResult\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"L\n" +
+	"\x15BulkOperationResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.user.BulkOperationResultR\aresults\"@\n" +
+	"\rImportOptions\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"e\n" +
+	"\x12ImportUsersRequest\x12/\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.user.ImportOptionsH\x00R\aoptions\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"h\n" +
+	"\x0eImportRowError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x03R\x04line\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\xcf\x01\n" +
+	"\x13ImportUsersResponse\x12\x12\n" +
+	"\x04rows\x18\x01 \x01(\x03R\x04rows\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\x03R\bimported\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12,\n" +
+	"\x06errors\x18\x04 \x03(\v2\x14.user.ImportRowErrorR\x06errors\x12)\n" +
+	"\x10errors_truncated\x18\x05 \x01(\bR\x0ferrorsTruncated\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\",\n" +
+	"\x12ExportUsersRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status2\xb8\x05\n" +
+	"\x10UserAdminService\x12G\n" +
+	"\x10ForceEmailChange\x12\x1d.user.ForceEmailChangeRequest\x1a\x12.user.UserResponse\"\x00\x12A\n" +
+	"\rSetUserStatus\x12\x1a.user.SetUserStatusRequest\x1a\x12.user.UserResponse\"\x00\x12R\n" +
+	"\x11BulkSetUserStatus\x12\x1e.user.BulkSetUserStatusRequest\x1a\x1b.user.BulkOperationResponse\"\x00\x12N\n" +
+	"\x0fBulkDeleteUsers\x12\x1c.user.BulkDeleteUsersRequest\x1a\x1b.user.BulkOperationResponse\"\x00\x12P\n" +
+	"\x0fListAuditEvents\x12\x1c.user.ListAuditEventsRequest\x1a\x1d.user.ListAuditEventsResponse\"\x00\x12V\n" +
+	"\x11ListUserRevisions\x12\x1e.user.ListUserRevisionsRequest\x1a\x1f.user.ListUserRevisionsResponse\"\x00\x12F\n" +
+	"\vImportUsers\x12\x18.user.ImportUsersRequest\x1a\x19.user.ImportUsersResponse\"\x00(\x01\x12A\n" +
+	"\x10StartImportUsers\x12\x18.user.ImportUsersRequest\x1a\x0f.user.Operation\"\x00(\x01\x12?\n" +
+	"\vExportUsers\x12\x18.user.ExportUsersRequest\x1a\x12.user.UserResponse\"\x000\x01B1Z/github.com/hailsayan/achilles/proto/user;userpbb\x06proto3"
+
+var (
+	file_user_user_admin_proto_rawDescOnce sync.Once
+	file_user_user_admin_proto_rawDescData []byte
+)
+
+func file_user_user_admin_proto_rawDescGZIP() []byte {
+	file_user_user_admin_proto_rawDescOnce.Do(func() {
+		file_user_user_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_user_user_admin_proto_rawDesc), len(file_user_user_admin_proto_rawDesc)))
+	})
+	return file_user_user_admin_proto_rawDescData
+}
+
+var file_user_user_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_user_user_admin_proto_goTypes = []any{
+	(*ForceEmailChangeRequest)(nil),   // 0: user.ForceEmailChangeRequest
+	(*SetUserStatusRequest)(nil),      // 1: user.SetUserStatusRequest
+	(*BulkSetUserStatusRequest)(nil),  // 2: user.BulkSetUserStatusRequest
+	(*BulkDeleteUsersRequest)(nil),    // 3: user.BulkDeleteUsersRequest
+	(*BulkOperationResult)(nil),       // 4: user.BulkOperationResult
+	(*BulkOperationResponse)(nil),     // 5: user.BulkOperationResponse
+	(*ImportOptions)(nil),             // 6: user.ImportOptions
+	(*ImportUsersRequest)(nil),        // 7: user.ImportUsersRequest
+	(*ImportRowError)(nil),            // 8: user.ImportRowError
+	(*ImportUsersResponse)(nil),       // 9: user.ImportUsersResponse
+	(*ExportUsersRequest)(nil),        // 10: user.ExportUsersRequest
+	(*ListAuditEventsRequest)(nil),    // 11: user.ListAuditEventsRequest
+	(*ListUserRevisionsRequest)(nil),  // 12: user.ListUserRevisionsRequest
+	(*UserResponse)(nil),              // 13: user.UserResponse
+	(*ListAuditEventsResponse)(nil),   // 14: user.ListAuditEventsResponse
+	(*ListUserRevisionsResponse)(nil), // 15: user.ListUserRevisionsResponse
+	(*Operation)(nil),                 // 16: user.Operation
+}
+var file_user_user_admin_proto_depIdxs = []int32{
+	4,  // 0: user.BulkOperationResponse.results:type_name -> user.BulkOperationResult
+	6,  // 1: user.ImportUsersRequest.options:type_name -> user.ImportOptions
+	8,  // 2: user.ImportUsersResponse.errors:type_name -> user.ImportRowError
+	0,  // 3: user.UserAdminService.ForceEmailChange:input_type -> user.ForceEmailChangeRequest
+	1,  // 4: user.UserAdminService.SetUserStatus:input_type -> user.SetUserStatusRequest
+	2,  // 5: user.UserAdminService.BulkSetUserStatus:input_type -> user.BulkSetUserStatusRequest
+	3,  // 6: user.UserAdminService.BulkDeleteUsers:input_type -> user.BulkDeleteUsersRequest
+	11, // 7: user.UserAdminService.ListAuditEvents:input_type -> user.ListAuditEventsRequest
+	12, // 8: user.UserAdminService.ListUserRevisions:input_type -> user.ListUserRevisionsRequest
+	7,  // 9: user.UserAdminService.ImportUsers:input_type -> user.ImportUsersRequest
+	7,  // 10: user.UserAdminService.StartImportUsers:input_type -> user.ImportUsersRequest
+	10, // 11: user.UserAdminService.ExportUsers:input_type -> user.ExportUsersRequest
+	13, // 12: user.UserAdminService.ForceEmailChange:output_type -> user.UserResponse
+	13, // 13: user.UserAdminService.SetUserStatus:output_type -> user.UserResponse
+	5,  // 14: user.UserAdminService.BulkSetUserStatus:output_type -> user.BulkOperationResponse
+	5,  // 15: user.UserAdminService.BulkDeleteUsers:output_type -> user.BulkOperationResponse
+	14, // 16: user.UserAdminService.ListAuditEvents:output_type -> user.ListAuditEventsResponse
+	15, // 17: user.UserAdminService.ListUserRevisions:output_type -> user.ListUserRevisionsResponse
+	9,  // 18: user.UserAdminService.ImportUsers:output_type -> user.ImportUsersResponse
+	16, // 19: user.UserAdminService.StartImportUsers:output_type -> user.Operation
+	13, // 20: user.UserAdminService.ExportUsers:output_type -> user.UserResponse
+	12, // [12:21] is the sub-list for method output_type
+	3,  // [3:12] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_user_user_admin_proto_init() }
+func file_user_user_admin_proto_init() {
+	if File_user_user_admin_proto != nil {
+		return
+	}
+	file_user_user_proto_init()
+	file_user_user_admin_proto_msgTypes[7].OneofWrappers = []any{
+		(*ImportUsersRequest_Options)(nil),
+		(*ImportUsersRequest_Chunk)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_user_admin_proto_rawDesc), len(file_user_user_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_user_user_admin_proto_goTypes,
+		DependencyIndexes: file_user_user_admin_proto_depIdxs,
+		MessageInfos:      file_user_user_admin_proto_msgTypes,
+	}.Build()
+	File_user_user_admin_proto = out.File
+	file_user_user_admin_proto_goTypes = nil
+	file_user_user_admin_proto_depIdxs = nil
+}
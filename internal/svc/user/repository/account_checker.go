@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type accountChecker struct {
+	dataStore DataStore
+}
+
+// NewAccountChecker returns a checker that counts a user as active while
+// their account exists, is not deleted and has the active status.
+func NewAccountChecker(dataStore DataStore) interceptor.AccountChecker {
+	return &accountChecker{
+		dataStore: dataStore,
+	}
+}
+
+func (c *accountChecker) IsActive(ctx context.Context, userID string) (bool, error) {
+	var user *entity.User
+	err := c.dataStore.Atomic(ctx, func(ds DataStore) error {
+		var err error
+		user, err = ds.UserRepository().GetByUserID(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return user != nil && user.Status == constant.UserStatusActive, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type AttributeSchemaRepository interface {
+	GetByTenantID(ctx context.Context, tenantID string) (*entity.AttributeSchema, error)
+	Upsert(ctx context.Context, schema *entity.AttributeSchema) error
+}
+
+type attributeSchemaRepository struct {
+	db DBTX
+}
+
+func NewAttributeSchemaRepository(db DBTX) AttributeSchemaRepository {
+	return &attributeSchemaRepository{
+		db: db,
+	}
+}
+
+func (r *attributeSchemaRepository) GetByTenantID(ctx context.Context, tenantID string) (*entity.AttributeSchema, error) {
+	query := `
+		SELECT
+			tenant_id, schema, updated_by, updated_at
+		FROM
+			tenant_attribute_schemas
+		WHERE
+			tenant_id = $1
+	`
+
+	schema := &entity.AttributeSchema{}
+	err := r.db.QueryRowContext(ctx, query, tenantID).Scan(
+		&schema.TenantID,
+		&schema.Schema,
+		&schema.UpdatedBy,
+		&schema.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+func (r *attributeSchemaRepository) Upsert(ctx context.Context, schema *entity.AttributeSchema) error {
+	query := `
+		INSERT INTO
+			tenant_attribute_schemas (tenant_id, schema, updated_by, updated_at)
+		VALUES
+			($1, $2, $3, $4)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			schema = EXCLUDED.schema, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		schema.TenantID,
+		[]byte(schema.Schema),
+		schema.UpdatedBy,
+		schema.UpdatedAt,
+	)
+
+	return err
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+// AvatarDeletionRepository queues avatar deletions that must wait until the
+// transaction removing their user has committed.
+type AvatarDeletionRepository interface {
+	Add(ctx context.Context, userID string, at time.Time) error
+	ListPending(ctx context.Context, limit int) ([]*entity.AvatarDeletion, error)
+	Delete(ctx context.Context, userID string) error
+}
+
+type avatarDeletionRepository struct {
+	db DBTX
+}
+
+func NewAvatarDeletionRepository(db DBTX) AvatarDeletionRepository {
+	return &avatarDeletionRepository{
+		db: db,
+	}
+}
+
+// Add queues userID once; queuing it again keeps the first entry.
+func (r *avatarDeletionRepository) Add(ctx context.Context, userID string, at time.Time) error {
+	query := `
+		INSERT INTO
+			avatar_deletions (user_id, created_at)
+		VALUES
+			($1, $2)
+		ON CONFLICT (tenant_id, user_id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, at)
+	return err
+}
+
+// ListPending returns queued deletions, oldest first. It is meant to be
+// called across all tenants.
+func (r *avatarDeletionRepository) ListPending(ctx context.Context, limit int) ([]*entity.AvatarDeletion, error) {
+	query := `
+		SELECT
+			tenant_id, user_id, created_at
+		FROM
+			avatar_deletions
+		ORDER BY
+			created_at
+		LIMIT $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deletions := []*entity.AvatarDeletion{}
+	for rows.Next() {
+		deletion := &entity.AvatarDeletion{}
+		if err := rows.Scan(&deletion.TenantID, &deletion.UserID, &deletion.CreatedAt); err != nil {
+			return nil, err
+		}
+		deletions = append(deletions, deletion)
+	}
+
+	return deletions, rows.Err()
+}
+
+func (r *avatarDeletionRepository) Delete(ctx context.Context, userID string) error {
+	query := `
+		DELETE FROM
+			avatar_deletions
+		WHERE
+			user_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
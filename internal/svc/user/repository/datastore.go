@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
 	
 )
 
@@ -10,11 +12,24 @@ type DBTX interface {
 	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
 	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
 	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
 }
 
 type DataStore interface {
 	Atomic(ctx context.Context, fn func(DataStore) error) error
 	UserRepository() UserRepository
+	AuditRepository() AuditRepository
+	ErasureRepository() ErasureRepository
+	UserHistoryRepository() UserHistoryRepository
+	AttributeSchemaRepository() AttributeSchemaRepository
+	UsernameRepository() UsernameRepository
+	OrganizationRepository() OrganizationRepository
+	MembershipRepository() MembershipRepository
+	OrganizationInvitationRepository() OrganizationInvitationRepository
+	UsageRepository() UsageRepository
+	UserImportRepository() UserImportRepository
+	OperationRepository() OperationRepository
+	AvatarDeletionRepository() AvatarDeletionRepository
 }
 
 type dataStore struct {
@@ -35,6 +50,13 @@ func (s *dataStore) Atomic(ctx context.Context, fn func(DataStore) error) error
 		return err
 	}
 
+	// Row-level security reads the tenant from app.tenant_id; it lasts
+	// until the transaction ends.
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('app.tenant_id', $1, true)`, tenant.FromContext(ctx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	err = fn(&dataStore{conn: s.conn, db: tx})
 	if err != nil {
 		if errRollback := tx.Rollback(); errRollback != nil {
@@ -47,4 +69,52 @@ func (s *dataStore) Atomic(ctx context.Context, fn func(DataStore) error) error
 }
 func (s *dataStore) UserRepository() UserRepository {
 	return NewUserRepository(s.db)
-}
\ No newline at end of file
+}
+
+func (s *dataStore) AuditRepository() AuditRepository {
+	return NewAuditRepository(s.db)
+}
+
+func (s *dataStore) ErasureRepository() ErasureRepository {
+	return NewErasureRepository(s.db)
+}
+
+func (s *dataStore) UserHistoryRepository() UserHistoryRepository {
+	return NewUserHistoryRepository(s.db)
+}
+
+func (s *dataStore) AttributeSchemaRepository() AttributeSchemaRepository {
+	return NewAttributeSchemaRepository(s.db)
+}
+
+func (s *dataStore) UsernameRepository() UsernameRepository {
+	return NewUsernameRepository(s.db)
+}
+
+func (s *dataStore) OrganizationRepository() OrganizationRepository {
+	return NewOrganizationRepository(s.db)
+}
+
+func (s *dataStore) MembershipRepository() MembershipRepository {
+	return NewMembershipRepository(s.db)
+}
+
+func (s *dataStore) OrganizationInvitationRepository() OrganizationInvitationRepository {
+	return NewOrganizationInvitationRepository(s.db)
+}
+
+func (s *dataStore) UsageRepository() UsageRepository {
+	return NewUsageRepository(s.db)
+}
+
+func (s *dataStore) UserImportRepository() UserImportRepository {
+	return NewUserImportRepository(s.db)
+}
+
+func (s *dataStore) OperationRepository() OperationRepository {
+	return NewOperationRepository(s.db)
+}
+
+func (s *dataStore) AvatarDeletionRepository() AvatarDeletionRepository {
+	return NewAvatarDeletionRepository(s.db)
+}
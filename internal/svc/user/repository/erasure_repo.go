@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/lib/pq"
+)
+
+type ErasureRepository interface {
+	Create(ctx context.Context, request *entity.ErasureRequest) error
+	GetByID(ctx context.Context, id string) (*entity.ErasureRequest, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*entity.ErasureRequest, error)
+	GetRunningByUserID(ctx context.Context, userID string) (*entity.ErasureRequest, error)
+	Update(ctx context.Context, request *entity.ErasureRequest) error
+}
+
+type erasureRepository struct {
+	db DBTX
+}
+
+func NewErasureRepository(db DBTX) ErasureRepository {
+	return &erasureRepository{
+		db: db,
+	}
+}
+
+func (r *erasureRepository) Create(ctx context.Context, request *entity.ErasureRequest) error {
+	query := `
+		INSERT INTO
+			erasure_requests (id, user_id, requested_by, state, services, confirmed_services, created_at, updated_at, completed_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		request.ID,
+		request.UserID,
+		request.RequestedBy,
+		request.State,
+		pq.Array(request.Services),
+		pq.Array(request.ConfirmedServices),
+		request.CreatedAt,
+		request.UpdatedAt,
+		request.CompletedAt,
+	)
+
+	return err
+}
+
+func (r *erasureRepository) GetByID(ctx context.Context, id string) (*entity.ErasureRequest, error) {
+	query := `
+		SELECT
+			id, user_id, requested_by, state, services, confirmed_services, created_at, updated_at, completed_at
+		FROM
+			erasure_requests
+		WHERE
+			id = $1
+	`
+
+	return r.scan(r.db.QueryRowContext(ctx, query, id))
+}
+
+// GetByIDForUpdate locks the row so concurrent confirmations from different
+// services are applied one after the other.
+func (r *erasureRepository) GetByIDForUpdate(ctx context.Context, id string) (*entity.ErasureRequest, error) {
+	query := `
+		SELECT
+			id, user_id, requested_by, state, services, confirmed_services, created_at, updated_at, completed_at
+		FROM
+			erasure_requests
+		WHERE
+			id = $1
+		FOR UPDATE
+	`
+
+	return r.scan(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *erasureRepository) GetRunningByUserID(ctx context.Context, userID string) (*entity.ErasureRequest, error) {
+	query := `
+		SELECT
+			id, user_id, requested_by, state, services, confirmed_services, created_at, updated_at, completed_at
+		FROM
+			erasure_requests
+		WHERE
+			user_id = $1 AND state = 'running'
+	`
+
+	return r.scan(r.db.QueryRowContext(ctx, query, userID))
+}
+
+func (r *erasureRepository) Update(ctx context.Context, request *entity.ErasureRequest) error {
+	query := `
+		UPDATE
+			erasure_requests
+		SET
+			state = $1, confirmed_services = $2, updated_at = $3, completed_at = $4
+		WHERE
+			id = $5
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		request.State,
+		pq.Array(request.ConfirmedServices),
+		request.UpdatedAt,
+		request.CompletedAt,
+		request.ID,
+	)
+
+	return err
+}
+
+func (r *erasureRepository) scan(row *sql.Row) (*entity.ErasureRequest, error) {
+	request := &entity.ErasureRequest{}
+	err := row.Scan(
+		&request.ID,
+		&request.UserID,
+		&request.RequestedBy,
+		&request.State,
+		pq.Array(&request.Services),
+		pq.Array(&request.ConfirmedServices),
+		&request.CreatedAt,
+		&request.UpdatedAt,
+		&request.CompletedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return request, nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type ListMembersParams struct {
+	OrganizationID string
+	AfterCreatedAt time.Time
+	AfterUserID    string
+	Limit          int
+}
+
+type MembershipRepository interface {
+	Add(ctx context.Context, membership *entity.Membership) (bool, error)
+	Get(ctx context.Context, organizationID, userID string) (*entity.Membership, error)
+	UpdateRole(ctx context.Context, organizationID, userID, role string) error
+	Remove(ctx context.Context, organizationID, userID string) (bool, error)
+	List(ctx context.Context, params *ListMembersParams) ([]*entity.Membership, error)
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+type membershipRepository struct {
+	db DBTX
+}
+
+func NewMembershipRepository(db DBTX) MembershipRepository {
+	return &membershipRepository{
+		db: db,
+	}
+}
+
+// Add reports whether the membership was created; an existing membership is
+// left as it is.
+func (r *membershipRepository) Add(ctx context.Context, membership *entity.Membership) (bool, error) {
+	query := `
+		INSERT INTO
+			organization_memberships (organization_id, user_id, role, created_at)
+		VALUES
+			($1, $2, $3, $4)
+		ON CONFLICT (organization_id, user_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		membership.OrganizationID,
+		membership.UserID,
+		membership.Role,
+		membership.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *membershipRepository) Get(ctx context.Context, organizationID, userID string) (*entity.Membership, error) {
+	query := `
+		SELECT
+			organization_id, user_id, role, created_at
+		FROM
+			organization_memberships
+		WHERE
+			organization_id = $1 AND user_id = $2
+	`
+
+	membership := &entity.Membership{}
+	err := r.db.QueryRowContext(ctx, query, organizationID, userID).Scan(
+		&membership.OrganizationID,
+		&membership.UserID,
+		&membership.Role,
+		&membership.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+func (r *membershipRepository) UpdateRole(ctx context.Context, organizationID, userID, role string) error {
+	query := `
+		UPDATE
+			organization_memberships
+		SET
+			role = $1
+		WHERE
+			organization_id = $2 AND user_id = $3
+	`
+
+	_, err := r.db.ExecContext(ctx, query, role, organizationID, userID)
+	return err
+}
+
+func (r *membershipRepository) Remove(ctx context.Context, organizationID, userID string) (bool, error) {
+	query := `
+		DELETE FROM
+			organization_memberships
+		WHERE
+			organization_id = $1 AND user_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, organizationID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// List pages through the live members of an organization in the order they
+// joined, with their profile fields.
+func (r *membershipRepository) List(ctx context.Context, params *ListMembersParams) ([]*entity.Membership, error) {
+	args := []any{params.OrganizationID}
+	after := ""
+	if params.AfterUserID != "" {
+		args = append(args, params.AfterCreatedAt, params.AfterUserID)
+		after = "AND (m.created_at, m.user_id) > ($2, $3)"
+	}
+	args = append(args, params.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT
+			m.organization_id, m.user_id, m.role, m.created_at, u.email, u.first_name, u.last_name, u.username
+		FROM
+			organization_memberships m
+			JOIN users u ON u.id = m.user_id
+		WHERE
+			m.organization_id = $1 AND u.deleted_at IS NULL
+			%s
+		ORDER BY
+			m.created_at, m.user_id
+		LIMIT $%d
+	`, after, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	memberships := []*entity.Membership{}
+	for rows.Next() {
+		membership := &entity.Membership{}
+		if err := rows.Scan(
+			&membership.OrganizationID,
+			&membership.UserID,
+			&membership.Role,
+			&membership.CreatedAt,
+			&membership.Email,
+			&membership.FirstName,
+			&membership.LastName,
+			&membership.Username,
+		); err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, membership)
+	}
+
+	return memberships, rows.Err()
+}
+
+func (r *membershipRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	query := `
+		DELETE FROM
+			organization_memberships
+		WHERE
+			user_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
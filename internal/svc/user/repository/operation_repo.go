@@ -0,0 +1,355 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type ListOperationsParams struct {
+	CreatedBy       string
+	Type            string
+	State           string
+	BeforeCreatedAt time.Time
+	BeforeID        string
+	Limit           int
+}
+
+// OperationRepository stores long-running operations. Claim, Heartbeat,
+// Release and Finish only touch an operation held by the given worker, so a
+// worker that lost its lease cannot overwrite the one that took over.
+type OperationRepository interface {
+	Create(ctx context.Context, operation *entity.Operation) error
+	GetByID(ctx context.Context, id string) (*entity.Operation, error)
+	List(ctx context.Context, params *ListOperationsParams) ([]*entity.Operation, error)
+	CancelPending(ctx context.Context, id string, at time.Time) (bool, error)
+	RequestCancel(ctx context.Context, id string, at time.Time) (bool, error)
+	ListRunnable(ctx context.Context, at time.Time, limit int) ([]*entity.Operation, error)
+	Claim(ctx context.Context, id, owner string, at, leaseExpiresAt time.Time) (*entity.Operation, error)
+	Heartbeat(ctx context.Context, id, owner string, progress json.RawMessage, at, leaseExpiresAt time.Time) (held bool, cancelRequested bool, err error)
+	Release(ctx context.Context, id, owner string, at time.Time) error
+	Finish(ctx context.Context, operation *entity.Operation, owner string) (bool, error)
+}
+
+type operationRepository struct {
+	db DBTX
+}
+
+func NewOperationRepository(db DBTX) OperationRepository {
+	return &operationRepository{
+		db: db,
+	}
+}
+
+const operationColumns = `id, tenant_id, type, state, params, progress, result, error_code, error_message, created_by, cancel_requested, attempts, lease_owner, lease_expires_at, created_at, updated_at, started_at, finished_at`
+
+func (r *operationRepository) Create(ctx context.Context, operation *entity.Operation) error {
+	query := `
+		INSERT INTO
+			operations (id, type, state, params, progress, result, created_by, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, '{}', '{}', $5, $6, $7)
+		RETURNING
+			tenant_id
+	`
+
+	params := []byte(operation.Params)
+	if len(params) == 0 {
+		params = []byte("{}")
+	}
+
+	return r.db.QueryRowContext(ctx, query,
+		operation.ID,
+		operation.Type,
+		operation.State,
+		params,
+		operation.CreatedBy,
+		operation.CreatedAt,
+		operation.UpdatedAt,
+	).Scan(&operation.TenantID)
+}
+
+func (r *operationRepository) GetByID(ctx context.Context, id string) (*entity.Operation, error) {
+	query := `
+		SELECT
+			` + operationColumns + `
+		FROM
+			operations
+		WHERE
+			id = $1
+	`
+
+	operation, err := r.scan(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return operation, nil
+}
+
+// List returns the operations matching params, newest first.
+func (r *operationRepository) List(ctx context.Context, params *ListOperationsParams) ([]*entity.Operation, error) {
+	conditions := []string{"TRUE"}
+	args := []any{}
+	addCondition := func(condition string, values ...any) {
+		placeholders := make([]any, 0, len(values))
+		for _, value := range values {
+			args = append(args, value)
+			placeholders = append(placeholders, len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if params.CreatedBy != "" {
+		addCondition("created_by = $%d", params.CreatedBy)
+	}
+	if params.Type != "" {
+		addCondition("type = $%d", params.Type)
+	}
+	if params.State != "" {
+		addCondition("state = $%d", params.State)
+	}
+	if params.BeforeID != "" {
+		addCondition("(created_at, id) < ($%d, $%d)", params.BeforeCreatedAt, params.BeforeID)
+	}
+	args = append(args, params.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			operations
+		WHERE
+			%s
+		ORDER BY
+			created_at DESC, id DESC
+		LIMIT $%d
+	`, operationColumns, strings.Join(conditions, " AND "), len(args))
+
+	return r.query(ctx, query, args...)
+}
+
+// CancelPending cancels the operation if no worker has started it yet.
+func (r *operationRepository) CancelPending(ctx context.Context, id string, at time.Time) (bool, error) {
+	query := `
+		UPDATE
+			operations
+		SET
+			state = 'cancelled', cancel_requested = TRUE, updated_at = $2, finished_at = $2
+		WHERE
+			id = $1 AND state = 'pending'
+	`
+
+	return r.exec(ctx, query, id, at)
+}
+
+// RequestCancel flags a running operation. Its worker sees the flag on the
+// next heartbeat and stops.
+func (r *operationRepository) RequestCancel(ctx context.Context, id string, at time.Time) (bool, error) {
+	query := `
+		UPDATE
+			operations
+		SET
+			cancel_requested = TRUE, updated_at = $2
+		WHERE
+			id = $1 AND state = 'running'
+	`
+
+	return r.exec(ctx, query, id, at)
+}
+
+// ListRunnable returns operations that are waiting for a worker: pending
+// ones and running ones whose worker let its lease expire. It is meant to
+// be called across all tenants, oldest first.
+func (r *operationRepository) ListRunnable(ctx context.Context, at time.Time, limit int) ([]*entity.Operation, error) {
+	query := `
+		SELECT
+			` + operationColumns + `
+		FROM
+			operations
+		WHERE
+			state = 'pending' OR (state = 'running' AND lease_expires_at <= $1)
+		ORDER BY
+			created_at
+		LIMIT $2
+	`
+
+	return r.query(ctx, query, at, limit)
+}
+
+// Claim leases a runnable operation to owner and counts the attempt. It
+// returns nil when the operation is no longer runnable, typically because
+// another worker claimed it first.
+func (r *operationRepository) Claim(ctx context.Context, id, owner string, at, leaseExpiresAt time.Time) (*entity.Operation, error) {
+	query := `
+		UPDATE
+			operations
+		SET
+			state = 'running', lease_owner = $2, lease_expires_at = $4, attempts = attempts + 1,
+			started_at = COALESCE(started_at, $3), updated_at = $3
+		WHERE
+			id = $1 AND (state = 'pending' OR (state = 'running' AND lease_expires_at <= $3))
+		RETURNING
+			` + operationColumns + `
+	`
+
+	operation, err := r.scan(r.db.QueryRowContext(ctx, query, id, owner, at, leaseExpiresAt))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return operation, nil
+}
+
+// Heartbeat extends owner's lease and, when progress is set, stores it. held
+// is false once the lease has passed to another worker or the operation has
+// ended.
+func (r *operationRepository) Heartbeat(ctx context.Context, id, owner string, progress json.RawMessage, at, leaseExpiresAt time.Time) (bool, bool, error) {
+	query := `
+		UPDATE
+			operations
+		SET
+			lease_expires_at = $5, progress = COALESCE($3::jsonb, progress), updated_at = $4
+		WHERE
+			id = $1 AND lease_owner = $2 AND state = 'running'
+		RETURNING
+			cancel_requested
+	`
+
+	var cancelRequested bool
+	err := r.db.QueryRowContext(ctx, query,
+		id,
+		owner,
+		sql.NullString{String: string(progress), Valid: len(progress) > 0},
+		at,
+		leaseExpiresAt,
+	).Scan(&cancelRequested)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	return true, cancelRequested, nil
+}
+
+// Release gives the operation up without finishing it, so that another
+// worker can take it over straight away.
+func (r *operationRepository) Release(ctx context.Context, id, owner string, at time.Time) error {
+	query := `
+		UPDATE
+			operations
+		SET
+			lease_owner = '', lease_expires_at = $3, updated_at = $3
+		WHERE
+			id = $1 AND lease_owner = $2 AND state = 'running'
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, owner, at)
+	return err
+}
+
+// Finish stores the final state, result and error of an operation held by
+// owner. It reports false when owner no longer holds it.
+func (r *operationRepository) Finish(ctx context.Context, operation *entity.Operation, owner string) (bool, error) {
+	query := `
+		UPDATE
+			operations
+		SET
+			state = $3, result = $4, error_code = $5, error_message = $6,
+			lease_owner = '', lease_expires_at = NULL, updated_at = $7, finished_at = $8
+		WHERE
+			id = $1 AND lease_owner = $2 AND state = 'running'
+	`
+
+	result := []byte(operation.Result)
+	if len(result) == 0 {
+		result = []byte("{}")
+	}
+
+	return r.exec(ctx, query,
+		operation.ID,
+		owner,
+		operation.State,
+		result,
+		operation.ErrorCode,
+		operation.ErrorMessage,
+		operation.UpdatedAt,
+		operation.FinishedAt,
+	)
+}
+
+func (r *operationRepository) exec(ctx context.Context, query string, args ...any) (bool, error) {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *operationRepository) query(ctx context.Context, query string, args ...any) ([]*entity.Operation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	operations := []*entity.Operation{}
+	for rows.Next() {
+		operation, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+	}
+
+	return operations, rows.Err()
+}
+
+func (r *operationRepository) scan(row interface{ Scan(...any) error }) (*entity.Operation, error) {
+	operation := &entity.Operation{}
+	var params, progress, result []byte
+	err := row.Scan(
+		&operation.ID,
+		&operation.TenantID,
+		&operation.Type,
+		&operation.State,
+		&params,
+		&progress,
+		&result,
+		&operation.ErrorCode,
+		&operation.ErrorMessage,
+		&operation.CreatedBy,
+		&operation.CancelRequested,
+		&operation.Attempts,
+		&operation.LeaseOwner,
+		&operation.LeaseExpiresAt,
+		&operation.CreatedAt,
+		&operation.UpdatedAt,
+		&operation.StartedAt,
+		&operation.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	operation.Params = json.RawMessage(params)
+	operation.Progress = json.RawMessage(progress)
+	operation.Result = json.RawMessage(result)
+	return operation, nil
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type OrganizationInvitationRepository interface {
+	Create(ctx context.Context, invitation *entity.OrganizationInvitation) error
+	GetByID(ctx context.Context, id string) (*entity.OrganizationInvitation, error)
+	GetPending(ctx context.Context, organizationID, email string) (*entity.OrganizationInvitation, error)
+	ListPendingByOrganization(ctx context.Context, organizationID string) ([]*entity.OrganizationInvitation, error)
+	ListPendingByEmail(ctx context.Context, email string) ([]*entity.OrganizationInvitation, error)
+	MarkAccepted(ctx context.Context, id string, at time.Time) (bool, error)
+	Revoke(ctx context.Context, id string, at time.Time) (bool, error)
+}
+
+type organizationInvitationRepository struct {
+	db DBTX
+}
+
+func NewOrganizationInvitationRepository(db DBTX) OrganizationInvitationRepository {
+	return &organizationInvitationRepository{
+		db: db,
+	}
+}
+
+func (r *organizationInvitationRepository) Create(ctx context.Context, invitation *entity.OrganizationInvitation) error {
+	query := `
+		INSERT INTO
+			organization_invitations (id, organization_id, email, role, invited_by, created_at, expires_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		invitation.ID,
+		invitation.OrganizationID,
+		invitation.Email,
+		invitation.Role,
+		invitation.InvitedBy,
+		invitation.CreatedAt,
+		invitation.ExpiresAt,
+	)
+
+	return err
+}
+
+func (r *organizationInvitationRepository) GetByID(ctx context.Context, id string) (*entity.OrganizationInvitation, error) {
+	query := `
+		SELECT
+			id, organization_id, email, role, invited_by, created_at, expires_at, accepted_at, revoked_at
+		FROM
+			organization_invitations
+		WHERE
+			id = $1
+	`
+
+	invitation := &entity.OrganizationInvitation{}
+	err := r.scan(r.db.QueryRowContext(ctx, query, id), invitation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// GetPending returns the open invitation for email, expired or not, since
+// it still holds the unique slot until it is revoked or accepted.
+func (r *organizationInvitationRepository) GetPending(ctx context.Context, organizationID, email string) (*entity.OrganizationInvitation, error) {
+	query := `
+		SELECT
+			id, organization_id, email, role, invited_by, created_at, expires_at, accepted_at, revoked_at
+		FROM
+			organization_invitations
+		WHERE
+			organization_id = $1 AND email = $2 AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+
+	invitation := &entity.OrganizationInvitation{}
+	err := r.scan(r.db.QueryRowContext(ctx, query, organizationID, email), invitation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (r *organizationInvitationRepository) ListPendingByOrganization(ctx context.Context, organizationID string) ([]*entity.OrganizationInvitation, error) {
+	query := `
+		SELECT
+			id, organization_id, email, role, invited_by, created_at, expires_at, accepted_at, revoked_at
+		FROM
+			organization_invitations
+		WHERE
+			organization_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY
+			created_at, id
+	`
+
+	return r.list(ctx, query, organizationID, time.Now().UTC())
+}
+
+func (r *organizationInvitationRepository) ListPendingByEmail(ctx context.Context, email string) ([]*entity.OrganizationInvitation, error) {
+	query := `
+		SELECT
+			id, organization_id, email, role, invited_by, created_at, expires_at, accepted_at, revoked_at
+		FROM
+			organization_invitations
+		WHERE
+			email = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY
+			created_at, id
+	`
+
+	return r.list(ctx, query, email, time.Now().UTC())
+}
+
+// MarkAccepted reports whether the invitation was still open.
+func (r *organizationInvitationRepository) MarkAccepted(ctx context.Context, id string, at time.Time) (bool, error) {
+	query := `
+		UPDATE
+			organization_invitations
+		SET
+			accepted_at = $1
+		WHERE
+			id = $2 AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+
+	return r.close(ctx, query, at, id)
+}
+
+// Revoke reports whether the invitation was still open.
+func (r *organizationInvitationRepository) Revoke(ctx context.Context, id string, at time.Time) (bool, error) {
+	query := `
+		UPDATE
+			organization_invitations
+		SET
+			revoked_at = $1
+		WHERE
+			id = $2 AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+
+	return r.close(ctx, query, at, id)
+}
+
+func (r *organizationInvitationRepository) close(ctx context.Context, query string, at time.Time, id string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, query, at, id)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *organizationInvitationRepository) list(ctx context.Context, query string, args ...any) ([]*entity.OrganizationInvitation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []*entity.OrganizationInvitation{}
+	for rows.Next() {
+		invitation := &entity.OrganizationInvitation{}
+		if err := r.scan(rows, invitation); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, rows.Err()
+}
+
+func (r *organizationInvitationRepository) scan(row interface{ Scan(...any) error }, invitation *entity.OrganizationInvitation) error {
+	return row.Scan(
+		&invitation.ID,
+		&invitation.OrganizationID,
+		&invitation.Email,
+		&invitation.Role,
+		&invitation.InvitedBy,
+		&invitation.CreatedAt,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedAt,
+		&invitation.RevokedAt,
+	)
+}
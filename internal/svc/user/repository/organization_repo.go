@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *entity.Organization) error
+	GetByID(ctx context.Context, id string) (*entity.Organization, error)
+	GetBySlug(ctx context.Context, slug string) (*entity.Organization, error)
+	Update(ctx context.Context, organization *entity.Organization) error
+	SetOwner(ctx context.Context, id, ownerID string, updatedAt time.Time) error
+	Delete(ctx context.Context, id string) error
+	ListByUserID(ctx context.Context, userID string) ([]*entity.UserOrganization, error)
+	CountOwnedByUserID(ctx context.Context, userID string) (int, error)
+}
+
+type organizationRepository struct {
+	db DBTX
+}
+
+func NewOrganizationRepository(db DBTX) OrganizationRepository {
+	return &organizationRepository{
+		db: db,
+	}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, organization *entity.Organization) error {
+	query := `
+		INSERT INTO
+			organizations (id, name, slug, owner_id, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		organization.ID,
+		organization.Name,
+		organization.Slug,
+		organization.OwnerID,
+		organization.CreatedAt,
+		organization.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id string) (*entity.Organization, error) {
+	query := `
+		SELECT
+			id, name, slug, owner_id, created_at, updated_at
+		FROM
+			organizations
+		WHERE
+			id = $1
+	`
+
+	return r.scan(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *organizationRepository) GetBySlug(ctx context.Context, slug string) (*entity.Organization, error) {
+	query := `
+		SELECT
+			id, name, slug, owner_id, created_at, updated_at
+		FROM
+			organizations
+		WHERE
+			slug = $1
+	`
+
+	return r.scan(r.db.QueryRowContext(ctx, query, slug))
+}
+
+func (r *organizationRepository) Update(ctx context.Context, organization *entity.Organization) error {
+	query := `
+		UPDATE
+			organizations
+		SET
+			name = $1, slug = $2, updated_at = $3
+		WHERE
+			id = $4
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		organization.Name,
+		organization.Slug,
+		organization.UpdatedAt,
+		organization.ID,
+	)
+
+	return err
+}
+
+func (r *organizationRepository) SetOwner(ctx context.Context, id, ownerID string, updatedAt time.Time) error {
+	query := `
+		UPDATE
+			organizations
+		SET
+			owner_id = $1, updated_at = $2
+		WHERE
+			id = $3
+	`
+
+	_, err := r.db.ExecContext(ctx, query, ownerID, updatedAt, id)
+	return err
+}
+
+// Delete removes the organization; its memberships and invitations go with
+// it through the foreign keys.
+func (r *organizationRepository) Delete(ctx context.Context, id string) error {
+	query := `
+		DELETE FROM
+			organizations
+		WHERE
+			id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *organizationRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.UserOrganization, error) {
+	query := `
+		SELECT
+			o.id, o.name, o.slug, o.owner_id, o.created_at, o.updated_at, m.role
+		FROM
+			organizations o
+			JOIN organization_memberships m ON m.organization_id = o.id
+		WHERE
+			m.user_id = $1
+		ORDER BY
+			o.name, o.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizations := []*entity.UserOrganization{}
+	for rows.Next() {
+		organization := &entity.Organization{}
+		userOrganization := &entity.UserOrganization{Organization: organization}
+		if err := rows.Scan(
+			&organization.ID,
+			&organization.Name,
+			&organization.Slug,
+			&organization.OwnerID,
+			&organization.CreatedAt,
+			&organization.UpdatedAt,
+			&userOrganization.Role,
+		); err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, userOrganization)
+	}
+
+	return organizations, rows.Err()
+}
+
+func (r *organizationRepository) CountOwnedByUserID(ctx context.Context, userID string) (int, error) {
+	query := `
+		SELECT
+			COUNT(*)
+		FROM
+			organizations
+		WHERE
+			owner_id = $1
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *organizationRepository) scan(row *sql.Row) (*entity.Organization, error) {
+	organization := &entity.Organization{}
+	err := row.Scan(
+		&organization.ID,
+		&organization.Name,
+		&organization.Slug,
+		&organization.OwnerID,
+		&organization.CreatedAt,
+		&organization.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return organization, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+// PhoneChallengeRepository keeps one challenge per user and purpose, with a
+// count of the failed attempts against it.
+type PhoneChallengeRepository interface {
+	Save(ctx context.Context, userID, purpose string, challenge *entity.PhoneChallenge, expiration time.Duration) error
+	Get(ctx context.Context, userID, purpose string) (*entity.PhoneChallenge, error)
+	Delete(ctx context.Context, userID, purpose string) error
+	IncrAttempts(ctx context.Context, userID, purpose string, expiration time.Duration) (int64, error)
+}
+
+type phoneChallengeRepository struct {
+	redisRepo RedisRepository
+}
+
+func NewPhoneChallengeRepository(redisRepo RedisRepository) PhoneChallengeRepository {
+	return &phoneChallengeRepository{
+		redisRepo: redisRepo,
+	}
+}
+
+// Save replaces any earlier challenge and resets its attempt count.
+func (r *phoneChallengeRepository) Save(ctx context.Context, userID, purpose string, challenge *entity.PhoneChallenge, expiration time.Duration) error {
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return err
+	}
+
+	if err := r.redisRepo.Delete(ctx, fmt.Sprintf(constant.PhoneAttemptsPrefix, purpose, userID)); err != nil {
+		return err
+	}
+	return r.redisRepo.Set(ctx, fmt.Sprintf(constant.PhoneChallengePrefix, purpose, userID), data, expiration)
+}
+
+func (r *phoneChallengeRepository) Get(ctx context.Context, userID, purpose string) (*entity.PhoneChallenge, error) {
+	result, err := r.redisRepo.Get(ctx, fmt.Sprintf(constant.PhoneChallengePrefix, purpose, userID))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	challenge := &entity.PhoneChallenge{}
+	if err := json.Unmarshal([]byte(result), challenge); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+func (r *phoneChallengeRepository) Delete(ctx context.Context, userID, purpose string) error {
+	if err := r.redisRepo.Delete(ctx, fmt.Sprintf(constant.PhoneChallengePrefix, purpose, userID)); err != nil {
+		return err
+	}
+	return r.redisRepo.Delete(ctx, fmt.Sprintf(constant.PhoneAttemptsPrefix, purpose, userID))
+}
+
+func (r *phoneChallengeRepository) IncrAttempts(ctx context.Context, userID, purpose string, expiration time.Duration) (int64, error) {
+	return r.redisRepo.Incr(ctx, fmt.Sprintf(constant.PhoneAttemptsPrefix, purpose, userID), expiration)
+}
@@ -11,6 +11,10 @@ type RedisRepository interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value any, expiration time.Duration) error
 	Delete(ctx context.Context, key string) error
+	MGet(ctx context.Context, keys []string) (map[string]string, error)
+	SetMany(ctx context.Context, values map[string]any, expiration time.Duration) error
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	TTL(ctx context.Context, key string) (time.Duration, error)
 }
 
 type redisClusterRepository struct {
@@ -33,3 +37,64 @@ func (r *redisClusterRepository) Set(ctx context.Context, key string, value any,
 func (r *redisClusterRepository) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
+
+// MGet groups keys by cluster slot and issues one MGET per slot in a single
+// pipeline, since a cross-slot MGET is rejected by the cluster. Missing keys
+// are absent from the result.
+func (r *redisClusterRepository) MGet(ctx context.Context, keys []string) (map[string]string, error) {
+	slots := map[int][]string{}
+	for _, key := range keys {
+		slot := redisKeySlot(key)
+		slots[slot] = append(slots[slot], key)
+	}
+
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.SliceCmd, 0, len(slots))
+	for _, slotKeys := range slots {
+		cmds = append(cmds, pipe.MGet(ctx, slotKeys...))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(keys))
+	for _, cmd := range cmds {
+		slotKeys := cmd.Args()[1:]
+		for i, value := range cmd.Val() {
+			if s, ok := value.(string); ok {
+				values[slotKeys[i].(string)] = s
+			}
+		}
+	}
+
+	return values, nil
+}
+
+func (r *redisClusterRepository) SetMany(ctx context.Context, values map[string]any, expiration time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	pipe := r.client.Pipeline()
+	for key, value := range values {
+		pipe.Set(ctx, key, value, expiration)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Incr increments a counter and starts its expiration on the first
+// increment, so a fixed window counts from the first event in it.
+func (r *redisClusterRepository) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, expiration)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (r *redisClusterRepository) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return r.client.TTL(ctx, key).Result()
+}
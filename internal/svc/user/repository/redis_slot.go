@@ -0,0 +1,32 @@
+package repository
+
+import "strings"
+
+const redisClusterSlots = 16384
+
+// redisKeySlot mirrors the server-side CLUSTER KEYSLOT computation: CRC16
+// (XMODEM) of the key, or of its {hash tag} when one is present, modulo
+// 16384. Keys sharing a slot can be read with a single MGET.
+func redisKeySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key)) % redisClusterSlots
+}
+
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
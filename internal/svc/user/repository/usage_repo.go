@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+// UsageRepository reads and records the consumption of the tenant the
+// transaction acts in.
+type UsageRepository interface {
+	LockTenant(ctx context.Context) error
+	CountLiveUsers(ctx context.Context) (int64, error)
+	Upsert(ctx context.Context, record *entity.UsageRecord) error
+	Sum(ctx context.Context, metric string, from, to time.Time) (int64, error)
+}
+
+type usageRepository struct {
+	db DBTX
+}
+
+func NewUsageRepository(db DBTX) UsageRepository {
+	return &usageRepository{
+		db: db,
+	}
+}
+
+// LockTenant serializes quota checks of the tenant until the transaction
+// ends, so concurrent creations cannot overshoot a limit together.
+func (r *usageRepository) LockTenant(ctx context.Context) error {
+	query := `
+		SELECT
+			pg_advisory_xact_lock(hashtext('quota:' || current_tenant()))
+	`
+
+	_, err := r.db.ExecContext(ctx, query)
+	return err
+}
+
+func (r *usageRepository) CountLiveUsers(ctx context.Context) (int64, error) {
+	query := `
+		SELECT
+			COUNT(*)
+		FROM
+			users
+		WHERE
+			deleted_at IS NULL
+	`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	return count, err
+}
+
+func (r *usageRepository) Upsert(ctx context.Context, record *entity.UsageRecord) error {
+	query := `
+		INSERT INTO
+			tenant_usage (metric, day, value, updated_at)
+		VALUES
+			($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, metric, day) DO UPDATE SET
+			value = EXCLUDED.value, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		record.Metric,
+		record.Day,
+		record.Value,
+		record.UpdatedAt,
+	)
+
+	return err
+}
+
+// Sum adds up the daily values of metric over [from, to).
+func (r *usageRepository) Sum(ctx context.Context, metric string, from, to time.Time) (int64, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(value), 0)
+		FROM
+			tenant_usage
+		WHERE
+			metric = $1 AND day >= $2 AND day < $3
+	`
+
+	var sum int64
+	err := r.db.QueryRowContext(ctx, query, metric, from, to).Scan(&sum)
+	return sum, err
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+// SoftDeleteUser marks a live user deleted when expectedVersion is nil or
+// matches the stored version. It reports whether a row was marked.
+func (r *userRepository) SoftDeleteUser(ctx context.Context, id string, expectedVersion *int64, deletedAt time.Time) (bool, error) {
+	query := `
+		UPDATE
+			users
+		SET
+			deleted_at = $1, updated_at = $1, version = version + 1
+		WHERE
+			id = $2 AND deleted_at IS NULL AND ($3::BIGINT IS NULL OR version = $3)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, deletedAt, id, expectedVersion)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *userRepository) GetDeletedByID(ctx context.Context, id string) (*entity.User, error) {
+	query := `
+		SELECT
+			id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, deleted_at, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at
+		FROM
+			users
+		WHERE
+			id = $1 AND deleted_at IS NOT NULL
+	`
+
+	return r.scanDeletedUser(r.db.QueryRowContext(ctx, query, id))
+}
+
+// GetDeletedByEmail returns the most recently deleted user that held email.
+func (r *userRepository) GetDeletedByEmail(ctx context.Context, email string) (*entity.User, error) {
+	query := `
+		SELECT
+			id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, deleted_at, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at
+		FROM
+			users
+		WHERE
+			email = $1 AND deleted_at IS NOT NULL
+		ORDER BY
+			deleted_at DESC
+		LIMIT 1
+	`
+
+	return r.scanDeletedUser(r.db.QueryRowContext(ctx, query, email))
+}
+
+func (r *userRepository) scanDeletedUser(row *sql.Row) (*entity.User, error) {
+	user := &entity.User{}
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.FirstName,
+		&user.LastName,
+		&user.Status,
+		&user.StatusReason,
+		&user.StatusChangedBy,
+		&user.StatusChangedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.Version,
+		&user.DeletedAt,
+		&user.Phone,
+		&user.Locale,
+		&user.Timezone,
+		&user.AvatarRef,
+		&user.Attributes,
+		&user.PhoneVerifiedAt,
+		&user.Username,
+		&user.UsernameChangedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// RestoreUser clears deleted_at if the row is still at user.Version, and
+// advances user.Version on success. It reports whether the row was restored.
+func (r *userRepository) RestoreUser(ctx context.Context, user *entity.User) (bool, error) {
+	query := `
+		UPDATE
+			users
+		SET
+			deleted_at = NULL, updated_at = $1, version = version + 1
+		WHERE
+			id = $2 AND version = $3 AND deleted_at IS NOT NULL
+		RETURNING
+			version
+	`
+
+	err := r.db.QueryRowContext(ctx, query, user.UpdatedAt, user.ID, user.Version).Scan(&user.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	user.DeletedAt = nil
+	return true, nil
+}
+
+// AnonymizeUser replaces the user's personal data with placeholders and
+// marks the row deleted, keeping the ID so references stay valid until the
+// purge removes it. The placeholder email is unique per user.
+func (r *userRepository) AnonymizeUser(ctx context.Context, id string, at time.Time) error {
+	query := `
+		UPDATE
+			users
+		SET
+			email = 'erased+' || id || '@erased.invalid',
+			first_name = '',
+			last_name = '',
+			status_reason = '',
+			status_changed_by = '',
+			phone = '',
+			locale = '',
+			timezone = '',
+			avatar_ref = '',
+			attributes = '{}',
+			phone_verified_at = NULL,
+			username = '',
+			username_changed_at = NULL,
+			deleted_at = COALESCE(deleted_at, $1),
+			updated_at = $1,
+			version = version + 1
+		WHERE
+			id = $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, at, id)
+	return err
+}
+
+// PurgeDeletedUsers hard-deletes up to limit users soft-deleted before
+// deletedBefore and returns their IDs. SKIP LOCKED lets several service
+// instances purge concurrently without blocking on each other.
+func (r *userRepository) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time, limit int) ([]string, error) {
+	query := `
+		DELETE FROM
+			users
+		WHERE
+			id IN (
+				SELECT
+					id
+				FROM
+					users
+				WHERE
+					deleted_at < $1
+				ORDER BY
+					deleted_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING
+			id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, deletedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// ListPurgeableTenants returns the tenants holding users PurgeDeletedUsers
+// would delete. It is only useful under the tenant.All scope.
+func (r *userRepository) ListPurgeableTenants(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	query := `
+		SELECT DISTINCT
+			tenant_id
+		FROM
+			users
+		WHERE
+			deleted_at < $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenantIDs := []string{}
+	for rows.Next() {
+		var tenantID string
+		if err := rows.Scan(&tenantID); err != nil {
+			return nil, err
+		}
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+
+	return tenantIDs, rows.Err()
+}
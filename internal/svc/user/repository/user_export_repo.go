@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+// ExportUsers calls fn for every live user, oldest first, optionally only
+// those with status. Users are read through a cursor fetchSize rows at a
+// time, so memory use does not grow with the table. It must run inside a
+// transaction, which is also what keeps the export to one snapshot.
+func (r *userRepository) ExportUsers(ctx context.Context, status string, fetchSize int, fn func(user *entity.User) error) error {
+	declareQuery := `
+		DECLARE user_export NO SCROLL CURSOR FOR
+		SELECT
+			id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at
+		FROM
+			users
+		WHERE
+			deleted_at IS NULL AND ($1 = '' OR status = $1)
+		ORDER BY
+			created_at, id
+	`
+
+	if _, err := r.db.ExecContext(ctx, declareQuery, status); err != nil {
+		return err
+	}
+
+	fetchQuery := fmt.Sprintf(`FETCH FORWARD %d FROM user_export`, fetchSize)
+	for {
+		fetched, err := r.fetchExport(ctx, fetchQuery, fn)
+		if err != nil {
+			return err
+		}
+		if fetched < fetchSize {
+			break
+		}
+	}
+
+	_, err := r.db.ExecContext(ctx, `CLOSE user_export`)
+	return err
+}
+
+func (r *userRepository) fetchExport(ctx context.Context, query string, fn func(user *entity.User) error) (int, error) {
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		user := &entity.User{}
+		if err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.FirstName,
+			&user.LastName,
+			&user.Status,
+			&user.StatusReason,
+			&user.StatusChangedBy,
+			&user.StatusChangedAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.Version,
+			&user.Phone,
+			&user.Locale,
+			&user.Timezone,
+			&user.AvatarRef,
+			&user.Attributes,
+			&user.PhoneVerifiedAt,
+			&user.Username,
+			&user.UsernameChangedAt,
+		); err != nil {
+			return fetched, err
+		}
+		fetched++
+
+		if err := fn(user); err != nil {
+			return fetched, err
+		}
+	}
+	return fetched, rows.Err()
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type UserHistoryRepository interface {
+	RecordRevision(ctx context.Context, userID string, at time.Time) error
+	GetAsOf(ctx context.Context, userID string, at time.Time) (*entity.UserRevision, error)
+	ListRevisions(ctx context.Context, userID string, beforeVersion int64, limit int) ([]*entity.UserRevision, error)
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+type userHistoryRepository struct {
+	db DBTX
+}
+
+func NewUserHistoryRepository(db DBTX) UserHistoryRepository {
+	return &userHistoryRepository{
+		db: db,
+	}
+}
+
+// RecordRevision closes the user's open revision at at and copies the row as
+// it now stands into a new one. It must run in the transaction that changed
+// the row, after the change, so the copy is exactly what was committed.
+func (r *userHistoryRepository) RecordRevision(ctx context.Context, userID string, at time.Time) error {
+	closeQuery := `
+		UPDATE
+			users_history
+		SET
+			valid_to = $2
+		WHERE
+			user_id = $1 AND valid_to IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, closeQuery, userID, at); err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO
+			users_history (user_id, version, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, deleted_at, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at, valid_from)
+		SELECT
+			id, version, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, deleted_at, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at, $2
+		FROM
+			users
+		WHERE
+			id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, insertQuery, userID, at)
+	return err
+}
+
+func (r *userHistoryRepository) GetAsOf(ctx context.Context, userID string, at time.Time) (*entity.UserRevision, error) {
+	query := `
+		SELECT
+			user_id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, deleted_at, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at, valid_from, valid_to
+		FROM
+			users_history
+		WHERE
+			user_id = $1 AND valid_from <= $2 AND (valid_to IS NULL OR valid_to > $2)
+	`
+
+	revision, err := r.scan(r.db.QueryRowContext(ctx, query, userID, at))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// ListRevisions returns up to limit revisions older than beforeVersion,
+// newest first. A beforeVersion of zero starts from the current revision.
+func (r *userHistoryRepository) ListRevisions(ctx context.Context, userID string, beforeVersion int64, limit int) ([]*entity.UserRevision, error) {
+	query := `
+		SELECT
+			user_id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, deleted_at, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at, valid_from, valid_to
+		FROM
+			users_history
+		WHERE
+			user_id = $1 AND ($2 = 0 OR version < $2)
+		ORDER BY
+			version DESC
+		LIMIT
+			$3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, beforeVersion, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []*entity.UserRevision{}
+	for rows.Next() {
+		revision, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+func (r *userHistoryRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	query := `
+		DELETE FROM
+			users_history
+		WHERE
+			user_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *userHistoryRepository) scan(row interface{ Scan(...any) error }) (*entity.UserRevision, error) {
+	user := &entity.User{}
+	revision := &entity.UserRevision{User: user}
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.FirstName,
+		&user.LastName,
+		&user.Status,
+		&user.StatusReason,
+		&user.StatusChangedBy,
+		&user.StatusChangedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.Version,
+		&user.DeletedAt,
+		&user.Phone,
+		&user.Locale,
+		&user.Timezone,
+		&user.AvatarRef,
+		&user.Attributes,
+		&user.PhoneVerifiedAt,
+		&user.Username,
+		&user.UsernameChangedAt,
+		&revision.ValidFrom,
+		&revision.ValidTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
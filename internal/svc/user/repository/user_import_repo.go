@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/lib/pq"
+)
+
+// UserImportRepository loads users in bulk within one transaction. Rows are
+// copied into a staging table first, because COPY cannot write to a table
+// under row-level security. Conflicts are then found for the whole batch at
+// once. The staging table is dropped when the transaction ends.
+type UserImportRepository interface {
+	Stage(ctx context.Context, users []*entity.User) error
+	RejectEmailConflicts(ctx context.Context, includeDeleted bool) ([]string, error)
+	RejectUsernameConflicts(ctx context.Context, at time.Time) ([]string, error)
+	RejectBeyond(ctx context.Context, keep int64) ([]string, error)
+	Insert(ctx context.Context, at time.Time) ([]string, error)
+}
+
+type userImportRepository struct {
+	db DBTX
+}
+
+func NewUserImportRepository(db DBTX) UserImportRepository {
+	return &userImportRepository{
+		db: db,
+	}
+}
+
+// Stage copies users into the staging table in order.
+func (r *userImportRepository) Stage(ctx context.Context, users []*entity.User) error {
+	createQuery := `
+		CREATE TEMP TABLE user_import_staging (
+			position INT NOT NULL,
+			LIKE users INCLUDING DEFAULTS
+		) ON COMMIT DROP
+	`
+
+	if _, err := r.db.ExecContext(ctx, createQuery); err != nil {
+		return err
+	}
+
+	stmt, err := r.db.PrepareContext(ctx, pq.CopyIn("user_import_staging",
+		"position", "id", "email", "first_name", "last_name", "status", "created_at", "updated_at", "version",
+		"phone", "locale", "timezone", "avatar_ref", "attributes", "username", "username_changed_at",
+	))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for i, user := range users {
+		// COPY sends values as text, so the attributes go as JSON text
+		// rather than the bytes their Value method returns.
+		attributes, err := json.Marshal(user.Attributes)
+		if err != nil {
+			return err
+		}
+		if user.Attributes == nil {
+			attributes = []byte("{}")
+		}
+
+		if _, err := stmt.ExecContext(ctx,
+			i,
+			user.ID,
+			user.Email,
+			user.FirstName,
+			user.LastName,
+			user.Status,
+			user.CreatedAt,
+			user.UpdatedAt,
+			user.Version,
+			user.Phone,
+			user.Locale,
+			user.Timezone,
+			user.AvatarRef,
+			string(attributes),
+			user.Username,
+			user.UsernameChangedAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	_, err = stmt.ExecContext(ctx)
+	return err
+}
+
+// RejectEmailConflicts drops staged users whose email belongs to a live
+// user, or to a soft-deleted one when includeDeleted is set, and returns
+// their ids.
+func (r *userImportRepository) RejectEmailConflicts(ctx context.Context, includeDeleted bool) ([]string, error) {
+	query := `
+		DELETE FROM
+			user_import_staging s
+		WHERE
+			EXISTS (
+				SELECT 1 FROM users u WHERE u.email = s.email AND ($1 OR u.deleted_at IS NULL)
+			)
+		RETURNING
+			s.id
+	`
+
+	return r.queryIDs(ctx, query, includeDeleted)
+}
+
+// RejectUsernameConflicts drops staged users whose username is held by any
+// user, live or soft-deleted, or still reserved at at, and returns their
+// ids.
+func (r *userImportRepository) RejectUsernameConflicts(ctx context.Context, at time.Time) ([]string, error) {
+	query := `
+		DELETE FROM
+			user_import_staging s
+		WHERE
+			s.username <> '' AND (
+				EXISTS (
+					SELECT 1 FROM users u WHERE LOWER(u.username) = LOWER(s.username)
+				) OR EXISTS (
+					SELECT 1 FROM username_reservations n WHERE n.username = LOWER(s.username) AND n.reserved_until > $1
+				)
+			)
+		RETURNING
+			s.id
+	`
+
+	return r.queryIDs(ctx, query, at)
+}
+
+// RejectBeyond keeps the first keep staged users and drops the rest,
+// returning the ids dropped.
+func (r *userImportRepository) RejectBeyond(ctx context.Context, keep int64) ([]string, error) {
+	query := `
+		DELETE FROM
+			user_import_staging
+		WHERE
+			position IN (SELECT position FROM user_import_staging ORDER BY position OFFSET $1)
+		RETURNING
+			id
+	`
+
+	return r.queryIDs(ctx, query, keep)
+}
+
+// Insert moves the remaining staged users into users, opens their first
+// revision at at, and returns their ids in staging order.
+func (r *userImportRepository) Insert(ctx context.Context, at time.Time) ([]string, error) {
+	insertQuery := `
+		INSERT INTO
+			users (id, email, first_name, last_name, status, created_at, updated_at, version, phone, locale, timezone, avatar_ref, attributes, username, username_changed_at)
+		SELECT
+			id, email, first_name, last_name, status, created_at, updated_at, version, phone, locale, timezone, avatar_ref, attributes, username, username_changed_at
+		FROM
+			user_import_staging
+		ORDER BY
+			position
+		RETURNING
+			id
+	`
+
+	ids, err := r.queryIDs(ctx, insertQuery)
+	if err != nil || len(ids) == 0 {
+		return ids, err
+	}
+
+	historyQuery := `
+		INSERT INTO
+			users_history (user_id, version, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, deleted_at, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at, valid_from)
+		SELECT
+			id, version, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, deleted_at, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at, $2
+		FROM
+			users
+		WHERE
+			id = ANY($1)
+	`
+
+	if _, err := r.db.ExecContext(ctx, historyQuery, pq.Array(ids), at); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *userImportRepository) queryIDs(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type ListUsersParams struct {
+	EmailPrefix   string
+	Name          string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Status        string
+	Organization  string
+	OrderBy       string
+	Descending    bool
+	AfterValue    any
+	AfterID       string
+	Limit         int
+}
+
+var listUsersOrderColumns = map[string]string{
+	"created_at": "created_at",
+	"email":      "email",
+}
+
+func (r *userRepository) ListUsers(ctx context.Context, params *ListUsersParams) ([]*entity.User, error) {
+	orderColumn, ok := listUsersOrderColumns[params.OrderBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported order column %q", params.OrderBy)
+	}
+
+	conditions := []string{"deleted_at IS NULL"}
+	args := []any{}
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if params.EmailPrefix != "" {
+		conditions = append(conditions, fmt.Sprintf("email LIKE %s", arg(escapeLike(params.EmailPrefix)+"%")))
+	}
+	if params.Name != "" {
+		pattern := arg(escapeLike(strings.ToLower(params.Name)) + "%")
+		conditions = append(conditions, fmt.Sprintf("(lower(first_name) LIKE %s OR lower(last_name) LIKE %s)", pattern, pattern))
+	}
+	if params.CreatedAfter != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= %s", arg(*params.CreatedAfter)))
+	}
+	if params.CreatedBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at < %s", arg(*params.CreatedBefore)))
+	}
+	if params.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("status = %s", arg(params.Status)))
+	}
+	if params.Organization != "" {
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT user_id FROM organization_memberships WHERE organization_id = %s)", arg(params.Organization)))
+	}
+
+	direction, comparator := "ASC", ">"
+	if params.Descending {
+		direction, comparator = "DESC", "<"
+	}
+	if params.AfterID != "" {
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)", orderColumn, comparator, arg(params.AfterValue), arg(params.AfterID)))
+	}
+
+	where := "WHERE\n\t\t\t" + strings.Join(conditions, "\n\t\t\tAND ")
+
+	query := fmt.Sprintf(`
+		SELECT
+			id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at
+		FROM
+			users
+		%s
+		ORDER BY
+			%s %s, id %s
+		LIMIT %s
+	`, where, orderColumn, direction, direction, arg(params.Limit))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*entity.User{}
+	for rows.Next() {
+		user := &entity.User{}
+		if err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.FirstName,
+			&user.LastName,
+			&user.Status,
+			&user.StatusReason,
+			&user.StatusChangedBy,
+			&user.StatusChangedAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.Version,
+			&user.Phone,
+			&user.Locale,
+			&user.Timezone,
+			&user.AvatarRef,
+			&user.Attributes,
+			&user.PhoneVerifiedAt,
+			&user.Username,
+			&user.UsernameChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
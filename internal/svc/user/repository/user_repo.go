@@ -4,16 +4,31 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/lib/pq"
 )
 
 type UserRepository interface {
 	CreateUser(ctx context.Context, user *entity.User) error
 	GetByUserID(ctx context.Context, id string) (*entity.User, error)
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
-	UpdateUser(ctx context.Context, user *entity.User) error
-	DeleteUserByID(ctx context.Context, id string) error
+	GetByUsername(ctx context.Context, username string) (*entity.User, error)
+	UsernameTaken(ctx context.Context, username, exceptUserID string) (bool, error)
+	GetByUserIDs(ctx context.Context, ids []string) ([]*entity.User, error)
+	UpdateUserFields(ctx context.Context, user *entity.User, paths []string, expectedVersion *int64) (*entity.User, error)
+	SoftDeleteUser(ctx context.Context, id string, expectedVersion *int64, deletedAt time.Time) (bool, error)
+	GetDeletedByID(ctx context.Context, id string) (*entity.User, error)
+	GetDeletedByEmail(ctx context.Context, email string) (*entity.User, error)
+	RestoreUser(ctx context.Context, user *entity.User) (bool, error)
+	AnonymizeUser(ctx context.Context, id string, at time.Time) error
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time, limit int) ([]string, error)
+	ListPurgeableTenants(ctx context.Context, deletedBefore time.Time) ([]string, error)
+	UpdateStatus(ctx context.Context, user *entity.User) (bool, error)
+	ListUsers(ctx context.Context, params *ListUsersParams) ([]*entity.User, error)
+	SearchUsers(ctx context.Context, params *SearchUsersParams) ([]*entity.UserSearchResult, error)
+	ExportUsers(ctx context.Context, status string, fetchSize int, fn func(user *entity.User) error) error
 }
 
 type userRepository struct {
@@ -29,9 +44,9 @@ func NewUserRepository(db DBTX) UserRepository {
 func (r *userRepository) CreateUser(ctx context.Context, user *entity.User) error {
 	query := `
 		INSERT INTO
-			users (id, email, first_name, last_name, created_at, updated_at)
+			users (id, email, first_name, last_name, status, created_at, updated_at, version, phone, locale, timezone, avatar_ref, attributes, username, username_changed_at)
 		VALUES
-			($1, $2, $3, $4, $5, $6)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -39,8 +54,17 @@ func (r *userRepository) CreateUser(ctx context.Context, user *entity.User) erro
 		user.Email,
 		user.FirstName,
 		user.LastName,
+		user.Status,
 		user.CreatedAt,
 		user.UpdatedAt,
+		user.Version,
+		user.Phone,
+		user.Locale,
+		user.Timezone,
+		user.AvatarRef,
+		user.Attributes,
+		user.Username,
+		user.UsernameChangedAt,
 	)
 
 	return err
@@ -49,11 +73,11 @@ func (r *userRepository) CreateUser(ctx context.Context, user *entity.User) erro
 func (r *userRepository) GetByUserID(ctx context.Context, id string) (*entity.User, error) {
 	query := `
 		SELECT
-			id, email, first_name, last_name, created_at, updated_at
+			id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at
 		FROM
 			users
 		WHERE
-			id = $1
+			id = $1 AND deleted_at IS NULL
 	`
 
 	user := &entity.User{}
@@ -62,8 +86,21 @@ func (r *userRepository) GetByUserID(ctx context.Context, id string) (*entity.Us
 		&user.Email,
 		&user.FirstName,
 		&user.LastName,
+		&user.Status,
+		&user.StatusReason,
+		&user.StatusChangedBy,
+		&user.StatusChangedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Version,
+		&user.Phone,
+		&user.Locale,
+		&user.Timezone,
+		&user.AvatarRef,
+		&user.Attributes,
+		&user.PhoneVerifiedAt,
+		&user.Username,
+		&user.UsernameChangedAt,
 	)
 
 	if err != nil {
@@ -76,14 +113,62 @@ func (r *userRepository) GetByUserID(ctx context.Context, id string) (*entity.Us
 	return user, nil
 }
 
+func (r *userRepository) GetByUserIDs(ctx context.Context, ids []string) ([]*entity.User, error) {
+	query := `
+		SELECT
+			id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at
+		FROM
+			users
+		WHERE
+			id = ANY($1) AND deleted_at IS NULL
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*entity.User{}
+	for rows.Next() {
+		user := &entity.User{}
+		if err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.FirstName,
+			&user.LastName,
+			&user.Status,
+			&user.StatusReason,
+			&user.StatusChangedBy,
+			&user.StatusChangedAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.Version,
+			&user.Phone,
+			&user.Locale,
+			&user.Timezone,
+			&user.AvatarRef,
+			&user.Attributes,
+			&user.PhoneVerifiedAt,
+			&user.Username,
+			&user.UsernameChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
 	query := `
 		SELECT
-			id, email, first_name, last_name, created_at, updated_at
+			id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at
 		FROM
 			users
 		WHERE
-			email = $1
+			email = $1 AND deleted_at IS NULL
 	`
 
 	user := &entity.User{}
@@ -92,8 +177,21 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.
 		&user.Email,
 		&user.FirstName,
 		&user.LastName,
+		&user.Status,
+		&user.StatusReason,
+		&user.StatusChangedBy,
+		&user.StatusChangedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Version,
+		&user.Phone,
+		&user.Locale,
+		&user.Timezone,
+		&user.AvatarRef,
+		&user.Attributes,
+		&user.PhoneVerifiedAt,
+		&user.Username,
+		&user.UsernameChangedAt,
 	)
 
 	if err != nil {
@@ -106,35 +204,96 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.
 	return user, nil
 }
 
-func (r *userRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+// GetByUsername matches the username regardless of case.
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
 	query := `
-		UPDATE
+		SELECT
+			id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at
+		FROM
 			users
-		SET
-			email = $1, first_name = $2, last_name = $3, updated_at = $4
 		WHERE
-			id = $5
+			LOWER(username) = LOWER($1) AND username <> '' AND deleted_at IS NULL
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
-		user.Email,
-		user.FirstName,
-		user.LastName,
-		user.UpdatedAt,
-		user.ID,
+	user := &entity.User{}
+	err := r.db.QueryRowContext(ctx, query, username).Scan(
+		&user.ID,
+		&user.Email,
+		&user.FirstName,
+		&user.LastName,
+		&user.Status,
+		&user.StatusReason,
+		&user.StatusChangedBy,
+		&user.StatusChangedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.Version,
+		&user.Phone,
+		&user.Locale,
+		&user.Timezone,
+		&user.AvatarRef,
+		&user.Attributes,
+		&user.PhoneVerifiedAt,
+		&user.Username,
+		&user.UsernameChangedAt,
 	)
 
-	return err
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UsernameTaken reports whether any user other than exceptUserID holds the
+// username, soft-deleted users included so a restore cannot collide.
+func (r *userRepository) UsernameTaken(ctx context.Context, username, exceptUserID string) (bool, error) {
+	query := `
+		SELECT
+			EXISTS (
+				SELECT 1 FROM users WHERE LOWER(username) = LOWER($1) AND username <> '' AND id::text <> $2
+			)
+	`
+
+	var taken bool
+	err := r.db.QueryRowContext(ctx, query, username, exceptUserID).Scan(&taken)
+	return taken, err
 }
 
-func (r *userRepository) DeleteUserByID(ctx context.Context, id string) error {
+// UpdateStatus writes the status fields only if the row is still at
+// user.Version, and advances user.Version on success. It reports whether the
+// row was updated.
+func (r *userRepository) UpdateStatus(ctx context.Context, user *entity.User) (bool, error) {
 	query := `
-		DELETE FROM
+		UPDATE
 			users
+		SET
+			status = $1, status_reason = $2, status_changed_by = $3, status_changed_at = $4, updated_at = $5, version = version + 1
 		WHERE
-			id = $1
+			id = $6 AND version = $7 AND deleted_at IS NULL
+		RETURNING
+			version
 	`
 
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
-}
\ No newline at end of file
+	err := r.db.QueryRowContext(ctx, query,
+		user.Status,
+		user.StatusReason,
+		user.StatusChangedBy,
+		user.StatusChangedAt,
+		user.UpdatedAt,
+		user.ID,
+		user.Version,
+	).Scan(&user.Version)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
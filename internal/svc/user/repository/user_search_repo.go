@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+// SearchUsersParams controls a ranked search. When MatchEmail is false only
+// name lexemes (weight A) and name trigrams take part, so callers that may
+// not see emails cannot probe them through search results either.
+type SearchUsersParams struct {
+	Term       string
+	Limit      int
+	MatchEmail bool
+}
+
+func (r *userRepository) SearchUsers(ctx context.Context, params *SearchUsersParams) ([]*entity.UserSearchResult, error) {
+	query := `
+		WITH q AS (
+			SELECT
+				websearch_to_tsquery('simple', $1) AS tsq,
+				CASE WHEN $3 THEN NULL ELSE '{a}'::"char"[] END AS weights
+		)
+		SELECT
+			id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at,
+			ts_rank(COALESCE(ts_filter(search_vector, q.weights), search_vector), q.tsq)
+				+ GREATEST(
+					similarity(first_name || ' ' || last_name, $1),
+					CASE WHEN $3 THEN similarity(email, $1) ELSE 0 END
+				) AS score,
+			ts_headline('simple', first_name || ' ' || last_name, q.tsq, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true'),
+			ts_headline('simple', email, q.tsq, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')
+		FROM
+			users, q
+		WHERE
+			deleted_at IS NULL
+			AND (
+				(search_vector @@ q.tsq AND ($3 OR ts_filter(search_vector, q.weights) @@ q.tsq))
+				OR (first_name || ' ' || last_name) % $1
+				OR ($3 AND email % $1)
+			)
+		ORDER BY
+			score DESC, id
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, params.Term, params.Limit, params.MatchEmail)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*entity.UserSearchResult{}
+	for rows.Next() {
+		user := &entity.User{}
+		result := &entity.UserSearchResult{User: user}
+		if err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.FirstName,
+			&user.LastName,
+			&user.Status,
+			&user.StatusReason,
+			&user.StatusChangedBy,
+			&user.StatusChangedAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.Version,
+			&user.Phone,
+			&user.Locale,
+			&user.Timezone,
+			&user.AvatarRef,
+			&user.Attributes,
+			&user.PhoneVerifiedAt,
+			&user.Username,
+			&user.UsernameChangedAt,
+			&result.Score,
+			&result.NameHighlight,
+			&result.EmailHighlight,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
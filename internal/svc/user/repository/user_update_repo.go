@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+var userUpdateColumns = map[string]func(user *entity.User) any{
+	"email":      func(user *entity.User) any { return user.Email },
+	"first_name": func(user *entity.User) any { return user.FirstName },
+	"last_name":  func(user *entity.User) any { return user.LastName },
+	"phone":      func(user *entity.User) any { return user.Phone },
+	"locale":     func(user *entity.User) any { return user.Locale },
+	"timezone":   func(user *entity.User) any { return user.Timezone },
+	"avatar_ref": func(user *entity.User) any { return user.AvatarRef },
+	"attributes": func(user *entity.User) any { return user.Attributes },
+
+	"phone_verified_at": func(user *entity.User) any { return user.PhoneVerifiedAt },
+
+	"username":            func(user *entity.User) any { return user.Username },
+	"username_changed_at": func(user *entity.User) any { return user.UsernameChangedAt },
+}
+
+// UpdateUserFields writes only the columns named in paths, plus updated_at,
+// so concurrent updates to different fields do not overwrite each other.
+// When expectedVersion is set the UPDATE only matches that version. It
+// returns the row as stored after the update, or nil if no row matched.
+func (r *userRepository) UpdateUserFields(ctx context.Context, user *entity.User, paths []string, expectedVersion *int64) (*entity.User, error) {
+	assignments := []string{}
+	args := []any{}
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	for _, path := range paths {
+		value, ok := userUpdateColumns[path]
+		if !ok {
+			return nil, fmt.Errorf("unsupported update column %q", path)
+		}
+		assignments = append(assignments, fmt.Sprintf("%s = %s", path, arg(value(user))))
+	}
+	assignments = append(assignments, fmt.Sprintf("updated_at = %s", arg(user.UpdatedAt)), "version = version + 1")
+
+	conditions := []string{fmt.Sprintf("id = %s", arg(user.ID)), "deleted_at IS NULL"}
+	if expectedVersion != nil {
+		conditions = append(conditions, fmt.Sprintf("version = %s", arg(*expectedVersion)))
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE
+			users
+		SET
+			%s
+		WHERE
+			%s
+		RETURNING
+			id, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, version, phone, locale, timezone, avatar_ref, attributes, phone_verified_at, username, username_changed_at
+	`, strings.Join(assignments, ", "), strings.Join(conditions, " AND "))
+
+	updated := &entity.User{}
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&updated.ID,
+		&updated.Email,
+		&updated.FirstName,
+		&updated.LastName,
+		&updated.Status,
+		&updated.StatusReason,
+		&updated.StatusChangedBy,
+		&updated.StatusChangedAt,
+		&updated.CreatedAt,
+		&updated.UpdatedAt,
+		&updated.Version,
+		&updated.Phone,
+		&updated.Locale,
+		&updated.Timezone,
+		&updated.AvatarRef,
+		&updated.Attributes,
+		&updated.PhoneVerifiedAt,
+		&updated.Username,
+		&updated.UsernameChangedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return updated, nil
+}
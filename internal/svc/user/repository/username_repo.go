@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+// UsernameRepository keeps usernames given up by their owners out of reach
+// of other users for a while, so a handle cannot be taken over right after
+// a rename. Reservations are keyed by the lowercased username.
+type UsernameRepository interface {
+	Reserve(ctx context.Context, reservation *entity.UsernameReservation) error
+	GetReservation(ctx context.Context, username string) (*entity.UsernameReservation, error)
+	DeleteReservation(ctx context.Context, username string) error
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+type usernameRepository struct {
+	db DBTX
+}
+
+func NewUsernameRepository(db DBTX) UsernameRepository {
+	return &usernameRepository{
+		db: db,
+	}
+}
+
+func (r *usernameRepository) Reserve(ctx context.Context, reservation *entity.UsernameReservation) error {
+	query := `
+		INSERT INTO
+			username_reservations (username, user_id, reserved_until)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (tenant_id, username) DO UPDATE SET
+			user_id = EXCLUDED.user_id, reserved_until = EXCLUDED.reserved_until
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		strings.ToLower(reservation.Username),
+		reservation.UserID,
+		reservation.ReservedUntil,
+	)
+	return err
+}
+
+// GetReservation returns the reservation on username if it has not lapsed.
+func (r *usernameRepository) GetReservation(ctx context.Context, username string) (*entity.UsernameReservation, error) {
+	query := `
+		SELECT
+			username, user_id, reserved_until
+		FROM
+			username_reservations
+		WHERE
+			username = $1 AND reserved_until > $2
+	`
+
+	reservation := &entity.UsernameReservation{}
+	err := r.db.QueryRowContext(ctx, query, strings.ToLower(username), time.Now().UTC()).Scan(
+		&reservation.Username,
+		&reservation.UserID,
+		&reservation.ReservedUntil,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+func (r *usernameRepository) DeleteReservation(ctx context.Context, username string) error {
+	query := `
+		DELETE FROM
+			username_reservations
+		WHERE
+			username = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, strings.ToLower(username))
+	return err
+}
+
+func (r *usernameRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	query := `
+		DELETE FROM
+			username_reservations
+		WHERE
+			user_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ForceEmailChange sets a user's email on an administrator's authority. It
+// skips the version check of UpdateUser but still keeps emails unique.
+func (u *userUseCaseImpl) ForceEmailChange(ctx context.Context, req *dto.ForceEmailChangeRequest) (*dto.UserResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, grpcerror.NewReasonRequiredError()
+	}
+	normalizedEmail := strings.ToLower(strings.TrimSpace(req.Email))
+	if normalizedEmail == "" {
+		return nil, grpcerror.NewEmailRequiredError()
+	}
+
+	res := new(dto.UserResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		userRepository := ds.UserRepository()
+
+		existingUser, err := userRepository.GetByUserID(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if existingUser == nil {
+			return grpcerror.NewUserNotFoundError()
+		}
+		if err := u.checkEmailAvailable(ctx, userRepository, normalizedEmail, req.ID); err != nil {
+			return err
+		}
+
+		changes := &entity.User{
+			ID:        existingUser.ID,
+			Email:     normalizedEmail,
+			UpdatedAt: time.Now().UTC(),
+		}
+		updatedUser, err := userRepository.UpdateUserFields(ctx, changes, []string{"email"}, nil)
+		if err != nil {
+			return err
+		}
+		if updatedUser == nil {
+			return grpcerror.NewUserNotFoundError()
+		}
+
+		if err := ds.UserHistoryRepository().RecordRevision(ctx, req.ID, changes.UpdatedAt); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, ds, constant.AuditOperationForceEmailChange, req.ID, existingUser, updatedUser, reasonMetadata(reason)); err != nil {
+			return err
+		}
+
+		cacheKey := userCacheKey(ctx, req.ID)
+		u.redisRepo.Delete(ctx, cacheKey)
+
+		res = dto.ToUserResponse(updatedUser)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetUserStatus moves a user to any status in
+// constant.AdminSettableStatuses the transition table allows, including a
+// ban, which the public API cannot set.
+func (u *userUseCaseImpl) SetUserStatus(ctx context.Context, req *dto.SetUserStatusRequest) (*dto.UserResponse, error) {
+	if !slices.Contains(constant.AdminSettableStatuses, req.Status) {
+		return nil, grpcerror.NewUnsupportedStatusError(req.Status)
+	}
+
+	return u.changeStatus(ctx, &dto.ChangeUserStatusRequest{
+		ID:      req.ID,
+		Reason:  req.Reason,
+		ActorID: req.ActorID,
+	}, req.Status)
+}
+
+func (u *userUseCaseImpl) BulkSetUserStatus(ctx context.Context, req *dto.BulkSetUserStatusRequest) (*dto.BulkOperationResponse, error) {
+	if !slices.Contains(constant.AdminSettableStatuses, req.Status) {
+		return nil, grpcerror.NewUnsupportedStatusError(req.Status)
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, grpcerror.NewStatusReasonRequiredError()
+	}
+
+	return applyBulk(req.IDs, func(id string) error {
+		_, err := u.changeStatus(ctx, &dto.ChangeUserStatusRequest{
+			ID:      id,
+			Reason:  req.Reason,
+			ActorID: req.ActorID,
+		}, req.Status)
+		return err
+	})
+}
+
+// BulkDeleteUsers soft deletes users exactly as DeleteUser does, so each
+// one can still be restored within the grace period.
+func (u *userUseCaseImpl) BulkDeleteUsers(ctx context.Context, req *dto.BulkDeleteUsersRequest) (*dto.BulkOperationResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, grpcerror.NewReasonRequiredError()
+	}
+
+	return applyBulk(req.IDs, func(id string) error {
+		_, err := u.DeleteUser(ctx, &dto.DeleteUserRequest{
+			ID:      id,
+			Reason:  reason,
+			ActorID: req.ActorID,
+		})
+		return err
+	})
+}
+
+// applyBulk runs apply for each distinct id in turn and reports every
+// outcome. A failure is recorded against its user rather than stopping the
+// batch.
+func applyBulk(ids []string, apply func(id string) error) (*dto.BulkOperationResponse, error) {
+	if len(ids) == 0 {
+		return nil, grpcerror.NewUserIDsRequiredError()
+	}
+
+	distinct := make([]string, 0, len(ids))
+	seen := map[string]struct{}{}
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		distinct = append(distinct, id)
+	}
+	if len(distinct) > constant.MaxBulkUsers {
+		return nil, grpcerror.NewTooManyUserIDsError(constant.MaxBulkUsers)
+	}
+
+	res := &dto.BulkOperationResponse{
+		Results: make([]*dto.BulkOperationResult, 0, len(distinct)),
+	}
+	for _, id := range distinct {
+		result := &dto.BulkOperationResult{UserID: id, Code: codes.OK}
+		if err := apply(id); err != nil {
+			// Errors that are not gRPC statuses come from storage and are
+			// not shown to the caller.
+			st, ok := status.FromError(err)
+			if !ok {
+				st, _ = status.FromError(grpcerror.NewInternalError())
+			}
+			result.Code = st.Code()
+			result.Message = st.Message()
+		}
+		res.Results = append(res.Results, result)
+	}
+	return res, nil
+}
+
+func reasonMetadata(reason string) map[string]any {
+	if reason == "" {
+		return nil
+	}
+	return map[string]any{"reason": reason}
+}
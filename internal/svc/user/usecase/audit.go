@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+func (u *userUseCaseImpl) ListAuditEvents(ctx context.Context, req *dto.ListAuditEventsRequest) (*dto.ListAuditEventsResponse, error) {
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = constant.DefaultPageSize
+	}
+	if pageSize > constant.MaxPageSize {
+		pageSize = constant.MaxPageSize
+	}
+
+	filter := &audit.Filter{
+		ActorID:   strings.TrimSpace(req.ActorID),
+		TargetID:  strings.TrimSpace(req.TargetID),
+		Operation: strings.TrimSpace(req.Operation),
+		Limit:     pageSize + 1,
+	}
+	if req.From != nil {
+		filter.From = *req.From
+	}
+	if req.To != nil {
+		filter.To = *req.To
+	}
+	if err := audit.ApplyPageToken(filter, req.PageToken); err != nil {
+		return nil, grpcerror.NewInvalidPageTokenError()
+	}
+
+	var events []*audit.Event
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		events, err = ds.AuditRepository().List(ctx, filter)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.ListAuditEventsResponse{}
+	if len(events) > pageSize {
+		events = events[:pageSize]
+		res.NextPageToken = audit.EncodePageToken(events[len(events)-1])
+	}
+	res.Events = events
+
+	return res, nil
+}
+
+// recordAudit writes an audit event for a change to a user through ds, so it
+// commits or rolls back with the change itself. Either snapshot may be nil.
+func recordAudit(ctx context.Context, ds repository.DataStore, operation, targetID string, before, after *entity.User, metadata map[string]any) error {
+	changes, err := audit.Diff(before, after, constant.AuditRedactedUserFields...)
+	if err != nil {
+		return err
+	}
+
+	event := &audit.Event{
+		ID:        uuid.New().String(),
+		ActorID:   constant.AuditActorSystem,
+		TargetID:  targetID,
+		Operation: operation,
+		RequestID: interceptor.RequestIDFromContext(ctx),
+		SourceIP:  interceptor.SourceIPFromContext(ctx),
+		Metadata:  metadata,
+		Changes:   changes,
+	}
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		event.ActorID = claims.UserID
+		if claims.IsImpersonation() {
+			event.ImpersonatorID = claims.Actor.Subject
+		}
+	}
+
+	return ds.AuditRepository().Record(ctx, event)
+}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/blob"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/imageutils"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+// AvatarConfig controls avatar uploads. Store is required; BaseURL is the
+// public address of the avatar HTTP handler, and a zero MaxBytes falls back
+// to constant.DefaultAvatarMaxBytes.
+type AvatarConfig struct {
+	Store    blob.BlobStore
+	BaseURL  string
+	MaxBytes int64
+}
+
+// UploadAvatar renders the upload into thumbnails, stores them and points
+// the user's avatar_ref at them. Users may replace their own avatar; anyone
+// else needs constant.PermissionManageAvatars.
+func (u *userUseCaseImpl) UploadAvatar(ctx context.Context, req *dto.UploadAvatarRequest) (*dto.UploadAvatarResponse, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+	if claims.UserID != req.UserID && !claims.HasPermission(constant.PermissionManageAvatars) {
+		return nil, grpcerror.NewPermissionDeniedError()
+	}
+
+	var existingUser *entity.User
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		existingUser, err = ds.UserRepository().GetByUserID(ctx, req.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if existingUser == nil {
+		return nil, grpcerror.NewUserNotFoundError()
+	}
+
+	data, err := io.ReadAll(io.LimitReader(req.Content, u.avatars.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, grpcerror.NewAvatarEmptyError()
+	}
+	if int64(len(data)) > u.avatars.MaxBytes {
+		return nil, grpcerror.NewAvatarTooLargeError(u.avatars.MaxBytes)
+	}
+
+	sourceType, err := imageutils.Sniff(data)
+	if err != nil {
+		return nil, grpcerror.NewUnsupportedAvatarTypeError()
+	}
+	img, err := imageutils.Decode(data, constant.AvatarMaxPixels)
+	if errors.Is(err, imageutils.ErrTooLarge) {
+		return nil, grpcerror.NewAvatarDimensionsTooLargeError(constant.AvatarMaxPixels)
+	}
+	if err != nil {
+		return nil, grpcerror.NewUnsupportedAvatarTypeError()
+	}
+
+	sum := sha256.Sum256(data)
+	ref := fmt.Sprintf(constant.AvatarRefFormat, req.UserID, hex.EncodeToString(sum[:16]))
+
+	// The images are stored before the reference is committed, so a
+	// committed avatar_ref never points at missing images. A failed commit
+	// leaves unreferenced images behind, which the next upload or erasure
+	// of the user removes.
+	images := make([]*dto.AvatarImage, 0, len(constant.AvatarSizes))
+	for _, size := range constant.AvatarSizes {
+		encoded, contentType, err := imageutils.Encode(imageutils.Thumbnail(img, size, data))
+		if err != nil {
+			return nil, err
+		}
+
+		storeCtx, cancel := context.WithTimeout(ctx, constant.AvatarStoreTimeout)
+		err = u.avatars.Store.Put(storeCtx, avatarKey(ref, size), contentType, encoded)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		images = append(images, &dto.AvatarImage{
+			Size:        size,
+			URL:         u.avatarURL(ref, size),
+			ContentType: contentType,
+		})
+	}
+
+	res := &dto.UploadAvatarResponse{Images: images}
+	var previousRef string
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		userRepository := ds.UserRepository()
+
+		existingUser, err := userRepository.GetByUserID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if existingUser == nil {
+			return grpcerror.NewUserNotFoundError()
+		}
+		previousRef = existingUser.AvatarRef
+
+		changes := &entity.User{
+			ID:        existingUser.ID,
+			AvatarRef: ref,
+			UpdatedAt: time.Now().UTC(),
+		}
+		updatedUser, err := userRepository.UpdateUserFields(ctx, changes, []string{"avatar_ref"}, &existingUser.Version)
+		if err != nil {
+			return err
+		}
+		if updatedUser == nil {
+			return currentVersionError(ctx, userRepository, req.UserID)
+		}
+
+		if err := ds.UserHistoryRepository().RecordRevision(ctx, req.UserID, changes.UpdatedAt); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, ds, constant.AuditOperationUploadAvatar, req.UserID, existingUser, updatedUser, map[string]any{
+			"content_type": sourceType,
+			"bytes":        len(data),
+		}); err != nil {
+			return err
+		}
+
+		cacheKey := userCacheKey(ctx, req.UserID)
+		u.redisRepo.Delete(ctx, cacheKey)
+
+		res.User = dto.ToUserResponse(updatedUser)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Removing the replaced images is best effort: they are no longer
+	// referenced, and erasure deletes everything under the user's prefix.
+	if previousRef != ref && strings.HasPrefix(previousRef, fmt.Sprintf(constant.AvatarUserPrefix, req.UserID)) {
+		u.avatars.Store.DeletePrefix(ctx, previousRef+"/")
+	}
+
+	return res, nil
+}
+
+// deleteAvatars removes every image stored for the users and then their
+// queued deletions. The blob store cannot take part in a transaction, so
+// purging and erasure queue the deletion and call this once they have
+// committed. A deletion that fails stays queued for
+// retryAvatarDeletions; deleting images that are already gone succeeds.
+func (u *userUseCaseImpl) deleteAvatars(ctx context.Context, userIDs ...string) error {
+	for _, userID := range userIDs {
+		storeCtx, cancel := context.WithTimeout(ctx, constant.AvatarStoreTimeout)
+		err := u.avatars.Store.DeletePrefix(storeCtx, fmt.Sprintf(constant.AvatarUserPrefix, userID))
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+			return ds.AvatarDeletionRepository().Delete(ctx, userID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retryAvatarDeletions deletes the avatars still queued in any tenant, such
+// as those a failed deleteAvatars left behind. It carries on past failures
+// and returns the first.
+func (u *userUseCaseImpl) retryAvatarDeletions(ctx context.Context) error {
+	var deletions []*entity.AvatarDeletion
+	err := u.dataStore.Atomic(tenant.WithTenant(ctx, tenant.All), func(ds repository.DataStore) error {
+		var err error
+		deletions, err = ds.AvatarDeletionRepository().ListPending(ctx, constant.PurgeBatchSize)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, deletion := range deletions {
+		if err := u.deleteAvatars(tenant.WithTenant(ctx, deletion.TenantID), deletion.UserID); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (u *userUseCaseImpl) avatarURL(ref string, size int) string {
+	return strings.TrimSuffix(u.avatars.BaseURL, "/") + "/" + avatarKey(ref, size)
+}
+
+func avatarKey(ref string, size int) string {
+	return ref + "/" + strconv.Itoa(size)
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+func (u *userUseCaseImpl) BatchGetUsers(ctx context.Context, req *dto.BatchGetUsersRequest) (*dto.BatchGetUsersResponse, error) {
+	if len(req.IDs) == 0 {
+		return nil, grpcerror.NewUserIDsRequiredError()
+	}
+	if err := validateReadMask(req.ReadMask); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(req.IDs))
+	seen := map[string]struct{}{}
+	for _, id := range req.IDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if len(ids) > constant.MaxBatchGetUsers {
+		return nil, grpcerror.NewTooManyUserIDsError(constant.MaxBatchGetUsers)
+	}
+
+	users, err := u.userLoader.LoadMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.BatchGetUsersResponse{
+		Users:      make([]*dto.UserResponse, 0, len(users)),
+		MissingIDs: []string{},
+	}
+	for _, id := range ids {
+		user, ok := users[id]
+		if !ok {
+			res.MissingIDs = append(res.MissingIDs, id)
+			continue
+		}
+		res.Users = append(res.Users, dto.MaskUserResponse(redactForCaller(ctx, dto.ToUserResponse(user)), req.ReadMask))
+	}
+
+	return res, nil
+}
+
+// fetchUsers backs the user loader: cached users are read with a slot-aware
+// multi-get and the remainder are loaded with a single query, then cached.
+func (u *userUseCaseImpl) fetchUsers(ctx context.Context, ids []string) (map[string]*entity.User, error) {
+	users := make(map[string]*entity.User, len(ids))
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = userCacheKey(ctx, id)
+	}
+
+	cached, err := u.redisRepo.MGet(ctx, keys)
+	if err != nil {
+		cached = nil
+	}
+
+	misses := []string{}
+	for i, id := range ids {
+		if data, ok := cached[keys[i]]; ok && data != "" {
+			var user entity.User
+			if err := json.Unmarshal([]byte(data), &user); err == nil {
+				users[id] = &user
+				continue
+			}
+		}
+		misses = append(misses, id)
+	}
+
+	if len(misses) == 0 {
+		return users, nil
+	}
+
+	var loaded []*entity.User
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		loaded, err = ds.UserRepository().GetByUserIDs(ctx, misses)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	toCache := make(map[string]any, len(loaded))
+	for _, user := range loaded {
+		users[user.ID] = user
+		if userData, err := json.Marshal(user); err == nil {
+			toCache[userCacheKey(ctx, user.ID)] = string(userData)
+		}
+	}
+	u.redisRepo.SetMany(ctx, toCache, constant.UserCacheTTL)
+
+	return users, nil
+}
+
+// userCacheKey scopes a cached user to the caller's tenant.
+func userCacheKey(ctx context.Context, userID string) string {
+	return fmt.Sprintf(constant.UserCachePrefix, tenant.FromContext(ctx), userID)
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+func TestBatchGetUsersRedactsPIIOfOtherUsers(t *testing.T) {
+	callerID, otherID := uuid.NewString(), uuid.NewString()
+	loader := newUserLoader(func(ctx context.Context, ids []string) (map[string]*entity.User, error) {
+		users := map[string]*entity.User{}
+		for _, id := range ids {
+			users[id] = &entity.User{ID: id, Email: id + "@example.com", FirstName: "Ada", Phone: "+15550100"}
+		}
+		return users, nil
+	}, time.Millisecond, 100, time.Second)
+	usecase := &userUseCaseImpl{userLoader: loader}
+	req := &dto.BatchGetUsersRequest{IDs: []string{callerID, otherID}}
+
+	ctx := interceptor.ContextWithClaims(context.Background(), &jwtutils.JWTClaims{UserID: callerID, TokenType: "access"})
+	res, err := usecase.BatchGetUsers(ctx, req)
+	if err != nil {
+		t.Fatalf("BatchGetUsers: %v", err)
+	}
+	for _, user := range res.Users {
+		if hasPII, self := user.Email != "" || user.Phone != "", user.ID == callerID; hasPII != self {
+			t.Errorf("user %s = %+v, want PII only for the caller", user.ID, user)
+		}
+		if user.FirstName != "Ada" {
+			t.Errorf("FirstName = %q, want Ada", user.FirstName)
+		}
+	}
+
+	ctx = interceptor.ContextWithClaims(context.Background(), &jwtutils.JWTClaims{UserID: callerID, TokenType: "access", Permissions: []string{constant.PermissionReadPII}})
+	res, err = usecase.BatchGetUsers(ctx, req)
+	if err != nil {
+		t.Fatalf("BatchGetUsers with read_pii: %v", err)
+	}
+	for _, user := range res.Users {
+		if user.Email == "" || user.Phone == "" {
+			t.Errorf("user %s = %+v, want PII for a read_pii holder", user.ID, user)
+		}
+	}
+}
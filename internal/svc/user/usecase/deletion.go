@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/events"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+// DeletionConfig controls soft deletion. Zero values fall back to
+// constant.DefaultDeletionGracePeriod and constant.EmailPolicyReserve.
+type DeletionConfig struct {
+	GracePeriod time.Duration
+	EmailPolicy string
+}
+
+// DeleteUser soft-deletes a user. Users may delete their own account;
+// anyone else needs constant.PermissionDeleteUsers.
+func (u *userUseCaseImpl) DeleteUser(ctx context.Context, req *dto.DeleteUserRequest) (*dto.DeleteUserResponse, error) {
+	if err := authorizeSelfOrPermission(ctx, req.ID, constant.PermissionDeleteUsers); err != nil {
+		return nil, err
+	}
+
+	res := new(dto.DeleteUserResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		userRepository := ds.UserRepository()
+
+		existingUser, err := userRepository.GetByUserID(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if existingUser == nil {
+			return grpcerror.NewUserNotFoundError()
+		}
+		if req.ExpectedVersion != nil && *req.ExpectedVersion != existingUser.Version {
+			return grpcerror.NewVersionMismatchError(existingUser.Version)
+		}
+		if err := checkOwnsNoOrganizations(ctx, ds, req.ID); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		deleted, err := userRepository.SoftDeleteUser(ctx, req.ID, req.ExpectedVersion, now)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return currentVersionError(ctx, userRepository, req.ID)
+		}
+
+		deletedUser := *existingUser
+		deletedUser.DeletedAt = &now
+		deletedUser.UpdatedAt = now
+		deletedUser.Version++
+		if err := ds.UserHistoryRepository().RecordRevision(ctx, req.ID, now); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, ds, constant.AuditOperationDeleteUser, req.ID, existingUser, &deletedUser, reasonMetadata(req.Reason)); err != nil {
+			return err
+		}
+
+		if err := u.publishLifecycle(ctx, req.ID, events.UserLifecycleDeleted, "", req.ActorID, now); err != nil {
+			return err
+		}
+
+		cacheKey := userCacheKey(ctx, req.ID)
+		u.redisRepo.Delete(ctx, cacheKey)
+
+		restoreDeadline := now.Add(u.deletion.GracePeriod).Format(time.RFC3339)
+		res = dto.ToDeleteUserResponse(true, fmt.Sprintf(constant.UserDeletedSuccessfully, restoreDeadline))
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *userUseCaseImpl) RestoreUser(ctx context.Context, req *dto.RestoreUserRequest) (*dto.UserResponse, error) {
+	res := new(dto.UserResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		userRepository := ds.UserRepository()
+
+		user, err := userRepository.GetDeletedByID(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return grpcerror.NewUserNotFoundError()
+		}
+
+		now := time.Now().UTC()
+		deadline := user.DeletedAt.Add(u.deletion.GracePeriod)
+		if now.After(deadline) {
+			return grpcerror.NewRestoreWindowExpiredError(deadline)
+		}
+		if err := u.checkUserQuota(ctx, ds); err != nil {
+			return err
+		}
+
+		// Under the release policy someone may have registered the address
+		// since; the live account keeps it.
+		if u.deletion.EmailPolicy == constant.EmailPolicyRelease {
+			liveUser, err := userRepository.GetByEmail(ctx, user.Email)
+			if err != nil {
+				return err
+			}
+			if liveUser != nil {
+				return grpcerror.NewEmailExistsError()
+			}
+		}
+
+		before := *user
+		user.UpdatedAt = now
+		restored, err := userRepository.RestoreUser(ctx, user)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			return currentVersionError(ctx, userRepository, req.ID)
+		}
+
+		if err := ds.UserHistoryRepository().RecordRevision(ctx, user.ID, now); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, ds, constant.AuditOperationRestoreUser, user.ID, &before, user, nil); err != nil {
+			return err
+		}
+
+		if err := u.publishLifecycle(ctx, user.ID, events.UserLifecycleRestored, user.Status, req.ActorID, now); err != nil {
+			return err
+		}
+
+		res = dto.ToUserResponse(user)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// PurgeDeletedUsers hard-deletes users whose grace period has ended, along
+// with their history and avatars, one batch per transaction, and publishes
+// a final purged event for each so the auth service can drop their
+// credentials. Tenants are purged one after another, and avatar deletions
+// that failed earlier are retried last. It returns how many users were
+// purged.
+func (u *userUseCaseImpl) PurgeDeletedUsers(ctx context.Context) (int, error) {
+	var tenantIDs []string
+	err := u.dataStore.Atomic(tenant.WithTenant(ctx, tenant.All), func(ds repository.DataStore) error {
+		var err error
+		tenantIDs, err = ds.UserRepository().ListPurgeableTenants(ctx, time.Now().UTC().Add(-u.deletion.GracePeriod))
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, tenantID := range tenantIDs {
+		n, err := u.purgeTenant(tenant.WithTenant(ctx, tenantID), nil)
+		purged += n
+		if err != nil {
+			return purged, err
+		}
+	}
+	return purged, u.retryAvatarDeletions(ctx)
+}
+
+// purgeTenant purges the tenant of ctx. onBatch, when set, is told the
+// running total after every batch and stops the purge by returning an error.
+func (u *userUseCaseImpl) purgeTenant(ctx context.Context, onBatch func(purged int) error) (int, error) {
+	purged := 0
+	for {
+		var ids []string
+		err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+			now := time.Now().UTC()
+
+			var err error
+			ids, err = ds.UserRepository().PurgeDeletedUsers(ctx, now.Add(-u.deletion.GracePeriod), constant.PurgeBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, id := range ids {
+				if err := ds.UserHistoryRepository().DeleteByUserID(ctx, id); err != nil {
+					return err
+				}
+				if err := ds.UsernameRepository().DeleteByUserID(ctx, id); err != nil {
+					return err
+				}
+				if err := ds.MembershipRepository().DeleteByUserID(ctx, id); err != nil {
+					return err
+				}
+				if err := ds.AvatarDeletionRepository().Add(ctx, id, now); err != nil {
+					return err
+				}
+				if err := recordAudit(ctx, ds, constant.AuditOperationPurgeUser, id, nil, nil, nil); err != nil {
+					return err
+				}
+				if err := u.publishLifecycle(ctx, id, events.UserLifecyclePurged, "", "", now); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return purged, err
+		}
+		// Best effort: whatever fails stays queued for retryAvatarDeletions.
+		u.deleteAvatars(ctx, ids...)
+
+		purged += len(ids)
+		if onBatch != nil {
+			if err := onBatch(purged); err != nil {
+				return purged, err
+			}
+		}
+		if len(ids) < constant.PurgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// checkEmailAvailable rejects an email held by another live user and, under
+// the reserve policy, one still held by a soft-deleted user.
+func (u *userUseCaseImpl) checkEmailAvailable(ctx context.Context, userRepository repository.UserRepository, email, userID string) error {
+	existingUser, err := userRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if existingUser != nil && existingUser.ID != userID {
+		return grpcerror.NewEmailExistsError()
+	}
+
+	if u.deletion.EmailPolicy != constant.EmailPolicyReserve {
+		return nil
+	}
+
+	deletedUser, err := userRepository.GetDeletedByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if deletedUser != nil && deletedUser.ID != userID {
+		return grpcerror.NewEmailExistsError()
+	}
+	return nil
+}
+
+// publishLifecycle sends inside the caller's transaction, so a failed send
+// rolls the change back rather than leaving the auth service unaware of it.
+func (u *userUseCaseImpl) publishLifecycle(ctx context.Context, userID, eventType, status, actorID string, at time.Time) error {
+	return u.lifecycleProducer.Send(ctx, &events.UserLifecycleEvent{
+		EventID:    uuid.New().String(),
+		UserID:     userID,
+		Type:       eventType,
+		Status:     status,
+		ActorID:    actorID,
+		TenantID:   tenant.FromContext(ctx),
+		OccurredAt: at,
+	})
+}
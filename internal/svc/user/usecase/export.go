@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+// ExportUsers passes every live user, oldest first, to send. The users are
+// read from one snapshot through a cursor, so an export of any size runs in
+// constant memory. An error from send stops the export.
+func (u *userUseCaseImpl) ExportUsers(ctx context.Context, req *dto.ExportUsersRequest, send func(user *dto.UserResponse) error) error {
+	if req.Status != "" {
+		if _, ok := constant.UserStatusTransitions[req.Status]; !ok {
+			return grpcerror.NewInvalidStatusFilterError()
+		}
+	}
+
+	return u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		return ds.UserRepository().ExportUsers(ctx, req.Status, constant.ExportFetchSize, func(user *entity.User) error {
+			return send(dto.ToUserResponse(user))
+		})
+	})
+}
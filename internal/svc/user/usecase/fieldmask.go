@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+)
+
+func validateReadMask(paths []string) error {
+	for _, path := range paths {
+		if _, ok := entity.UserFieldPaths[path]; !ok {
+			return grpcerror.NewInvalidFieldMaskPathError(path)
+		}
+	}
+	return nil
+}
+
+// normalizeUpdateMask validates paths against the User model, expands the
+// wildcard and drops duplicates.
+func normalizeUpdateMask(paths []string) ([]string, error) {
+	normalized := []string{}
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == constant.FieldMaskWildcard {
+			return slices.Clone(constant.UpdatableUserFields), nil
+		}
+		if _, ok := entity.UserFieldPaths[path]; !ok {
+			return nil, grpcerror.NewInvalidFieldMaskPathError(path)
+		}
+		if !slices.Contains(constant.UpdatableUserFields, path) {
+			return nil, grpcerror.NewFieldNotUpdatableError(path)
+		}
+		if !slices.Contains(normalized, path) {
+			normalized = append(normalized, path)
+		}
+	}
+	return normalized, nil
+}
+
+// updateMaskPaths returns the fields UpdateUser should write: the update mask
+// when one is given, otherwise the fields set on the request.
+func updateMaskPaths(req *dto.UpdateUserRequest) ([]string, error) {
+	if len(req.UpdateMask) > 0 {
+		return normalizeUpdateMask(req.UpdateMask)
+	}
+
+	paths := []string{}
+	if req.Email != nil {
+		paths = append(paths, "email")
+	}
+	if req.FirstName != nil {
+		paths = append(paths, "first_name")
+	}
+	if req.LastName != nil {
+		paths = append(paths, "last_name")
+	}
+	if req.Phone != nil {
+		paths = append(paths, "phone")
+	}
+	if req.Locale != nil {
+		paths = append(paths, "locale")
+	}
+	if req.Timezone != nil {
+		paths = append(paths, "timezone")
+	}
+	if req.AvatarRef != nil {
+		paths = append(paths, "avatar_ref")
+	}
+	if req.Attributes != nil {
+		paths = append(paths, "attributes")
+	}
+	if req.Username != nil {
+		paths = append(paths, "username")
+	}
+	return paths, nil
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+// getUserAsOf reads the revision that was current at req.AsOf. A user who
+// did not exist yet, or was deleted at the time, is not found. PII is
+// cleared unless the caller holds constant.PermissionReadPII, as in
+// ListUserRevisions.
+func (u *userUseCaseImpl) getUserAsOf(ctx context.Context, req *dto.GetUserRequest) (*dto.GetUserResponse, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+	if claims.IsImpersonation() || !claims.HasPermission(constant.PermissionReadHistory) {
+		return nil, grpcerror.NewPermissionDeniedError()
+	}
+
+	var revision *entity.UserRevision
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		revision, err = ds.UserHistoryRepository().GetAsOf(ctx, req.ID, req.AsOf.UTC())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if revision == nil || revision.User.DeletedAt != nil {
+		return nil, grpcerror.NewUserNotFoundError()
+	}
+
+	res := dto.ToGetUserResponse(revision.User)
+	if !claims.HasPermission(constant.PermissionReadPII) {
+		dto.RedactPII(res)
+	}
+	return dto.MaskUserResponse(res, req.ReadMask), nil
+}
+
+func (u *userUseCaseImpl) ListUserRevisions(ctx context.Context, req *dto.ListUserRevisionsRequest) (*dto.ListUserRevisionsResponse, error) {
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = constant.DefaultPageSize
+	}
+	if pageSize > constant.MaxPageSize {
+		pageSize = constant.MaxPageSize
+	}
+
+	var beforeVersion int64
+	if req.PageToken != "" {
+		version, err := decodeRevisionToken(req.PageToken)
+		if err != nil {
+			return nil, grpcerror.NewInvalidPageTokenError()
+		}
+		beforeVersion = version
+	}
+
+	// One revision past the page both signals another page and is the base
+	// the last revision on this one is diffed against.
+	var revisions []*entity.UserRevision
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		revisions, err = ds.UserHistoryRepository().ListRevisions(ctx, req.ID, beforeVersion, pageSize+1)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 && beforeVersion == 0 {
+		return nil, grpcerror.NewUserNotFoundError()
+	}
+
+	var redact []string
+	if !req.IncludePII {
+		redact = constant.AuditRedactedUserFields
+	}
+
+	res := &dto.ListUserRevisionsResponse{
+		Revisions: make([]*dto.UserRevision, 0, min(len(revisions), pageSize)),
+	}
+	for i, revision := range revisions {
+		if i == pageSize {
+			res.NextPageToken = encodeRevisionToken(revisions[i-1].User.Version)
+			break
+		}
+
+		var previous *entity.User
+		if i+1 < len(revisions) {
+			previous = revisions[i+1].User
+		}
+		changes, err := audit.Diff(previous, revision.User, redact...)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Revisions = append(res.Revisions, &dto.UserRevision{
+			Version:   revision.User.Version,
+			ValidFrom: revision.ValidFrom,
+			ValidTo:   revision.ValidTo,
+			Changes:   changes,
+		})
+	}
+
+	return res, nil
+}
+
+func encodeRevisionToken(version int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(version, 10)))
+}
+
+func decodeRevisionToken(token string) (int64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(data), 10, 64)
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+type historyDataStore struct {
+	repository.DataStore
+
+	history *historyRepository
+}
+
+func (s *historyDataStore) Atomic(ctx context.Context, fn func(repository.DataStore) error) error {
+	return fn(s)
+}
+
+func (s *historyDataStore) UserHistoryRepository() repository.UserHistoryRepository {
+	return s.history
+}
+
+type historyRepository struct {
+	repository.UserHistoryRepository
+
+	revision *entity.UserRevision
+}
+
+func (r *historyRepository) GetAsOf(ctx context.Context, userID string, at time.Time) (*entity.UserRevision, error) {
+	return r.revision, nil
+}
+
+func TestGetUserAsOfRedactsPIIWithoutPermission(t *testing.T) {
+	user := &entity.User{ID: uuid.NewString(), Email: "ada@example.com", FirstName: "Ada", Phone: "+15550100", StatusReason: "fraud review"}
+	usecase := &userUseCaseImpl{dataStore: &historyDataStore{history: &historyRepository{revision: &entity.UserRevision{User: user}}}}
+	asOf := time.Now().Add(-time.Hour)
+
+	tests := map[string]struct {
+		permissions []string
+		wantPII     bool
+	}{
+		"history only":         {permissions: []string{constant.PermissionReadHistory}},
+		"history and read_pii": {permissions: []string{constant.PermissionReadHistory, constant.PermissionReadPII}, wantPII: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Reading one's own history grants nothing extra.
+			ctx := interceptor.ContextWithClaims(context.Background(), &jwtutils.JWTClaims{UserID: user.ID, TokenType: "access", Permissions: tt.permissions})
+			res, err := usecase.GetUser(ctx, &dto.GetUserRequest{ID: user.ID, AsOf: &asOf})
+			if err != nil {
+				t.Fatalf("GetUser as of: %v", err)
+			}
+			if hasPII := res.Email != "" || res.Phone != "" || res.StatusReason != ""; hasPII != tt.wantPII {
+				t.Errorf("GetUser as of = %+v, want PII %v", res, tt.wantPII)
+			}
+			if res.FirstName != "Ada" {
+				t.Errorf("FirstName = %q, want Ada", res.FirstName)
+			}
+		})
+	}
+}
@@ -0,0 +1,505 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/jsonschema"
+	"github.com/hailsayan/achilles/internal/pkg/quota"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+	"google.golang.org/grpc/status"
+)
+
+// errImportDryRun rolls back a dry-run batch once its outcome is known.
+var errImportDryRun = errors.New("import dry run")
+
+// ImportUsers reads users from req.Content and creates those that pass the
+// same checks as CreateUser. Rows are validated as they arrive and inserted
+// in batches, each in its own transaction. A row that fails is reported and
+// skipped. An error that stops the import leaves the earlier batches in
+// place; importing the same file again reports their rows as existing,
+// unless it resumes from the last checkpoint.
+func (u *userUseCaseImpl) ImportUsers(ctx context.Context, req *dto.ImportUsersRequest) (*dto.ImportUsersResponse, error) {
+	rows, err := newImportRowReader(req.Format, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema *jsonschema.Schema
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		schema, err = loadAttributeSchema(ctx, ds)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	importer := &userImporter{
+		useCase:    u,
+		dryRun:     req.DryRun,
+		operation:  req.Operation,
+		schema:     schema,
+		emails:     map[string]int64{},
+		usernames:  map[string]int64{},
+		checkpoint: req.Checkpoint,
+		res:        &dto.ImportUsersResponse{DryRun: req.DryRun},
+	}
+	var resumeLine int64
+	if req.Resume != nil && req.Resume.Result != nil {
+		resumeLine = req.Resume.Line
+		res := *req.Resume.Result
+		res.Errors = slices.Clone(res.Errors)
+		importer.res = &res
+	}
+
+	var line int64
+	for {
+		row, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line = row.line
+
+		// Rows settled before the import was interrupted are already
+		// counted; they are only replayed so that later repeats of their
+		// email or username are still caught.
+		if row.line <= resumeLine {
+			importer.replay(row)
+			continue
+		}
+
+		importer.res.Rows++
+		if err := importer.add(row); err != nil {
+			importer.fail(row.line, row.email(), err)
+			continue
+		}
+		if len(importer.batch) >= constant.ImportBatchSize {
+			if err := importer.flush(ctx, line); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := importer.flush(ctx, line); err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(importer.res.Errors, func(a, b *dto.ImportRowError) int {
+		return cmp.Compare(a.Line, b.Line)
+	})
+	return importer.res, nil
+}
+
+// userImporter holds the state of one import. Emails and usernames are
+// remembered for the whole import, so a repeat is caught even when the
+// first occurrence is in an earlier batch.
+type userImporter struct {
+	useCase    *userUseCaseImpl
+	dryRun     bool
+	operation  string
+	schema     *jsonschema.Schema
+	emails     map[string]int64
+	usernames  map[string]int64
+	batch      []*importedUser
+	checkpoint func(checkpoint *dto.ImportCheckpoint) error
+	res        *dto.ImportUsersResponse
+}
+
+type importedUser struct {
+	line int64
+	user *entity.User
+}
+
+// add validates row and queues it for the next batch.
+func (i *userImporter) add(row *importRow) error {
+	if row.err != nil {
+		return row.err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(row.user.Email))
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return grpcerror.NewInvalidEmailError()
+	}
+	if firstLine, ok := i.emails[email]; ok {
+		return grpcerror.NewDuplicateImportEmailError(firstLine)
+	}
+
+	phone, err := normalizePhone(row.user.Phone)
+	if err != nil {
+		return err
+	}
+	locale, err := normalizeLocale(row.user.Locale)
+	if err != nil {
+		return err
+	}
+	timezone, err := normalizeTimezone(row.user.Timezone)
+	if err != nil {
+		return err
+	}
+	if err := checkAttributes(i.schema, row.user.Attributes); err != nil {
+		return err
+	}
+	attributes := entity.Attributes(row.user.Attributes)
+	if attributes == nil {
+		attributes = entity.Attributes{}
+	}
+
+	now := time.Now().UTC()
+	var username string
+	var usernameChangedAt *time.Time
+	if strings.TrimSpace(row.user.Username) != "" {
+		username, err = normalizeUsername(row.user.Username)
+		if err != nil {
+			return err
+		}
+		if firstLine, ok := i.usernames[strings.ToLower(username)]; ok {
+			return grpcerror.NewDuplicateImportUsernameError(firstLine)
+		}
+		usernameChangedAt = &now
+		i.usernames[strings.ToLower(username)] = row.line
+	}
+	i.emails[email] = row.line
+
+	i.batch = append(i.batch, &importedUser{
+		line: row.line,
+		user: &entity.User{
+			ID:         uuid.New().String(),
+			Email:      email,
+			FirstName:  strings.TrimSpace(row.user.FirstName),
+			LastName:   strings.TrimSpace(row.user.LastName),
+			Status:     constant.UserStatusActive,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			Version:    1,
+			Phone:      phone,
+			Locale:     locale,
+			Timezone:   timezone,
+			Attributes: attributes,
+
+			Username:          username,
+			UsernameChangedAt: usernameChangedAt,
+		},
+	})
+	return nil
+}
+
+// replay remembers the email and username of a row settled by an earlier
+// run of the import without queueing it again.
+func (i *userImporter) replay(row *importRow) {
+	queued := len(i.batch)
+	if i.add(row) == nil {
+		i.batch = i.batch[:queued]
+	}
+}
+
+// flush inserts the queued users in one transaction. Users that clash with
+// existing ones, or that would take the tenant over its user limit, are
+// reported and left out. In a dry run the transaction is rolled back after
+// the outcome is known. Every row up to line is settled afterwards, which is
+// reported to the checkpoint function.
+func (i *userImporter) flush(ctx context.Context, line int64) error {
+	if len(i.batch) == 0 {
+		return i.saveCheckpoint(line)
+	}
+
+	users := make([]*entity.User, 0, len(i.batch))
+	byID := make(map[string]*importedUser, len(i.batch))
+	for _, imported := range i.batch {
+		users = append(users, imported.user)
+		byID[imported.user.ID] = imported
+	}
+
+	now := time.Now().UTC()
+	limit := i.useCase.quotas.Limits.For(tenant.FromContext(ctx)).MaxUsers
+	var emailConflicts, usernameConflicts, overLimit, inserted []string
+	err := i.useCase.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		importRepository := ds.UserImportRepository()
+
+		if err := importRepository.Stage(ctx, users); err != nil {
+			return err
+		}
+
+		var err error
+		emailConflicts, err = importRepository.RejectEmailConflicts(ctx, i.useCase.deletion.EmailPolicy == constant.EmailPolicyReserve)
+		if err != nil {
+			return err
+		}
+		usernameConflicts, err = importRepository.RejectUsernameConflicts(ctx, now)
+		if err != nil {
+			return err
+		}
+
+		if limit > 0 {
+			usageRepository := ds.UsageRepository()
+			if err := usageRepository.LockTenant(ctx); err != nil {
+				return err
+			}
+			live, err := usageRepository.CountLiveUsers(ctx)
+			if err != nil {
+				return err
+			}
+			// Earlier dry-run batches were rolled back, so their users
+			// are not in the live count yet.
+			remaining := limit - live
+			if i.dryRun {
+				remaining -= i.res.Imported
+			}
+			overLimit, err = importRepository.RejectBeyond(ctx, max(remaining, 0))
+			if err != nil {
+				return err
+			}
+		}
+
+		inserted, err = importRepository.Insert(ctx, now)
+		if err != nil {
+			return err
+		}
+		for _, id := range inserted {
+			metadata := map[string]any{"source": "import"}
+			if i.operation != "" {
+				metadata["operation"] = i.operation
+			}
+			if err := recordAudit(ctx, ds, constant.AuditOperationCreateUser, id, nil, byID[id].user, metadata); err != nil {
+				return err
+			}
+		}
+
+		if i.dryRun {
+			return errImportDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportDryRun) {
+		return err
+	}
+
+	i.res.Imported += int64(len(inserted))
+	for _, id := range emailConflicts {
+		i.fail(byID[id].line, byID[id].user.Email, grpcerror.NewEmailExistsError())
+	}
+	for _, id := range usernameConflicts {
+		i.fail(byID[id].line, byID[id].user.Email, grpcerror.NewUsernameExistsError())
+	}
+	for _, id := range overLimit {
+		i.fail(byID[id].line, byID[id].user.Email, grpcerror.NewQuotaExceededError(quota.MetricUsers, limit))
+	}
+
+	i.batch = i.batch[:0]
+	return i.saveCheckpoint(line)
+}
+
+func (i *userImporter) saveCheckpoint(line int64) error {
+	if i.checkpoint == nil || line == 0 {
+		return nil
+	}
+	return i.checkpoint(&dto.ImportCheckpoint{Line: line, Result: i.res})
+}
+
+func (i *userImporter) fail(line int64, email string, err error) {
+	i.res.Failed++
+	if len(i.res.Errors) >= constant.MaxImportRowErrors {
+		i.res.ErrorsTruncated = true
+		return
+	}
+
+	// Errors that are not gRPC statuses are not shown to the caller.
+	st, ok := status.FromError(err)
+	if !ok {
+		st, _ = status.FromError(grpcerror.NewInternalError())
+	}
+	i.res.Errors = append(i.res.Errors, &dto.ImportRowError{
+		Line:    line,
+		Email:   email,
+		Code:    st.Code(),
+		Message: st.Message(),
+	})
+}
+
+// importRow is one decoded row. A row that could not be decoded has err set
+// and no user.
+type importRow struct {
+	line int64
+	user *dto.ImportUserRow
+	err  error
+}
+
+func (r *importRow) email() string {
+	if r.user == nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(r.user.Email))
+}
+
+// importRowReader yields rows until it returns io.EOF. Any other error ends
+// the import.
+type importRowReader interface {
+	Next() (*importRow, error)
+}
+
+func newImportRowReader(format string, content io.Reader) (importRowReader, error) {
+	switch format {
+	case constant.ImportFormatCSV:
+		return newCSVRowReader(content)
+	case constant.ImportFormatNDJSON:
+		return newNDJSONRowReader(content), nil
+	default:
+		return nil, grpcerror.NewInvalidImportFormatError()
+	}
+}
+
+// csvRowReader reads rows under a header line naming constant.ImportColumns
+// in any order. Attributes are given as a JSON object.
+type csvRowReader struct {
+	reader  *csv.Reader
+	columns []string
+}
+
+func newCSVRowReader(content io.Reader) (*csvRowReader, error) {
+	reader := csv.NewReader(content)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, grpcerror.NewInvalidImportHeaderError("missing")
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, grpcerror.NewInvalidImportHeaderError(parseErr.Err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, 0, len(header))
+	for _, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(constant.ImportColumns, name) {
+			return nil, grpcerror.NewInvalidImportHeaderError(fmt.Sprintf("unknown column %q", name))
+		}
+		if slices.Contains(columns, name) {
+			return nil, grpcerror.NewInvalidImportHeaderError(fmt.Sprintf("repeated column %q", name))
+		}
+		columns = append(columns, name)
+	}
+	if !slices.Contains(columns, "email") {
+		return nil, grpcerror.NewInvalidImportHeaderError(`missing column "email"`)
+	}
+
+	return &csvRowReader{
+		reader:  reader,
+		columns: columns,
+	}, nil
+}
+
+func (r *csvRowReader) Next() (*importRow, error) {
+	record, err := r.reader.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &importRow{
+			line: int64(parseErr.StartLine),
+			err:  grpcerror.NewInvalidImportRowError(parseErr.Err.Error()),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	row := &importRow{
+		line: int64(line),
+		user: &dto.ImportUserRow{},
+	}
+	for i, value := range record {
+		switch r.columns[i] {
+		case "email":
+			row.user.Email = value
+		case "first_name":
+			row.user.FirstName = value
+		case "last_name":
+			row.user.LastName = value
+		case "phone":
+			row.user.Phone = value
+		case "locale":
+			row.user.Locale = value
+		case "timezone":
+			row.user.Timezone = value
+		case "username":
+			row.user.Username = value
+		case "attributes":
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			if err := json.Unmarshal([]byte(value), &row.user.Attributes); err != nil {
+				row.err = grpcerror.NewInvalidImportRowError("attributes must be a JSON object")
+			}
+		}
+	}
+	return row, nil
+}
+
+// ndjsonRowReader reads one JSON object per line, keyed by
+// constant.ImportColumns. Blank lines are skipped.
+type ndjsonRowReader struct {
+	scanner *bufio.Scanner
+	line    int64
+}
+
+func newNDJSONRowReader(content io.Reader) *ndjsonRowReader {
+	scanner := bufio.NewScanner(content)
+	scanner.Buffer(make([]byte, 0, 64*1024), constant.MaxImportLineBytes)
+	return &ndjsonRowReader{
+		scanner: scanner,
+	}
+}
+
+func (r *ndjsonRowReader) Next() (*importRow, error) {
+	for r.scanner.Scan() {
+		r.line++
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := &importRow{
+			line: r.line,
+			user: &dto.ImportUserRow{},
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(row.user); err != nil {
+			return &importRow{line: r.line, err: grpcerror.NewInvalidImportRowError(err.Error())}, nil
+		}
+		if decoder.More() {
+			return &importRow{line: r.line, err: grpcerror.NewInvalidImportRowError("more than one value on the line")}, nil
+		}
+		return row, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, grpcerror.NewImportLineTooLongError(r.line+1, constant.MaxImportLineBytes)
+		}
+		return nil, err
+	}
+	return nil, io.EOF
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// listDataStore serves a fixed page of users to members of one
+// organization. Repositories the tests do not use are nil.
+type listDataStore struct {
+	repository.DataStore
+
+	users       *listUserRepository
+	memberships *listMembershipRepository
+}
+
+func (s *listDataStore) Atomic(ctx context.Context, fn func(repository.DataStore) error) error {
+	return fn(s)
+}
+
+func (s *listDataStore) UserRepository() repository.UserRepository {
+	return s.users
+}
+
+func (s *listDataStore) MembershipRepository() repository.MembershipRepository {
+	return s.memberships
+}
+
+type listUserRepository struct {
+	repository.UserRepository
+
+	users []*entity.User
+}
+
+func (r *listUserRepository) ListUsers(ctx context.Context, params *repository.ListUsersParams) ([]*entity.User, error) {
+	return r.users, nil
+}
+
+type listMembershipRepository struct {
+	repository.MembershipRepository
+
+	member string
+}
+
+func (r *listMembershipRepository) Get(ctx context.Context, organizationID, userID string) (*entity.Membership, error) {
+	if userID != r.member {
+		return nil, nil
+	}
+	return &entity.Membership{OrganizationID: organizationID, UserID: userID, Role: constant.OrganizationRoleMember}, nil
+}
+
+func newListFixture() (*userUseCaseImpl, context.Context) {
+	callerID := uuid.NewString()
+	dataStore := &listDataStore{
+		users: &listUserRepository{users: []*entity.User{{
+			ID:         uuid.NewString(),
+			Email:      "ada@example.com",
+			FirstName:  "Ada",
+			Phone:      "+15550100",
+			Attributes: entity.Attributes{"employee_id": "42"},
+		}}},
+		memberships: &listMembershipRepository{member: callerID},
+	}
+	ctx := interceptor.ContextWithClaims(context.Background(), &jwtutils.JWTClaims{UserID: callerID, OrgID: "org-1", TokenType: "access"})
+	return &userUseCaseImpl{dataStore: dataStore}, ctx
+}
+
+func TestListUsersRedactsPIIInOrganizationListings(t *testing.T) {
+	usecase, ctx := newListFixture()
+
+	res, err := usecase.ListUsers(ctx, &dto.ListUsersRequest{})
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	user := res.Users[0]
+	if user.Email != "" || user.Phone != "" || user.Attributes != nil {
+		t.Errorf("ListUsers without PII = %+v, want email, phone and attributes cleared", user)
+	}
+	if user.FirstName != "Ada" {
+		t.Errorf("FirstName = %q, want Ada", user.FirstName)
+	}
+
+	res, err = usecase.ListUsers(ctx, &dto.ListUsersRequest{IncludePII: true})
+	if err != nil {
+		t.Fatalf("ListUsers with PII: %v", err)
+	}
+	if user := res.Users[0]; user.Email != "ada@example.com" || user.Phone == "" || user.Attributes == nil {
+		t.Errorf("ListUsers with PII = %+v, want email, phone and attributes", user)
+	}
+
+	for _, req := range []*dto.ListUsersRequest{{EmailPrefix: "ada"}, {OrderBy: "email"}} {
+		if _, err := usecase.ListUsers(ctx, req); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("ListUsers(%+v) without PII: %v, want PermissionDenied", req, err)
+		}
+	}
+}
+
+func TestListUsersRejectsMalformedCursor(t *testing.T) {
+	usecase, ctx := newListFixture()
+
+	token := func(cursor any) string {
+		data, _ := json.Marshal(cursor)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+
+	tests := map[string]string{
+		"id not a uuid":      token(map[string]any{"o": "created_at", "v": now, "i": "1 OR 1=1", "g": "org-1"}),
+		"value not a time":   token(map[string]any{"o": "created_at", "v": "yesterday", "i": uuid.NewString(), "g": "org-1"}),
+		"value not a string": token(map[string]any{"o": "created_at", "v": 42, "i": uuid.NewString(), "g": "org-1"}),
+		"not base64":         "%%%",
+	}
+	for name, pageToken := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := usecase.ListUsers(ctx, &dto.ListUsersRequest{PageToken: pageToken})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("ListUsers: %v, want InvalidArgument", err)
+			}
+		})
+	}
+
+	valid := token(map[string]any{"o": "created_at", "v": now, "i": uuid.NewString(), "g": "org-1"})
+	if _, err := usecase.ListUsers(ctx, &dto.ListUsersRequest{PageToken: valid}); err != nil {
+		t.Fatalf("ListUsers with a valid cursor: %v", err)
+	}
+}
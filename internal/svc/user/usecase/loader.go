@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type userFetchFunc func(ctx context.Context, ids []string) (map[string]*entity.User, error)
+
+// userLoader coalesces concurrent lookups in the style of a dataloader: ids
+// requested while a batch is open join it, and the whole batch is resolved
+// with one fetch. Ids already in the open batch are not fetched twice. Each
+// tenant has its own open batch, since a fetch reads a single tenant.
+type userLoader struct {
+	fetch    userFetchFunc
+	wait     time.Duration
+	maxBatch int
+	timeout  time.Duration
+
+	mu      sync.Mutex
+	batches map[string]*userBatch
+}
+
+type userBatch struct {
+	tenant string
+	ctx    context.Context
+	ids    []string
+	seen   map[string]struct{}
+	timer  *time.Timer
+	done   chan struct{}
+
+	users map[string]*entity.User
+	err   error
+}
+
+func newUserLoader(fetch userFetchFunc, wait time.Duration, maxBatch int, timeout time.Duration) *userLoader {
+	return &userLoader{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		timeout:  timeout,
+		batches:  map[string]*userBatch{},
+	}
+}
+
+// LoadMany returns the users found for ids. Missing ids are absent from the
+// result rather than reported as an error, and so are ids that are not
+// UUIDs: they cannot name a user, and one of them would fail the whole
+// batch's query for every caller in it.
+func (l *userLoader) LoadMany(ctx context.Context, ids []string) (map[string]*entity.User, error) {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err == nil {
+			valid = append(valid, id)
+		}
+	}
+	if len(valid) == 0 {
+		return map[string]*entity.User{}, nil
+	}
+	ids = valid
+
+	batch := l.enqueue(ctx, ids)
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if batch.err != nil {
+		return nil, batch.err
+	}
+
+	users := make(map[string]*entity.User, len(ids))
+	for _, id := range ids {
+		if user, ok := batch.users[id]; ok {
+			users[id] = user
+		}
+	}
+	return users, nil
+}
+
+func (l *userLoader) Load(ctx context.Context, id string) (*entity.User, error) {
+	users, err := l.LoadMany(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	return users[id], nil
+}
+
+func (l *userLoader) enqueue(ctx context.Context, ids []string) *userBatch {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tenantID := tenant.FromContext(ctx)
+	batch := l.batches[tenantID]
+	if batch == nil {
+		// The fetch serves every caller in the batch and outlives each of
+		// them, so it runs on a fresh context that carries only the tenant
+		// rather than anything of the first caller's.
+		batch = &userBatch{
+			tenant: tenantID,
+			ctx:    tenant.WithTenant(context.Background(), tenantID),
+			seen:   map[string]struct{}{},
+			done:   make(chan struct{}),
+		}
+		batch.timer = time.AfterFunc(l.wait, func() { l.dispatch(batch) })
+		l.batches[tenantID] = batch
+	}
+
+	for _, id := range ids {
+		if _, ok := batch.seen[id]; ok {
+			continue
+		}
+		batch.seen[id] = struct{}{}
+		batch.ids = append(batch.ids, id)
+	}
+
+	if len(batch.ids) >= l.maxBatch {
+		batch.timer.Stop()
+		delete(l.batches, tenantID)
+		go l.run(batch)
+	}
+
+	return batch
+}
+
+func (l *userLoader) dispatch(batch *userBatch) {
+	l.mu.Lock()
+	if l.batches[batch.tenant] != batch {
+		l.mu.Unlock()
+		return
+	}
+	delete(l.batches, batch.tenant)
+	l.mu.Unlock()
+
+	l.run(batch)
+}
+
+func (l *userLoader) run(batch *userBatch) {
+	ctx, cancel := context.WithTimeout(batch.ctx, l.timeout)
+	defer cancel()
+
+	batch.users, batch.err = l.fetch(ctx, batch.ids)
+	close(batch.done)
+}
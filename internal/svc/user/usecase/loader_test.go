@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+)
+
+type callerKey struct{}
+
+func TestUserLoader(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		fetched [][]string
+	)
+	loader := newUserLoader(func(ctx context.Context, ids []string) (map[string]*entity.User, error) {
+		mu.Lock()
+		fetched = append(fetched, ids)
+		mu.Unlock()
+
+		if ctx.Value(callerKey{}) != nil {
+			t.Error("fetch context carries a value of the caller's")
+		}
+		if got := tenant.FromContext(ctx); got != "acme" {
+			t.Errorf("fetch tenant = %q, want %q", got, "acme")
+		}
+
+		users := map[string]*entity.User{}
+		for _, id := range ids {
+			users[id] = &entity.User{ID: id}
+		}
+		return users, nil
+	}, 10*time.Millisecond, 100, time.Second)
+
+	ctx := tenant.WithTenant(context.WithValue(context.Background(), callerKey{}, "first"), "acme")
+
+	t.Run("invalid ids are missing", func(t *testing.T) {
+		id := uuid.NewString()
+
+		users, err := loader.LoadMany(ctx, []string{id, "not-a-uuid", "'; DROP TABLE users; --"})
+		if err != nil {
+			t.Fatalf("LoadMany: %v", err)
+		}
+		if len(users) != 1 || users[id] == nil {
+			t.Fatalf("LoadMany = %v, want only %s", users, id)
+		}
+		if len(fetched) != 1 || len(fetched[0]) != 1 || fetched[0][0] != id {
+			t.Errorf("fetched %v, want only [%s]", fetched, id)
+		}
+	})
+
+	t.Run("only invalid ids", func(t *testing.T) {
+		fetched = nil
+
+		users, err := loader.LoadMany(ctx, []string{"not-a-uuid"})
+		if err != nil {
+			t.Fatalf("LoadMany: %v", err)
+		}
+		if len(users) != 0 || len(fetched) != 0 {
+			t.Errorf("LoadMany = %v after fetching %v, want nothing", users, fetched)
+		}
+	})
+
+	t.Run("first caller cancelling", func(t *testing.T) {
+		first, cancel := context.WithCancel(ctx)
+		second := uuid.NewString()
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loader.LoadMany(first, []string{uuid.NewString()})
+		}()
+		time.Sleep(time.Millisecond)
+		cancel()
+
+		users, err := loader.LoadMany(ctx, []string{second})
+		wg.Wait()
+		if err != nil {
+			t.Fatalf("LoadMany: %v", err)
+		}
+		if users[second] == nil {
+			t.Errorf("LoadMany = %v, want %s", users, second)
+		}
+	})
+}
@@ -0,0 +1,622 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/blob"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+	"google.golang.org/grpc/status"
+)
+
+// OperationConfig controls background operations. Uploads keeps the input
+// of import operations until they end; unlike the avatar store it must not
+// be served over HTTP.
+type OperationConfig struct {
+	Uploads blob.BlobStore
+}
+
+var (
+	// errOperationCancelled stops a job whose cancellation was requested.
+	errOperationCancelled = errors.New("operation cancelled")
+	// errOperationLeaseLost stops a job that another worker has taken over.
+	errOperationLeaseLost = errors.New("operation lease lost")
+)
+
+// operationJob runs one type of operation. report stores progress, which a
+// later attempt finds in op.Progress, and fails once the job should stop.
+type operationJob func(u *userUseCaseImpl, ctx context.Context, op *entity.Operation, report func(progress any) error) (any, error)
+
+var operationJobs = map[string]operationJob{
+	constant.OperationTypePurgeDeletedUsers: (*userUseCaseImpl).runPurgeOperation,
+	constant.OperationTypeImportUsers:       (*userUseCaseImpl).runImportOperation,
+	constant.OperationTypeExportUserData:    (*userUseCaseImpl).runExportOperation,
+}
+
+// operationCursor is serialized into the ListOperations page token.
+type operationCursor struct {
+	CreatedAt string `json:"c"`
+	ID        string `json:"i"`
+}
+
+type purgeProgress struct {
+	Purged int `json:"purged"`
+}
+
+type importOperationParams struct {
+	Format    string `json:"format"`
+	DryRun    bool   `json:"dry_run"`
+	UploadKey string `json:"upload_key"`
+	ActorID   string `json:"actor_id"`
+}
+
+type exportOperationParams struct {
+	UserID string `json:"user_id"`
+	Format string `json:"format"`
+}
+
+// CreateOperation queues an operation of one of the
+// constant.CreatableOperationTypes, each of which needs its own permission.
+func (u *userUseCaseImpl) CreateOperation(ctx context.Context, req *dto.CreateOperationRequest) (*dto.OperationResponse, error) {
+	permission, ok := constant.CreatableOperationTypes[req.Type]
+	if !ok {
+		return nil, grpcerror.NewInvalidOperationTypeError(req.Type)
+	}
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+	if claims.IsImpersonation() || !claims.HasPermission(permission) {
+		return nil, grpcerror.NewPermissionDeniedError()
+	}
+
+	return u.createOperation(ctx, req.Type, req.ActorID, nil)
+}
+
+// StartImportUsers stores the upload and queues an import of it. The result
+// of the operation is the ImportUsersResponse ImportUsers would return.
+func (u *userUseCaseImpl) StartImportUsers(ctx context.Context, req *dto.StartImportUsersRequest) (*dto.OperationResponse, error) {
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format != constant.ImportFormatCSV && format != constant.ImportFormatNDJSON {
+		return nil, grpcerror.NewInvalidImportFormatError()
+	}
+
+	data, err := io.ReadAll(io.LimitReader(req.Content, constant.MaxImportUploadBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > constant.MaxImportUploadBytes {
+		return nil, grpcerror.NewImportUploadTooLargeError(constant.MaxImportUploadBytes)
+	}
+
+	key := fmt.Sprintf(constant.ImportUploadKeyFormat, tenant.FromContext(ctx), uuid.New().String())
+	if err := u.operations.Uploads.Put(ctx, key, "application/octet-stream", data); err != nil {
+		return nil, err
+	}
+
+	params, err := json.Marshal(&importOperationParams{
+		Format:    format,
+		DryRun:    req.DryRun,
+		UploadKey: key,
+		ActorID:   req.ActorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.createOperation(ctx, constant.OperationTypeImportUsers, req.ActorID, params)
+	if err != nil {
+		u.operations.Uploads.DeletePrefix(context.WithoutCancel(ctx), key)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (u *userUseCaseImpl) createOperation(ctx context.Context, operationType, createdBy string, params json.RawMessage) (*dto.OperationResponse, error) {
+	now := time.Now().UTC()
+	operation := &entity.Operation{
+		ID:        uuid.New().String(),
+		Type:      operationType,
+		State:     constant.OperationStatePending,
+		Params:    params,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		return ds.OperationRepository().Create(ctx, operation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToOperationResponse(operation), nil
+}
+
+// GetOperation returns an erasure request or a background operation by
+// name.
+func (u *userUseCaseImpl) GetOperation(ctx context.Context, req *dto.GetOperationRequest) (*dto.OperationResponse, error) {
+	if strings.HasPrefix(req.Name, constant.ErasureOperationPrefix) {
+		return u.getErasureOperation(ctx, req.Name)
+	}
+
+	operation, err := u.getOperation(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToOperationResponse(operation), nil
+}
+
+func (u *userUseCaseImpl) getOperation(ctx context.Context, name string) (*entity.Operation, error) {
+	id, ok := strings.CutPrefix(name, constant.OperationNamePrefix)
+	if !ok {
+		return nil, grpcerror.NewOperationNotFoundError()
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, grpcerror.NewOperationNotFoundError()
+	}
+
+	var operation *entity.Operation
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		operation, err = ds.OperationRepository().GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if operation == nil {
+		return nil, grpcerror.NewOperationNotFoundError()
+	}
+
+	if err := authorizeOperation(ctx, operation.CreatedBy); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// ListOperations lists background operations, newest first. Callers without
+// constant.PermissionManageOperations only see the ones they created.
+// Erasure requests are not included.
+func (u *userUseCaseImpl) ListOperations(ctx context.Context, req *dto.ListOperationsRequest) (*dto.ListOperationsResponse, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = constant.DefaultPageSize
+	}
+	if pageSize > constant.MaxPageSize {
+		pageSize = constant.MaxPageSize
+	}
+
+	params := &repository.ListOperationsParams{
+		Type:  strings.TrimSpace(req.Type),
+		State: strings.TrimSpace(req.State),
+		Limit: pageSize + 1,
+	}
+	if params.State != "" && !slices.Contains(constant.OperationStates, params.State) {
+		return nil, grpcerror.NewInvalidOperationStateError()
+	}
+	if claims.IsImpersonation() || !claims.HasPermission(constant.PermissionManageOperations) {
+		params.CreatedBy = claims.UserID
+	}
+	if req.PageToken != "" {
+		cursor, err := decodeOperationCursor(req.PageToken)
+		if err != nil {
+			return nil, grpcerror.NewInvalidPageTokenError()
+		}
+		beforeCreatedAt, err := time.Parse(time.RFC3339Nano, cursor.CreatedAt)
+		if err != nil {
+			return nil, grpcerror.NewInvalidPageTokenError()
+		}
+		if _, err := uuid.Parse(cursor.ID); err != nil {
+			return nil, grpcerror.NewInvalidPageTokenError()
+		}
+		params.BeforeCreatedAt = beforeCreatedAt
+		params.BeforeID = cursor.ID
+	}
+
+	var operations []*entity.Operation
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		operations, err = ds.OperationRepository().List(ctx, params)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.ListOperationsResponse{
+		Operations: make([]*dto.OperationResponse, 0, len(operations)),
+	}
+	if len(operations) > pageSize {
+		operations = operations[:pageSize]
+		res.NextPageToken = encodeOperationCursor(operations[len(operations)-1])
+	}
+	for _, operation := range operations {
+		res.Operations = append(res.Operations, dto.ToOperationResponse(operation))
+	}
+	return res, nil
+}
+
+// CancelOperation cancels a pending operation straight away and asks the
+// worker running a running one to stop, which it does at its next heartbeat
+// or progress report. Work done until then is kept. Erasure requests cannot
+// be cancelled.
+func (u *userUseCaseImpl) CancelOperation(ctx context.Context, req *dto.CancelOperationRequest) (*dto.OperationResponse, error) {
+	if strings.HasPrefix(req.Name, constant.ErasureOperationPrefix) {
+		return nil, grpcerror.NewOperationNotCancellableError(req.Name)
+	}
+
+	operation, err := u.getOperation(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		operationRepository := ds.OperationRepository()
+
+		now := time.Now().UTC()
+		cancelled, err := operationRepository.CancelPending(ctx, operation.ID, now)
+		if err != nil {
+			return err
+		}
+		if !cancelled {
+			requested, err := operationRepository.RequestCancel(ctx, operation.ID, now)
+			if err != nil {
+				return err
+			}
+			if !requested {
+				return grpcerror.NewOperationNotCancellableError(req.Name)
+			}
+		}
+
+		operation, err = operationRepository.GetByID(ctx, operation.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToOperationResponse(operation), nil
+}
+
+// WaitOperation returns the operation once it is done or the timeout has
+// passed, whichever comes first. Like GetOperation it accepts erasure
+// requests.
+func (u *userUseCaseImpl) WaitOperation(ctx context.Context, req *dto.WaitOperationRequest) (*dto.OperationResponse, error) {
+	timeout := req.Timeout
+	if timeout <= 0 {
+		timeout = constant.DefaultOperationWaitTimeout
+	}
+	if timeout > constant.MaxOperationWaitTimeout {
+		timeout = constant.MaxOperationWaitTimeout
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(constant.OperationWaitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		res, err := u.GetOperation(ctx, &dto.GetOperationRequest{Name: req.Name})
+		if err != nil || res.Done {
+			return res, err
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+			return res, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunNextOperation claims the oldest runnable operation of any tenant for
+// workerID and runs it to the end. It reports whether there was one to run.
+// Operations left running by a worker that stopped are claimed again once
+// their lease has expired and resume from their last progress report.
+func (u *userUseCaseImpl) RunNextOperation(ctx context.Context, workerID string) (bool, error) {
+	var candidates []*entity.Operation
+	err := u.dataStore.Atomic(tenant.WithTenant(ctx, tenant.All), func(ds repository.DataStore) error {
+		var err error
+		candidates, err = ds.OperationRepository().ListRunnable(ctx, time.Now().UTC(), constant.OperationClaimCandidates)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, candidate := range candidates {
+		tenantCtx := tenant.WithTenant(ctx, candidate.TenantID)
+
+		var operation *entity.Operation
+		err := u.dataStore.Atomic(tenantCtx, func(ds repository.DataStore) error {
+			now := time.Now().UTC()
+
+			var err error
+			operation, err = ds.OperationRepository().Claim(ctx, candidate.ID, workerID, now, now.Add(constant.OperationLeaseDuration))
+			return err
+		})
+		if err != nil {
+			return false, err
+		}
+		if operation == nil {
+			continue
+		}
+
+		return true, u.runOperation(tenantCtx, operation, workerID)
+	}
+	return false, nil
+}
+
+func (u *userUseCaseImpl) runOperation(ctx context.Context, operation *entity.Operation, owner string) error {
+	job, ok := operationJobs[operation.Type]
+	switch {
+	case operation.CancelRequested:
+		return u.finishOperation(ctx, operation, owner, constant.OperationStateCancelled, nil, grpcerror.NewOperationCancelledError())
+	case !ok:
+		return u.finishOperation(ctx, operation, owner, constant.OperationStateFailed, nil, grpcerror.NewInvalidOperationTypeError(operation.Type))
+	case operation.Attempts > constant.MaxOperationAttempts:
+		return u.finishOperation(ctx, operation, owner, constant.OperationStateFailed, nil, grpcerror.NewOperationAbandonedError(constant.MaxOperationAttempts))
+	}
+
+	jobCtx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+
+	run := &operationRun{useCase: u, operation: operation, owner: owner, stop: stop}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		run.keepAlive(jobCtx)
+	}()
+
+	result, err := job(u, jobCtx, operation, func(progress any) error {
+		return run.report(jobCtx, progress)
+	})
+	cause := context.Cause(jobCtx)
+	stop(nil)
+	wg.Wait()
+
+	switch {
+	case ctx.Err() != nil:
+		// The worker is shutting down. Another one takes over from the last
+		// progress report.
+		releaseCtx := context.WithoutCancel(ctx)
+		return u.dataStore.Atomic(releaseCtx, func(ds repository.DataStore) error {
+			return ds.OperationRepository().Release(releaseCtx, operation.ID, owner, time.Now().UTC())
+		})
+	case errors.Is(cause, errOperationLeaseLost):
+		return nil
+	case errors.Is(cause, errOperationCancelled) || errors.Is(err, errOperationCancelled):
+		return u.finishOperation(ctx, operation, owner, constant.OperationStateCancelled, nil, grpcerror.NewOperationCancelledError())
+	case err != nil:
+		return u.finishOperation(ctx, operation, owner, constant.OperationStateFailed, nil, err)
+	default:
+		return u.finishOperation(ctx, operation, owner, constant.OperationStateSucceeded, result, nil)
+	}
+}
+
+// finishOperation stores the outcome and releases what the operation held.
+// Errors that are not gRPC statuses are stored as internal errors.
+func (u *userUseCaseImpl) finishOperation(ctx context.Context, operation *entity.Operation, owner, state string, result any, cause error) error {
+	ctx = context.WithoutCancel(ctx)
+
+	now := time.Now().UTC()
+	operation.State = state
+	operation.UpdatedAt = now
+	operation.FinishedAt = &now
+	if result != nil {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		operation.Result = data
+	}
+	if cause != nil {
+		st, ok := status.FromError(cause)
+		if !ok {
+			st, _ = status.FromError(grpcerror.NewInternalError())
+		}
+		operation.ErrorCode = int(st.Code())
+		operation.ErrorMessage = st.Message()
+	}
+
+	var finished bool
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		finished, err = ds.OperationRepository().Finish(ctx, operation, owner)
+		return err
+	})
+	if err != nil || !finished {
+		return err
+	}
+
+	if operation.Type == constant.OperationTypeImportUsers {
+		var params importOperationParams
+		if err := json.Unmarshal(operation.Params, &params); err != nil {
+			return err
+		}
+		return u.operations.Uploads.DeletePrefix(ctx, params.UploadKey)
+	}
+	return nil
+}
+
+// operationRun holds the lease of a running operation. It stops the job
+// through stop when the operation is cancelled or the lease is lost.
+type operationRun struct {
+	useCase   *userUseCaseImpl
+	operation *entity.Operation
+	owner     string
+	stop      context.CancelCauseFunc
+}
+
+// keepAlive renews the lease until ctx is done. A failed renewal is retried
+// on the next tick; the lease outlasts a couple of them.
+func (r *operationRun) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(constant.OperationHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.heartbeat(ctx, nil)
+		}
+	}
+}
+
+func (r *operationRun) report(ctx context.Context, progress any) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	return r.heartbeat(ctx, data)
+}
+
+func (r *operationRun) heartbeat(ctx context.Context, progress json.RawMessage) error {
+	var held, cancelRequested bool
+	err := r.useCase.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		now := time.Now().UTC()
+
+		var err error
+		held, cancelRequested, err = ds.OperationRepository().Heartbeat(ctx, r.operation.ID, r.owner, progress, now, now.Add(constant.OperationLeaseDuration))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !held:
+		r.stop(errOperationLeaseLost)
+		return errOperationLeaseLost
+	case cancelRequested:
+		r.stop(errOperationCancelled)
+		return errOperationCancelled
+	}
+	return nil
+}
+
+// runPurgeOperation purges the deleted users of the operation's tenant
+// whose grace period has ended. An earlier attempt's count is carried on.
+func (u *userUseCaseImpl) runPurgeOperation(ctx context.Context, operation *entity.Operation, report func(progress any) error) (any, error) {
+	var previous purgeProgress
+	if err := json.Unmarshal(operation.Progress, &previous); err != nil {
+		return nil, err
+	}
+
+	purged, err := u.purgeTenant(ctx, func(purged int) error {
+		return report(&purgeProgress{Purged: previous.Purged + purged})
+	})
+	return &purgeProgress{Purged: previous.Purged + purged}, err
+}
+
+// runImportOperation imports the upload of the operation. Its progress is
+// the import checkpoint, so a later attempt skips the batches already
+// committed.
+func (u *userUseCaseImpl) runImportOperation(ctx context.Context, operation *entity.Operation, report func(progress any) error) (any, error) {
+	var params importOperationParams
+	if err := json.Unmarshal(operation.Params, &params); err != nil {
+		return nil, err
+	}
+	checkpoint := &dto.ImportCheckpoint{}
+	if err := json.Unmarshal(operation.Progress, checkpoint); err != nil {
+		return nil, err
+	}
+
+	upload, err := u.operations.Uploads.Get(ctx, params.UploadKey)
+	if err != nil {
+		return nil, err
+	}
+
+	importReq := &dto.ImportUsersRequest{
+		Format:    params.Format,
+		DryRun:    params.DryRun,
+		Content:   bytes.NewReader(upload.Data),
+		ActorID:   params.ActorID,
+		Operation: constant.OperationNamePrefix + operation.ID,
+		Checkpoint: func(checkpoint *dto.ImportCheckpoint) error {
+			return report(checkpoint)
+		},
+	}
+	if checkpoint.Result != nil {
+		importReq.Resume = checkpoint
+	}
+	return u.ImportUsers(ctx, importReq)
+}
+
+// runExportOperation exports the data of the operation's user. The worker
+// has no caller token to forward, so the auth service is called with the
+// user service's own credential.
+func (u *userUseCaseImpl) runExportOperation(ctx context.Context, operation *entity.Operation, report func(progress any) error) (any, error) {
+	var params exportOperationParams
+	if err := json.Unmarshal(operation.Params, &params); err != nil {
+		return nil, err
+	}
+	return u.exportUserData(ctx, params.UserID, params.Format)
+}
+
+// authorizeOperation lets callers see and cancel the operations they
+// created; other operations need constant.PermissionManageOperations, which
+// impersonation tokens never carry.
+func authorizeOperation(ctx context.Context, createdBy string) error {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return grpcerror.NewUnauthenticatedError()
+	}
+	if createdBy != "" && claims.UserID == createdBy {
+		return nil
+	}
+	if claims.IsImpersonation() || !claims.HasPermission(constant.PermissionManageOperations) {
+		return grpcerror.NewPermissionDeniedError()
+	}
+	return nil
+}
+
+func encodeOperationCursor(operation *entity.Operation) string {
+	data, _ := json.Marshal(operationCursor{
+		CreatedAt: operation.CreatedAt.UTC().Format(time.RFC3339Nano),
+		ID:        operation.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeOperationCursor(token string) (*operationCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := &operationCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
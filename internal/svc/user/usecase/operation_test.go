@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type operationDataStore struct {
+	repository.DataStore
+
+	operations *operationRepository
+}
+
+func (s *operationDataStore) Atomic(ctx context.Context, fn func(repository.DataStore) error) error {
+	return fn(s)
+}
+
+func (s *operationDataStore) OperationRepository() repository.OperationRepository {
+	return s.operations
+}
+
+type operationRepository struct {
+	repository.OperationRepository
+
+	params *repository.ListOperationsParams
+}
+
+func (r *operationRepository) List(ctx context.Context, params *repository.ListOperationsParams) ([]*entity.Operation, error) {
+	r.params = params
+	return nil, nil
+}
+
+func TestListOperationsRejectsMalformedCursor(t *testing.T) {
+	operations := &operationRepository{}
+	usecase := &userUseCaseImpl{dataStore: &operationDataStore{operations: operations}}
+	ctx := interceptor.ContextWithClaims(context.Background(), &jwtutils.JWTClaims{UserID: uuid.NewString(), TokenType: "access"})
+
+	token := func(cursor any) string {
+		data, _ := json.Marshal(cursor)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+
+	tests := map[string]string{
+		"id not a uuid":    token(map[string]any{"c": now, "i": "1 OR 1=1"}),
+		"id missing":       token(map[string]any{"c": now}),
+		"value not a time": token(map[string]any{"c": "yesterday", "i": uuid.NewString()}),
+		"not base64":       "%%%",
+	}
+	for name, pageToken := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := usecase.ListOperations(ctx, &dto.ListOperationsRequest{PageToken: pageToken})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("ListOperations: %v, want InvalidArgument", err)
+			}
+		})
+	}
+
+	id := uuid.NewString()
+	if _, err := usecase.ListOperations(ctx, &dto.ListOperationsRequest{PageToken: token(map[string]any{"c": now, "i": id})}); err != nil {
+		t.Fatalf("ListOperations with a valid cursor: %v", err)
+	}
+	if operations.params.BeforeID != id {
+		t.Errorf("BeforeID = %q, want %q", operations.params.BeforeID, id)
+	}
+}
@@ -0,0 +1,855 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/notify"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+var organizationSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}[a-z0-9]$`)
+
+// OrganizationUseCase manages organizations and who belongs to them. Every
+// method acts on behalf of the caller's token and checks the caller's role
+// in the organization: owners may do anything, admins manage members and
+// invitations, members may look.
+type OrganizationUseCase interface {
+	CreateOrganization(ctx context.Context, req *dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error)
+	GetOrganization(ctx context.Context, req *dto.GetOrganizationRequest) (*dto.OrganizationResponse, error)
+	UpdateOrganization(ctx context.Context, req *dto.UpdateOrganizationRequest) (*dto.OrganizationResponse, error)
+	DeleteOrganization(ctx context.Context, req *dto.DeleteOrganizationRequest) (*dto.DeleteOrganizationResponse, error)
+	ListOrganizations(ctx context.Context) (*dto.ListOrganizationsResponse, error)
+	TransferOwnership(ctx context.Context, req *dto.TransferOwnershipRequest) (*dto.OrganizationResponse, error)
+	GetMembership(ctx context.Context, req *dto.GetMembershipRequest) (*dto.MembershipResponse, error)
+	ListMembers(ctx context.Context, req *dto.ListMembersRequest) (*dto.ListMembersResponse, error)
+	UpdateMemberRole(ctx context.Context, req *dto.UpdateMemberRoleRequest) (*dto.MembershipResponse, error)
+	RemoveMember(ctx context.Context, req *dto.RemoveMemberRequest) (*dto.RemoveMemberResponse, error)
+	InviteMember(ctx context.Context, req *dto.InviteMemberRequest) (*dto.OrganizationInvitationResponse, error)
+	ListOrganizationInvitations(ctx context.Context, req *dto.ListOrganizationInvitationsRequest) (*dto.ListOrganizationInvitationsResponse, error)
+	RevokeOrganizationInvitation(ctx context.Context, req *dto.OrganizationInvitationRequest) (*dto.OrganizationInvitationResponse, error)
+	AcceptOrganizationInvitation(ctx context.Context, req *dto.AcceptOrganizationInvitationRequest) (*dto.MembershipResponse, error)
+}
+
+// InvitationConfig controls how organization invitations are signed and
+// delivered. AcceptURL is the page the invitee lands on; the token is
+// appended as the token query parameter.
+type InvitationConfig struct {
+	Notifier  notify.Notifier
+	Secret    string
+	AcceptURL string
+}
+
+type organizationUseCaseImpl struct {
+	dataStore   repository.DataStore
+	invitations InvitationConfig
+}
+
+func NewOrganizationUseCase(dataStore repository.DataStore, invitations InvitationConfig) (OrganizationUseCase, error) {
+	if len(invitations.Secret) < constant.MinInvitationSecretBytes {
+		return nil, fmt.Errorf("user: invitation secret must be at least %d bytes", constant.MinInvitationSecretBytes)
+	}
+
+	return &organizationUseCaseImpl{
+		dataStore:   dataStore,
+		invitations: invitations,
+	}, nil
+}
+
+// memberCursor is serialized into the ListMembers page token, bound to the
+// organization it was issued for.
+type memberCursor struct {
+	OrganizationID string `json:"g"`
+	CreatedAt      string `json:"c"`
+	UserID         string `json:"u"`
+}
+
+func (u *organizationUseCaseImpl) CreateOrganization(ctx context.Context, req *dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+
+	name, err := normalizeOrganizationName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	slug, err := normalizeOrganizationSlug(req.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	organization := &entity.Organization{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Slug:      slug,
+		OwnerID:   claims.UserID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		if err := checkSlugAvailable(ctx, ds, slug, ""); err != nil {
+			return err
+		}
+
+		if err := ds.OrganizationRepository().Create(ctx, organization); err != nil {
+			return err
+		}
+		if _, err := ds.MembershipRepository().Add(ctx, &entity.Membership{
+			OrganizationID: organization.ID,
+			UserID:         claims.UserID,
+			Role:           constant.OrganizationRoleOwner,
+			CreatedAt:      now,
+		}); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, ds, constant.AuditOperationCreateOrganization, organization.ID, nil, nil, map[string]any{
+			"name": organization.Name,
+			"slug": organization.Slug,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToOrganizationResponse(organization, constant.OrganizationRoleOwner), nil
+}
+
+func (u *organizationUseCaseImpl) GetOrganization(ctx context.Context, req *dto.GetOrganizationRequest) (*dto.OrganizationResponse, error) {
+	res := new(dto.OrganizationResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		membership, err := callerMembership(ctx, ds, req.ID, constant.OrganizationRoleMember)
+		if err != nil {
+			return err
+		}
+
+		organization, err := ds.OrganizationRepository().GetByID(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if organization == nil {
+			return grpcerror.NewOrganizationNotFoundError()
+		}
+
+		res = dto.ToOrganizationResponse(organization, membership.Role)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *organizationUseCaseImpl) UpdateOrganization(ctx context.Context, req *dto.UpdateOrganizationRequest) (*dto.OrganizationResponse, error) {
+	res := new(dto.OrganizationResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		membership, err := callerMembership(ctx, ds, req.ID, constant.OrganizationRoleAdmin)
+		if err != nil {
+			return err
+		}
+
+		organizationRepository := ds.OrganizationRepository()
+		organization, err := organizationRepository.GetByID(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if organization == nil {
+			return grpcerror.NewOrganizationNotFoundError()
+		}
+
+		before := *organization
+		if req.Name != nil {
+			if organization.Name, err = normalizeOrganizationName(*req.Name); err != nil {
+				return err
+			}
+		}
+		if req.Slug != nil {
+			if organization.Slug, err = normalizeOrganizationSlug(*req.Slug); err != nil {
+				return err
+			}
+			if err := checkSlugAvailable(ctx, ds, organization.Slug, organization.ID); err != nil {
+				return err
+			}
+		}
+		organization.UpdatedAt = time.Now().UTC()
+
+		if err := organizationRepository.Update(ctx, organization); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, ds, constant.AuditOperationUpdateOrganization, organization.ID, nil, nil, map[string]any{
+			"before": map[string]string{"name": before.Name, "slug": before.Slug},
+			"after":  map[string]string{"name": organization.Name, "slug": organization.Slug},
+		}); err != nil {
+			return err
+		}
+
+		res = dto.ToOrganizationResponse(organization, membership.Role)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *organizationUseCaseImpl) DeleteOrganization(ctx context.Context, req *dto.DeleteOrganizationRequest) (*dto.DeleteOrganizationResponse, error) {
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		if _, err := callerMembership(ctx, ds, req.ID, constant.OrganizationRoleOwner); err != nil {
+			return err
+		}
+
+		if err := ds.OrganizationRepository().Delete(ctx, req.ID); err != nil {
+			return err
+		}
+		return recordAudit(ctx, ds, constant.AuditOperationDeleteOrganization, req.ID, nil, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.DeleteOrganizationResponse{
+		Success: true,
+		Message: constant.OrganizationDeleted,
+	}, nil
+}
+
+// ListOrganizations returns every organization the caller belongs to, with
+// the caller's role in each.
+func (u *organizationUseCaseImpl) ListOrganizations(ctx context.Context) (*dto.ListOrganizationsResponse, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+
+	var organizations []*entity.UserOrganization
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		organizations, err = ds.OrganizationRepository().ListByUserID(ctx, claims.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.ListOrganizationsResponse{
+		Organizations: make([]*dto.OrganizationResponse, 0, len(organizations)),
+	}
+	for _, organization := range organizations {
+		res.Organizations = append(res.Organizations, dto.ToOrganizationResponse(organization.Organization, organization.Role))
+	}
+	return res, nil
+}
+
+// TransferOwnership hands the organization to another member. The previous
+// owner stays on as an admin.
+func (u *organizationUseCaseImpl) TransferOwnership(ctx context.Context, req *dto.TransferOwnershipRequest) (*dto.OrganizationResponse, error) {
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		owner, err := callerMembership(ctx, ds, req.OrganizationID, constant.OrganizationRoleOwner)
+		if err != nil {
+			return err
+		}
+		if req.NewOwnerID == owner.UserID {
+			return nil
+		}
+
+		membershipRepository := ds.MembershipRepository()
+		newOwner, err := membershipRepository.Get(ctx, req.OrganizationID, req.NewOwnerID)
+		if err != nil {
+			return err
+		}
+		if newOwner == nil {
+			return grpcerror.NewMemberNotFoundError()
+		}
+
+		now := time.Now().UTC()
+		if err := ds.OrganizationRepository().SetOwner(ctx, req.OrganizationID, req.NewOwnerID, now); err != nil {
+			return err
+		}
+		if err := membershipRepository.UpdateRole(ctx, req.OrganizationID, req.NewOwnerID, constant.OrganizationRoleOwner); err != nil {
+			return err
+		}
+		if err := membershipRepository.UpdateRole(ctx, req.OrganizationID, owner.UserID, constant.OrganizationRoleAdmin); err != nil {
+			return err
+		}
+		return recordAudit(ctx, ds, constant.AuditOperationTransferOwnership, req.OrganizationID, nil, nil, map[string]any{
+			"previous_owner_id": owner.UserID,
+			"new_owner_id":      req.NewOwnerID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var organization *entity.Organization
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		organization, err = ds.OrganizationRepository().GetByID(ctx, req.OrganizationID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return nil, grpcerror.NewOrganizationNotFoundError()
+	}
+	role := constant.OrganizationRoleAdmin
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok && claims.UserID == organization.OwnerID {
+		role = constant.OrganizationRoleOwner
+	}
+	return dto.ToOrganizationResponse(organization, role), nil
+}
+
+// GetMembership is open to the user it is about and to the organization's
+// members. The auth service uses it when switching organizations.
+func (u *organizationUseCaseImpl) GetMembership(ctx context.Context, req *dto.GetMembershipRequest) (*dto.MembershipResponse, error) {
+	res := new(dto.MembershipResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		caller, err := callerMembership(ctx, ds, req.OrganizationID, constant.OrganizationRoleMember)
+		if err != nil {
+			return err
+		}
+		if caller.UserID == req.UserID {
+			res = dto.ToMembershipResponse(caller)
+			return nil
+		}
+
+		membership, err := ds.MembershipRepository().Get(ctx, req.OrganizationID, req.UserID)
+		if err != nil {
+			return err
+		}
+		if membership == nil {
+			return grpcerror.NewMemberNotFoundError()
+		}
+		res = dto.ToMembershipResponse(membership)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *organizationUseCaseImpl) ListMembers(ctx context.Context, req *dto.ListMembersRequest) (*dto.ListMembersResponse, error) {
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = constant.DefaultPageSize
+	}
+	if pageSize > constant.MaxPageSize {
+		pageSize = constant.MaxPageSize
+	}
+
+	params := &repository.ListMembersParams{
+		OrganizationID: req.OrganizationID,
+		Limit:          pageSize + 1,
+	}
+	if req.PageToken != "" {
+		cursor, err := decodeMemberCursor(req.PageToken)
+		if err != nil || cursor.OrganizationID != req.OrganizationID {
+			return nil, grpcerror.NewInvalidPageTokenError()
+		}
+		afterCreatedAt, err := time.Parse(time.RFC3339Nano, cursor.CreatedAt)
+		if err != nil {
+			return nil, grpcerror.NewInvalidPageTokenError()
+		}
+		params.AfterCreatedAt = afterCreatedAt
+		params.AfterUserID = cursor.UserID
+	}
+
+	var members []*entity.Membership
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		if _, err := callerMembership(ctx, ds, req.OrganizationID, constant.OrganizationRoleMember); err != nil {
+			return err
+		}
+
+		var err error
+		members, err = ds.MembershipRepository().List(ctx, params)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.ListMembersResponse{
+		Members: make([]*dto.MembershipResponse, 0, len(members)),
+	}
+	if len(members) > pageSize {
+		members = members[:pageSize]
+		res.NextPageToken = encodeMemberCursor(members[len(members)-1])
+	}
+	for _, member := range members {
+		res.Members = append(res.Members, dto.ToMembershipResponse(member))
+	}
+	return res, nil
+}
+
+func (u *organizationUseCaseImpl) UpdateMemberRole(ctx context.Context, req *dto.UpdateMemberRoleRequest) (*dto.MembershipResponse, error) {
+	if req.Role != constant.OrganizationRoleAdmin && req.Role != constant.OrganizationRoleMember {
+		return nil, grpcerror.NewInvalidOrganizationRoleError()
+	}
+
+	res := new(dto.MembershipResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		caller, err := callerMembership(ctx, ds, req.OrganizationID, constant.OrganizationRoleAdmin)
+		if err != nil {
+			return err
+		}
+
+		membershipRepository := ds.MembershipRepository()
+		member, err := membershipRepository.Get(ctx, req.OrganizationID, req.UserID)
+		if err != nil {
+			return err
+		}
+		if member == nil {
+			return grpcerror.NewMemberNotFoundError()
+		}
+		if !outranks(caller.Role, member.Role) || !outranks(caller.Role, req.Role) {
+			return grpcerror.NewPermissionDeniedError()
+		}
+
+		if member.Role != req.Role {
+			if err := membershipRepository.UpdateRole(ctx, req.OrganizationID, req.UserID, req.Role); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, ds, constant.AuditOperationUpdateMemberRole, req.OrganizationID, nil, nil, map[string]any{
+				"user_id":       req.UserID,
+				"previous_role": member.Role,
+				"role":          req.Role,
+			}); err != nil {
+				return err
+			}
+		}
+
+		member.Role = req.Role
+		res = dto.ToMembershipResponse(member)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RemoveMember removes a member ranked below the caller, or the caller
+// themselves, which is how a member leaves. The owner cannot leave.
+func (u *organizationUseCaseImpl) RemoveMember(ctx context.Context, req *dto.RemoveMemberRequest) (*dto.RemoveMemberResponse, error) {
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		caller, err := callerMembership(ctx, ds, req.OrganizationID, constant.OrganizationRoleMember)
+		if err != nil {
+			return err
+		}
+
+		membershipRepository := ds.MembershipRepository()
+		member := caller
+		if req.UserID != caller.UserID {
+			member, err = membershipRepository.Get(ctx, req.OrganizationID, req.UserID)
+			if err != nil {
+				return err
+			}
+			if member == nil {
+				return grpcerror.NewMemberNotFoundError()
+			}
+			if !outranks(caller.Role, constant.OrganizationRoleMember) || !outranks(caller.Role, member.Role) {
+				return grpcerror.NewPermissionDeniedError()
+			}
+		}
+		if member.Role == constant.OrganizationRoleOwner {
+			return grpcerror.NewOwnerCannotLeaveError()
+		}
+
+		if _, err := membershipRepository.Remove(ctx, req.OrganizationID, member.UserID); err != nil {
+			return err
+		}
+		return recordAudit(ctx, ds, constant.AuditOperationRemoveMember, req.OrganizationID, nil, nil, map[string]any{
+			"user_id": member.UserID,
+			"role":    member.Role,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RemoveMemberResponse{
+		Success: true,
+		Message: constant.MemberRemoved,
+	}, nil
+}
+
+// InviteMember invites an email address to join with a role ranked below
+// the caller's, and mails it a signed link. Whoever holds the link accepts,
+// since only the owner of the address could have received it.
+func (u *organizationUseCaseImpl) InviteMember(ctx context.Context, req *dto.InviteMemberRequest) (*dto.OrganizationInvitationResponse, error) {
+	if req.Role != constant.OrganizationRoleAdmin && req.Role != constant.OrganizationRoleMember {
+		return nil, grpcerror.NewInvalidOrganizationRoleError()
+	}
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if email == "" {
+		return nil, grpcerror.NewEmailRequiredError()
+	}
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return nil, grpcerror.NewInvalidEmailError()
+	}
+
+	res := new(dto.OrganizationInvitationResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		caller, err := callerMembership(ctx, ds, req.OrganizationID, constant.OrganizationRoleAdmin)
+		if err != nil {
+			return err
+		}
+		if !outranks(caller.Role, req.Role) {
+			return grpcerror.NewPermissionDeniedError()
+		}
+
+		invitee, err := ds.UserRepository().GetByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+		if invitee != nil {
+			member, err := ds.MembershipRepository().Get(ctx, req.OrganizationID, invitee.ID)
+			if err != nil {
+				return err
+			}
+			if member != nil {
+				return grpcerror.NewMemberExistsError()
+			}
+		}
+
+		now := time.Now().UTC()
+		invitationRepository := ds.OrganizationInvitationRepository()
+		pending, err := invitationRepository.GetPending(ctx, req.OrganizationID, email)
+		if err != nil {
+			return err
+		}
+		if pending != nil {
+			if pending.IsPending(now) {
+				return grpcerror.NewInvitationExistsError()
+			}
+			// An expired invitation still holds the slot; replace it.
+			if _, err := invitationRepository.Revoke(ctx, pending.ID, now); err != nil {
+				return err
+			}
+		}
+
+		invitation := &entity.OrganizationInvitation{
+			ID:             uuid.New().String(),
+			OrganizationID: req.OrganizationID,
+			Email:          email,
+			Role:           req.Role,
+			InvitedBy:      caller.UserID,
+			CreatedAt:      now,
+			ExpiresAt:      now.Add(constant.OrganizationInvitationTTL),
+		}
+		if err := invitationRepository.Create(ctx, invitation); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, ds, constant.AuditOperationInviteMember, req.OrganizationID, nil, nil, map[string]any{
+			"invitation_id": invitation.ID,
+			"role":          invitation.Role,
+		}); err != nil {
+			return err
+		}
+
+		res = dto.ToOrganizationInvitationResponse(invitation)
+
+		// Sent last so a failed delivery rolls the invitation back and the
+		// admin can simply retry.
+		return u.invitations.Notifier.Notify(ctx, &notify.Message{
+			To:      invitation.Email,
+			Subject: constant.OrganizationInvitationEmailSubject,
+			Body:    fmt.Sprintf(constant.OrganizationInvitationEmailBody, invitation.Role, u.invitationLink(invitation), invitation.ExpiresAt.Format(time.RFC1123)),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *organizationUseCaseImpl) ListOrganizationInvitations(ctx context.Context, req *dto.ListOrganizationInvitationsRequest) (*dto.ListOrganizationInvitationsResponse, error) {
+	var invitations []*entity.OrganizationInvitation
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		if req.OrganizationID == "" {
+			caller, err := callerUser(ctx, ds)
+			if err != nil {
+				return err
+			}
+			invitations, err = ds.OrganizationInvitationRepository().ListPendingByEmail(ctx, caller.Email)
+			return err
+		}
+
+		if _, err := callerMembership(ctx, ds, req.OrganizationID, constant.OrganizationRoleAdmin); err != nil {
+			return err
+		}
+		var err error
+		invitations, err = ds.OrganizationInvitationRepository().ListPendingByOrganization(ctx, req.OrganizationID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.ListOrganizationInvitationsResponse{
+		Invitations: make([]*dto.OrganizationInvitationResponse, 0, len(invitations)),
+	}
+	for _, invitation := range invitations {
+		res.Invitations = append(res.Invitations, dto.ToOrganizationInvitationResponse(invitation))
+	}
+	return res, nil
+}
+
+func (u *organizationUseCaseImpl) RevokeOrganizationInvitation(ctx context.Context, req *dto.OrganizationInvitationRequest) (*dto.OrganizationInvitationResponse, error) {
+	res := new(dto.OrganizationInvitationResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		invitationRepository := ds.OrganizationInvitationRepository()
+		invitation, err := invitationRepository.GetByID(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if invitation == nil {
+			return grpcerror.NewInvitationNotFoundError()
+		}
+		if _, err := callerMembership(ctx, ds, invitation.OrganizationID, constant.OrganizationRoleAdmin); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		revoked, err := invitationRepository.Revoke(ctx, invitation.ID, now)
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			return grpcerror.NewInvitationClosedError()
+		}
+		if err := recordAudit(ctx, ds, constant.AuditOperationRevokeInvitation, invitation.OrganizationID, nil, nil, map[string]any{
+			"invitation_id": invitation.ID,
+		}); err != nil {
+			return err
+		}
+
+		invitation.RevokedAt = &now
+		res = dto.ToOrganizationInvitationResponse(invitation)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// AcceptOrganizationInvitation adds the caller to the organization if the
+// token is one InviteMember mailed and the invitation is still open. The
+// caller's profile email plays no part: it is not verified.
+func (u *organizationUseCaseImpl) AcceptOrganizationInvitation(ctx context.Context, req *dto.AcceptOrganizationInvitationRequest) (*dto.MembershipResponse, error) {
+	id, signature, ok := strings.Cut(req.Token, ".")
+	if !ok {
+		return nil, grpcerror.NewInvalidInvitationTokenError()
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, grpcerror.NewInvalidInvitationTokenError()
+	}
+
+	res := new(dto.MembershipResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		caller, err := callerUser(ctx, ds)
+		if err != nil {
+			return err
+		}
+
+		invitationRepository := ds.OrganizationInvitationRepository()
+		invitation, err := invitationRepository.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if invitation == nil || !hmac.Equal([]byte(signature), []byte(u.signInvitation(invitation))) {
+			return grpcerror.NewInvalidInvitationTokenError()
+		}
+
+		now := time.Now().UTC()
+		if !invitation.IsPending(now) {
+			return grpcerror.NewInvitationClosedError()
+		}
+		accepted, err := invitationRepository.MarkAccepted(ctx, invitation.ID, now)
+		if err != nil {
+			return err
+		}
+		if !accepted {
+			return grpcerror.NewInvitationClosedError()
+		}
+
+		membership := &entity.Membership{
+			OrganizationID: invitation.OrganizationID,
+			UserID:         caller.ID,
+			Role:           invitation.Role,
+			CreatedAt:      now,
+		}
+		added, err := ds.MembershipRepository().Add(ctx, membership)
+		if err != nil {
+			return err
+		}
+		if !added {
+			return grpcerror.NewMemberExistsError()
+		}
+		if err := recordAudit(ctx, ds, constant.AuditOperationAcceptInvitation, invitation.OrganizationID, nil, nil, map[string]any{
+			"invitation_id": invitation.ID,
+			"user_id":       caller.ID,
+			"role":          invitation.Role,
+		}); err != nil {
+			return err
+		}
+
+		res = dto.ToMembershipResponse(membership)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *organizationUseCaseImpl) invitationToken(invitation *entity.OrganizationInvitation) string {
+	return invitation.ID + "." + u.signInvitation(invitation)
+}
+
+// signInvitation covers what the invitation grants as stored, so a token
+// cannot be reused for a reissued invitation or outlive its own.
+func (u *organizationUseCaseImpl) signInvitation(invitation *entity.OrganizationInvitation) string {
+	mac := hmac.New(sha256.New, []byte(u.invitations.Secret))
+	for _, field := range []string{invitation.ID, invitation.OrganizationID, invitation.Email, invitation.Role} {
+		mac.Write([]byte(field))
+		mac.Write([]byte("."))
+	}
+	mac.Write([]byte(strconv.FormatInt(invitation.ExpiresAt.Unix(), 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (u *organizationUseCaseImpl) invitationLink(invitation *entity.OrganizationInvitation) string {
+	return fmt.Sprintf("%s?token=%s", u.invitations.AcceptURL, url.QueryEscape(u.invitationToken(invitation)))
+}
+
+func callerUser(ctx context.Context, ds repository.DataStore) (*entity.User, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+	user, err := ds.UserRepository().GetByUserID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, grpcerror.NewUserNotFoundError()
+	}
+	return user, nil
+}
+
+// callerMembership returns the caller's membership in the organization. A
+// caller outside it gets NotFound, so organizations are not disclosed to
+// non-members; one ranked below minRole gets PermissionDenied.
+func callerMembership(ctx context.Context, ds repository.DataStore, organizationID, minRole string) (*entity.Membership, error) {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, grpcerror.NewUnauthenticatedError()
+	}
+
+	membership, err := ds.MembershipRepository().Get(ctx, organizationID, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil {
+		return nil, grpcerror.NewOrganizationNotFoundError()
+	}
+	if constant.OrganizationRoleRanks[membership.Role] < constant.OrganizationRoleRanks[minRole] {
+		return nil, grpcerror.NewPermissionDeniedError()
+	}
+	return membership, nil
+}
+
+// checkOwnsNoOrganizations keeps a user who owns organizations from being
+// deleted, which would leave those organizations without an owner.
+func checkOwnsNoOrganizations(ctx context.Context, ds repository.DataStore, userID string) error {
+	owned, err := ds.OrganizationRepository().CountOwnedByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if owned > 0 {
+		return grpcerror.NewOwnsOrganizationsError()
+	}
+	return nil
+}
+
+func outranks(role, other string) bool {
+	return constant.OrganizationRoleRanks[role] > constant.OrganizationRoleRanks[other]
+}
+
+func checkSlugAvailable(ctx context.Context, ds repository.DataStore, slug, organizationID string) error {
+	existing, err := ds.OrganizationRepository().GetBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != organizationID {
+		return grpcerror.NewOrganizationSlugExistsError()
+	}
+	return nil
+}
+
+func normalizeOrganizationName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > constant.MaxOrganizationNameLength {
+		return "", grpcerror.NewInvalidOrganizationNameError()
+	}
+	return name, nil
+}
+
+func normalizeOrganizationSlug(slug string) (string, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if !organizationSlugPattern.MatchString(slug) {
+		return "", grpcerror.NewInvalidOrganizationSlugError()
+	}
+	return slug, nil
+}
+
+func encodeMemberCursor(membership *entity.Membership) string {
+	data, _ := json.Marshal(memberCursor{
+		OrganizationID: membership.OrganizationID,
+		CreatedAt:      membership.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UserID:         membership.UserID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeMemberCursor(token string) (*memberCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := &memberCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
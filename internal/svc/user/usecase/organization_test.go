@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/notify"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// invitationDataStore keeps users, memberships and invitations in memory.
+// Repositories the tests do not use are nil.
+type invitationDataStore struct {
+	repository.DataStore
+
+	users       *invitationUserRepository
+	memberships *invitationMembershipRepository
+	invitations *invitationRepository
+}
+
+func (s *invitationDataStore) Atomic(ctx context.Context, fn func(repository.DataStore) error) error {
+	return fn(s)
+}
+
+func (s *invitationDataStore) UserRepository() repository.UserRepository {
+	return s.users
+}
+
+func (s *invitationDataStore) MembershipRepository() repository.MembershipRepository {
+	return s.memberships
+}
+
+func (s *invitationDataStore) OrganizationInvitationRepository() repository.OrganizationInvitationRepository {
+	return s.invitations
+}
+
+func (s *invitationDataStore) AuditRepository() repository.AuditRepository {
+	return invitationAuditRepository{}
+}
+
+type invitationUserRepository struct {
+	repository.UserRepository
+
+	users map[string]*entity.User
+}
+
+func (r *invitationUserRepository) GetByUserID(ctx context.Context, userID string) (*entity.User, error) {
+	return r.users[userID], nil
+}
+
+func (r *invitationUserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+type invitationMembershipRepository struct {
+	repository.MembershipRepository
+
+	memberships map[string]*entity.Membership
+}
+
+func (r *invitationMembershipRepository) Get(ctx context.Context, organizationID, userID string) (*entity.Membership, error) {
+	return r.memberships[organizationID+"/"+userID], nil
+}
+
+func (r *invitationMembershipRepository) Add(ctx context.Context, membership *entity.Membership) (bool, error) {
+	key := membership.OrganizationID + "/" + membership.UserID
+	if _, ok := r.memberships[key]; ok {
+		return false, nil
+	}
+	r.memberships[key] = membership
+	return true, nil
+}
+
+type invitationRepository struct {
+	repository.OrganizationInvitationRepository
+
+	invitations map[string]*entity.OrganizationInvitation
+}
+
+func (r *invitationRepository) Create(ctx context.Context, invitation *entity.OrganizationInvitation) error {
+	r.invitations[invitation.ID] = invitation
+	return nil
+}
+
+func (r *invitationRepository) GetByID(ctx context.Context, id string) (*entity.OrganizationInvitation, error) {
+	return r.invitations[id], nil
+}
+
+func (r *invitationRepository) GetPending(ctx context.Context, organizationID, email string) (*entity.OrganizationInvitation, error) {
+	for _, invitation := range r.invitations {
+		if invitation.OrganizationID == organizationID && invitation.Email == email && invitation.AcceptedAt == nil && invitation.RevokedAt == nil {
+			return invitation, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *invitationRepository) MarkAccepted(ctx context.Context, id string, at time.Time) (bool, error) {
+	invitation := r.invitations[id]
+	if invitation == nil || invitation.AcceptedAt != nil {
+		return false, nil
+	}
+	invitation.AcceptedAt = &at
+	return true, nil
+}
+
+type invitationAuditRepository struct {
+	repository.AuditRepository
+}
+
+func (invitationAuditRepository) Record(ctx context.Context, event *audit.Event) error {
+	return nil
+}
+
+var invitationTokenPattern = regexp.MustCompile(`token=(\S+)`)
+
+type invitationFixture struct {
+	usecase  OrganizationUseCase
+	notifier *notify.MemoryNotifier
+	admin    context.Context
+	invitee  context.Context
+	stranger context.Context
+}
+
+func newInvitationFixture(t *testing.T) *invitationFixture {
+	t.Helper()
+
+	adminID, inviteeID, strangerID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	dataStore := &invitationDataStore{
+		users: &invitationUserRepository{users: map[string]*entity.User{
+			adminID:   {ID: adminID, Email: "admin@example.com"},
+			inviteeID: {ID: inviteeID, Email: "someone@example.com"},
+			// The stranger has set their profile email to the invitee's
+			// address, which nothing verifies.
+			strangerID: {ID: strangerID, Email: "ada@example.com"},
+		}},
+		memberships: &invitationMembershipRepository{memberships: map[string]*entity.Membership{
+			"org-1/" + adminID: {OrganizationID: "org-1", UserID: adminID, Role: constant.OrganizationRoleOwner},
+		}},
+		invitations: &invitationRepository{invitations: map[string]*entity.OrganizationInvitation{}},
+	}
+	notifier := notify.NewMemoryNotifier()
+	usecase, err := NewOrganizationUseCase(dataStore, InvitationConfig{
+		Notifier:  notifier,
+		Secret:    strings.Repeat("s", constant.MinInvitationSecretBytes),
+		AcceptURL: "https://example.com/invitations/accept",
+	})
+	if err != nil {
+		t.Fatalf("NewOrganizationUseCase: %v", err)
+	}
+
+	withClaims := func(userID string) context.Context {
+		return interceptor.ContextWithClaims(context.Background(), &jwtutils.JWTClaims{UserID: userID, TokenType: "access"})
+	}
+	return &invitationFixture{
+		usecase:  usecase,
+		notifier: notifier,
+		admin:    withClaims(adminID),
+		invitee:  withClaims(inviteeID),
+		stranger: withClaims(strangerID),
+	}
+}
+
+func (f *invitationFixture) invite(t *testing.T) (*dto.OrganizationInvitationResponse, string) {
+	t.Helper()
+
+	invitation, err := f.usecase.InviteMember(f.admin, &dto.InviteMemberRequest{
+		OrganizationID: "org-1",
+		Email:          "Ada@Example.com",
+		Role:           constant.OrganizationRoleMember,
+	})
+	if err != nil {
+		t.Fatalf("InviteMember: %v", err)
+	}
+
+	message, ok := f.notifier.Last("ada@example.com")
+	if !ok {
+		t.Fatal("InviteMember sent no message to ada@example.com")
+	}
+	match := invitationTokenPattern.FindStringSubmatch(message.Body)
+	if match == nil {
+		t.Fatalf("message body %q has no token link", message.Body)
+	}
+	token, err := url.QueryUnescape(match[1])
+	if err != nil {
+		t.Fatalf("unescape token: %v", err)
+	}
+	return invitation, token
+}
+
+func TestInviteMemberMailsTokenThatAcceptsInvitation(t *testing.T) {
+	f := newInvitationFixture(t)
+	invitation, token := f.invite(t)
+	if !strings.HasPrefix(token, invitation.ID+".") {
+		t.Errorf("token %q does not name invitation %s", token, invitation.ID)
+	}
+
+	membership, err := f.usecase.AcceptOrganizationInvitation(f.invitee, &dto.AcceptOrganizationInvitationRequest{Token: token})
+	if err != nil {
+		t.Fatalf("AcceptOrganizationInvitation: %v", err)
+	}
+	if membership.OrganizationID != "org-1" || membership.Role != constant.OrganizationRoleMember {
+		t.Errorf("membership = %+v, want member of org-1", membership)
+	}
+
+	if _, err := f.usecase.AcceptOrganizationInvitation(f.invitee, &dto.AcceptOrganizationInvitationRequest{Token: token}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("accepting twice: %v, want FailedPrecondition", err)
+	}
+}
+
+func TestAcceptOrganizationInvitationRequiresMailedToken(t *testing.T) {
+	f := newInvitationFixture(t)
+	invitation, token := f.invite(t)
+
+	id, signature, _ := strings.Cut(token, ".")
+	tests := map[string]string{
+		"empty":            "",
+		"id only":          invitation.ID,
+		"id not a uuid":    "1 OR 1=1." + signature,
+		"other invitation": uuid.NewString() + "." + signature,
+		"bad signature":    id + "." + strings.Repeat("A", len(signature)),
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := f.usecase.AcceptOrganizationInvitation(f.stranger, &dto.AcceptOrganizationInvitationRequest{Token: token})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("AcceptOrganizationInvitation: %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestInviteMemberRejectsMalformedEmail(t *testing.T) {
+	f := newInvitationFixture(t)
+
+	for _, email := range []string{"not-an-address", "Ada <ada@example.com>", "ada@example.com, eve@example.com"} {
+		_, err := f.usecase.InviteMember(f.admin, &dto.InviteMemberRequest{OrganizationID: "org-1", Email: email, Role: constant.OrganizationRoleMember})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("InviteMember(%q): %v, want InvalidArgument", email, err)
+		}
+	}
+	if messages := f.notifier.Messages(); len(messages) != 0 {
+		t.Errorf("sent %d messages for malformed addresses, want none", len(messages))
+	}
+}
+
+func TestNewOrganizationUseCaseRejectsShortSecret(t *testing.T) {
+	for _, secret := range []string{"", strings.Repeat("s", constant.MinInvitationSecretBytes-1)} {
+		if _, err := NewOrganizationUseCase(nil, InvitationConfig{Secret: secret}); err == nil {
+			t.Errorf("NewOrganizationUseCase with a %d-byte secret succeeded, want error", len(secret))
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/randutils"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StartPhoneVerification texts a one-time code to the user's phone.
+//
+// The verify purpose confirms the profile number, second_factor backs a
+// step-up at the auth service and needs a verified number, and recovery
+// lets a signed-out user prove ownership of the account. Recovery answers
+// the same way whether or not a code was sent, so it cannot be used to
+// discover accounts or their numbers.
+func (u *userUseCaseImpl) StartPhoneVerification(ctx context.Context, req *dto.StartPhoneVerificationRequest) (*dto.StartPhoneVerificationResponse, error) {
+	purpose, err := phonePurpose(req.Purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.StartPhoneVerificationResponse{
+		Message:    constant.PhoneCodeSent,
+		ExpiresIn:  int64(constant.PhoneCodeTTL.Seconds()),
+		RetryAfter: int64(constant.PhoneResendCooldown.Seconds()),
+	}
+
+	if purpose == constant.PhonePurposeRecovery {
+		var user *entity.User
+		err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+			var err error
+			user, err = ds.UserRepository().GetByEmail(ctx, strings.ToLower(strings.TrimSpace(req.Email)))
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if user == nil || user.Status != constant.UserStatusActive || user.PhoneVerifiedAt == nil {
+			return res, nil
+		}
+		if err := u.sendPhoneCode(ctx, user, purpose); err != nil && !isPhoneRateLimited(err) {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	if err := authorizeSelf(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	var user *entity.User
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		user, err = ds.UserRepository().GetByUserID(ctx, req.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, grpcerror.NewUserNotFoundError()
+	}
+	if user.Phone == "" {
+		return nil, grpcerror.NewPhoneRequiredError()
+	}
+	if purpose == constant.PhonePurposeSecondFactor && user.PhoneVerifiedAt == nil {
+		return nil, grpcerror.NewPhoneNotVerifiedError()
+	}
+
+	if err := u.sendPhoneCode(ctx, user, purpose); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ConfirmPhoneVerification redeems a code. A verify code marks the profile
+// number verified; the other purposes only report whether the code was
+// right, for the auth service to act on. Recovery codes are redeemed by the
+// auth service on behalf of a signed-out user, so only a service may redeem
+// them; anyone else could otherwise guess codes for any user ID.
+func (u *userUseCaseImpl) ConfirmPhoneVerification(ctx context.Context, req *dto.ConfirmPhoneVerificationRequest) (*dto.ConfirmPhoneVerificationResponse, error) {
+	purpose, err := phonePurpose(req.Purpose)
+	if err != nil {
+		return nil, err
+	}
+	if purpose == constant.PhonePurposeRecovery {
+		if !interceptor.IsServiceIdentity(ctx) {
+			return nil, grpcerror.NewPermissionDeniedError()
+		}
+	} else if err := authorizeSelf(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	challenge, err := u.redeemPhoneCode(ctx, req.UserID, purpose, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if purpose != constant.PhonePurposeVerify {
+		return &dto.ConfirmPhoneVerificationResponse{Verified: true}, nil
+	}
+
+	res := &dto.ConfirmPhoneVerificationResponse{Verified: true}
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		userRepository := ds.UserRepository()
+
+		existingUser, err := userRepository.GetByUserID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if existingUser == nil {
+			return grpcerror.NewUserNotFoundError()
+		}
+		// The number may have changed since the code was sent; the code
+		// proves ownership of the old one only.
+		if existingUser.Phone != challenge.Phone {
+			return grpcerror.NewInvalidPhoneCodeError()
+		}
+
+		now := time.Now().UTC()
+		changes := &entity.User{
+			ID:              existingUser.ID,
+			PhoneVerifiedAt: &now,
+			UpdatedAt:       now,
+		}
+		updatedUser, err := userRepository.UpdateUserFields(ctx, changes, []string{"phone_verified_at"}, &existingUser.Version)
+		if err != nil {
+			return err
+		}
+		if updatedUser == nil {
+			return currentVersionError(ctx, userRepository, req.UserID)
+		}
+
+		if err := ds.UserHistoryRepository().RecordRevision(ctx, req.UserID, now); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, ds, constant.AuditOperationVerifyPhone, req.UserID, existingUser, updatedUser, nil); err != nil {
+			return err
+		}
+
+		cacheKey := userCacheKey(ctx, req.UserID)
+		u.redisRepo.Delete(ctx, cacheKey)
+
+		res.User = dto.ToUserResponse(updatedUser)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// sendPhoneCode enforces the resend limits, stores a new challenge and
+// texts its code. The challenge is dropped again if the text fails.
+func (u *userUseCaseImpl) sendPhoneCode(ctx context.Context, user *entity.User, purpose string) error {
+	cooldownKey := fmt.Sprintf(constant.PhoneCooldownPrefix, user.ID)
+	sends, err := u.redisRepo.Incr(ctx, cooldownKey, constant.PhoneResendCooldown)
+	if err != nil {
+		return err
+	}
+	if sends > 1 {
+		return u.phoneRateLimitedError(ctx, cooldownKey, constant.PhoneResendCooldown)
+	}
+
+	sendCountKey := fmt.Sprintf(constant.PhoneSendCountPrefix, user.Phone)
+	sends, err = u.redisRepo.Incr(ctx, sendCountKey, constant.PhoneSendWindow)
+	if err != nil {
+		return err
+	}
+	if sends > constant.PhoneMaxSendsPerWindow {
+		return u.phoneRateLimitedError(ctx, sendCountKey, constant.PhoneSendWindow)
+	}
+
+	code, err := randutils.GenerateString(constant.PhoneCodeAlphabet, constant.PhoneCodeLength)
+	if err != nil {
+		return err
+	}
+
+	challenge := &entity.PhoneChallenge{
+		Phone:     user.Phone,
+		CodeHash:  hashPhoneCode(user.ID, purpose, code),
+		ExpiresAt: time.Now().UTC().Add(constant.PhoneCodeTTL),
+	}
+	if err := u.phoneChallengeRepo.Save(ctx, user.ID, purpose, challenge, constant.PhoneCodeTTL); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(constant.PhoneCodeMessage, code, int(constant.PhoneCodeTTL.Minutes()))
+	if err := u.smsSender.Send(ctx, user.Phone, body); err != nil {
+		u.phoneChallengeRepo.Delete(ctx, user.ID, purpose)
+		return grpcerror.NewSMSUnavailableError()
+	}
+	return nil
+}
+
+// redeemPhoneCode checks code against the stored challenge and consumes the
+// challenge on success. Every wrong code counts, and once the attempts are
+// used up the challenge is discarded so the code cannot be guessed.
+func (u *userUseCaseImpl) redeemPhoneCode(ctx context.Context, userID, purpose, code string) (*entity.PhoneChallenge, error) {
+	challenge, err := u.phoneChallengeRepo.Get(ctx, userID, purpose)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil || time.Now().After(challenge.ExpiresAt) {
+		return nil, grpcerror.NewInvalidPhoneCodeError()
+	}
+
+	attempts, err := u.phoneChallengeRepo.IncrAttempts(ctx, userID, purpose, time.Until(challenge.ExpiresAt))
+	if err != nil {
+		return nil, err
+	}
+	if attempts > constant.PhoneCodeMaxAttempts {
+		if err := u.phoneChallengeRepo.Delete(ctx, userID, purpose); err != nil {
+			return nil, err
+		}
+		return nil, grpcerror.NewPhoneCodeAttemptsExceededError()
+	}
+
+	expected := []byte(challenge.CodeHash)
+	actual := []byte(hashPhoneCode(userID, purpose, strings.TrimSpace(code)))
+	if subtle.ConstantTimeCompare(expected, actual) != 1 {
+		return nil, grpcerror.NewInvalidPhoneCodeError()
+	}
+
+	if err := u.phoneChallengeRepo.Delete(ctx, userID, purpose); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+func (u *userUseCaseImpl) phoneRateLimitedError(ctx context.Context, key string, window time.Duration) error {
+	retryAfter, err := u.redisRepo.TTL(ctx, key)
+	if err != nil || retryAfter <= 0 {
+		retryAfter = window
+	}
+	return grpcerror.NewPhoneRateLimitedError(retryAfter)
+}
+
+func isPhoneRateLimited(err error) bool {
+	return status.Code(err) == codes.ResourceExhausted
+}
+
+func phonePurpose(purpose string) (string, error) {
+	if purpose == "" {
+		return constant.PhonePurposeVerify, nil
+	}
+	if !slices.Contains(constant.PhonePurposes, purpose) {
+		return "", grpcerror.NewInvalidPhonePurposeError()
+	}
+	return purpose, nil
+}
+
+// hashPhoneCode binds the code to its user and purpose, so a stored hash is
+// useless for any other challenge.
+func hashPhoneCode(userID, purpose, code string) string {
+	sum := sha256.Sum256([]byte(userID + ":" + purpose + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+// authorizeSelf admits only the user's own token. Impersonation tokens are
+// refused: codes go to the real owner's phone.
+func authorizeSelf(ctx context.Context, userID string) error {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return grpcerror.NewUnauthenticatedError()
+	}
+	if claims.IsImpersonation() || claims.UserID != userID {
+		return grpcerror.NewPermissionDeniedError()
+	}
+	return nil
+}
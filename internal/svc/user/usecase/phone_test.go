@@ -0,0 +1,355 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/sms"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// phoneRedisRepository is an in-memory RedisRepository with expiring keys.
+type phoneRedisRepository struct {
+	repository.RedisRepository
+
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+}
+
+func newPhoneRedisRepository() *phoneRedisRepository {
+	return &phoneRedisRepository{values: map[string]string{}, expires: map[string]time.Time{}}
+}
+
+func (r *phoneRedisRepository) live(key string) bool {
+	if expires, ok := r.expires[key]; ok && !time.Now().Before(expires) {
+		delete(r.values, key)
+		delete(r.expires, key)
+	}
+	_, ok := r.values[key]
+	return ok
+}
+
+func (r *phoneRedisRepository) Get(ctx context.Context, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.live(key) {
+		return "", redis.Nil
+	}
+	return r.values[key], nil
+}
+
+func (r *phoneRedisRepository) Set(ctx context.Context, key string, value any, expiration time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if data, ok := value.([]byte); ok {
+		value = string(data)
+	}
+	r.values[key] = fmt.Sprint(value)
+	r.expires[key] = time.Now().Add(expiration)
+	return nil
+}
+
+func (r *phoneRedisRepository) Delete(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.values, key)
+	delete(r.expires, key)
+	return nil
+}
+
+func (r *phoneRedisRepository) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	if r.live(key) {
+		fmt.Sscan(r.values[key], &count)
+	} else {
+		r.expires[key] = time.Now().Add(expiration)
+	}
+	count++
+	r.values[key] = fmt.Sprint(count)
+	return count, nil
+}
+
+func (r *phoneRedisRepository) TTL(ctx context.Context, key string) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.live(key) {
+		return -2, nil
+	}
+	return time.Until(r.expires[key]), nil
+}
+
+// expire ends every key starting with prefix, as if its TTL had run out.
+func (r *phoneRedisRepository) expire(prefix string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.values {
+		if strings.HasPrefix(key, prefix) {
+			r.expires[key] = time.Now()
+		}
+	}
+}
+
+type phoneDataStore struct {
+	repository.DataStore
+
+	users *phoneUserRepository
+}
+
+func (s *phoneDataStore) Atomic(ctx context.Context, fn func(repository.DataStore) error) error {
+	return fn(s)
+}
+
+func (s *phoneDataStore) UserRepository() repository.UserRepository {
+	return s.users
+}
+
+type phoneUserRepository struct {
+	repository.UserRepository
+
+	user *entity.User
+}
+
+func (r *phoneUserRepository) GetByUserID(ctx context.Context, userID string) (*entity.User, error) {
+	if userID != r.user.ID {
+		return nil, nil
+	}
+	return r.user, nil
+}
+
+func (r *phoneUserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	if email != r.user.Email {
+		return nil, nil
+	}
+	return r.user, nil
+}
+
+var phoneCodePattern = regexp.MustCompile(`\d{6}`)
+
+type phoneFixture struct {
+	usecase *userUseCaseImpl
+	redis   *phoneRedisRepository
+	sender  *sms.MemorySender
+	user    *entity.User
+	self    context.Context
+	service context.Context
+}
+
+func newPhoneFixture() *phoneFixture {
+	verifiedAt := time.Now().Add(-time.Hour)
+	user := &entity.User{
+		ID:              uuid.NewString(),
+		Email:           "ada@example.com",
+		Phone:           "+15550100",
+		PhoneVerifiedAt: &verifiedAt,
+		Status:          constant.UserStatusActive,
+	}
+	redisRepo := newPhoneRedisRepository()
+	sender := sms.NewMemorySender()
+	return &phoneFixture{
+		usecase: &userUseCaseImpl{
+			dataStore:          &phoneDataStore{users: &phoneUserRepository{user: user}},
+			redisRepo:          redisRepo,
+			phoneChallengeRepo: repository.NewPhoneChallengeRepository(redisRepo),
+			smsSender:          sender,
+		},
+		redis:   redisRepo,
+		sender:  sender,
+		user:    user,
+		self:    interceptor.ContextWithClaims(context.Background(), &jwtutils.JWTClaims{UserID: user.ID, TokenType: "access"}),
+		service: interceptor.ContextWithClaims(context.Background(), &jwtutils.JWTClaims{UserID: "auth-service", TokenType: "service"}),
+	}
+}
+
+// code returns the code in the last text sent to the user.
+func (f *phoneFixture) code(t *testing.T) string {
+	t.Helper()
+
+	message, ok := f.sender.Last(f.user.Phone)
+	if !ok {
+		t.Fatal("no code was texted")
+	}
+	code := phoneCodePattern.FindString(message.Body)
+	if code == "" {
+		t.Fatalf("text %q has no code", message.Body)
+	}
+	return code
+}
+
+func TestConfirmRecoveryCodeRequiresService(t *testing.T) {
+	f := newPhoneFixture()
+
+	if _, err := f.usecase.StartPhoneVerification(context.Background(), &dto.StartPhoneVerificationRequest{Email: f.user.Email, Purpose: constant.PhonePurposeRecovery}); err != nil {
+		t.Fatalf("StartPhoneVerification: %v", err)
+	}
+	code := f.code(t)
+	req := &dto.ConfirmPhoneVerificationRequest{UserID: f.user.ID, Purpose: constant.PhonePurposeRecovery, Code: code}
+
+	for name, ctx := range map[string]context.Context{
+		"anonymous": context.Background(),
+		"the user":  f.self,
+	} {
+		if _, err := f.usecase.ConfirmPhoneVerification(ctx, req); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("ConfirmPhoneVerification as %s: %v, want PermissionDenied", name, err)
+		}
+	}
+
+	res, err := f.usecase.ConfirmPhoneVerification(f.service, req)
+	if err != nil {
+		t.Fatalf("ConfirmPhoneVerification as a service: %v", err)
+	}
+	if !res.Verified {
+		t.Error("ConfirmPhoneVerification as a service: not verified")
+	}
+}
+
+func TestStartPhoneVerificationRateLimits(t *testing.T) {
+	f := newPhoneFixture()
+	start := func(ctx context.Context, req *dto.StartPhoneVerificationRequest) error {
+		_, err := f.usecase.StartPhoneVerification(ctx, req)
+		return err
+	}
+	secondFactor := &dto.StartPhoneVerificationRequest{UserID: f.user.ID, Purpose: constant.PhonePurposeSecondFactor}
+
+	if err := start(f.self, secondFactor); err != nil {
+		t.Fatalf("first StartPhoneVerification: %v", err)
+	}
+	err := start(f.self, secondFactor)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("StartPhoneVerification within the cooldown: %v, want ResourceExhausted", err)
+	}
+	if details := status.Convert(err).Details(); len(details) != 1 {
+		t.Errorf("rate limit error details = %v, want RetryInfo", details)
+	}
+
+	for i := 1; i < constant.PhoneMaxSendsPerWindow; i++ {
+		f.redis.expire("phone_cooldown:")
+		if err := start(f.self, secondFactor); err != nil {
+			t.Fatalf("send %d after the cooldown: %v", i+1, err)
+		}
+	}
+	f.redis.expire("phone_cooldown:")
+	if err := start(f.self, secondFactor); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("send past the window limit: %v, want ResourceExhausted", err)
+	}
+	sent := len(f.sender.Messages())
+	if sent != constant.PhoneMaxSendsPerWindow {
+		t.Errorf("texted %d codes, want %d", sent, constant.PhoneMaxSendsPerWindow)
+	}
+
+	// Recovery answers the same while limited, so it reveals nothing, but
+	// sends nothing either.
+	f.redis.expire("phone_cooldown:")
+	if err := start(context.Background(), &dto.StartPhoneVerificationRequest{Email: f.user.Email, Purpose: constant.PhonePurposeRecovery}); err != nil {
+		t.Fatalf("recovery while limited: %v", err)
+	}
+	if got := len(f.sender.Messages()); got != sent {
+		t.Errorf("recovery while limited texted %d more codes", got-sent)
+	}
+
+	f.redis.expire("phone_sends:")
+	f.redis.expire("phone_cooldown:")
+	if err := start(f.self, secondFactor); err != nil {
+		t.Fatalf("send after the window: %v", err)
+	}
+}
+
+func TestConfirmPhoneVerificationCountsAttempts(t *testing.T) {
+	f := newPhoneFixture()
+	if _, err := f.usecase.StartPhoneVerification(f.self, &dto.StartPhoneVerificationRequest{UserID: f.user.ID, Purpose: constant.PhonePurposeSecondFactor}); err != nil {
+		t.Fatalf("StartPhoneVerification: %v", err)
+	}
+	code := f.code(t)
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	confirm := func(code string) error {
+		_, err := f.usecase.ConfirmPhoneVerification(f.self, &dto.ConfirmPhoneVerificationRequest{UserID: f.user.ID, Purpose: constant.PhonePurposeSecondFactor, Code: code})
+		return err
+	}
+
+	for i := 0; i < constant.PhoneCodeMaxAttempts-1; i++ {
+		if err := confirm(wrong); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("wrong code %d: %v, want InvalidArgument", i+1, err)
+		}
+	}
+	if err := confirm(code); err != nil {
+		t.Fatalf("right code on the last attempt: %v", err)
+	}
+	if err := confirm(code); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("redeeming a code twice: %v, want InvalidArgument", err)
+	}
+
+	f.redis.expire("phone_cooldown:")
+	if _, err := f.usecase.StartPhoneVerification(f.self, &dto.StartPhoneVerificationRequest{UserID: f.user.ID, Purpose: constant.PhonePurposeSecondFactor}); err != nil {
+		t.Fatalf("StartPhoneVerification: %v", err)
+	}
+	code = f.code(t)
+	for i := 0; i < constant.PhoneCodeMaxAttempts; i++ {
+		confirm(wrong)
+	}
+	if err := confirm(code); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("right code after the attempts ran out: %v, want ResourceExhausted", err)
+	}
+	if err := confirm(code); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("right code after the challenge was discarded: %v, want InvalidArgument", err)
+	}
+}
+
+func TestConfirmPhoneVerificationRejectsExpiredCode(t *testing.T) {
+	f := newPhoneFixture()
+	req := &dto.StartPhoneVerificationRequest{UserID: f.user.ID, Purpose: constant.PhonePurposeSecondFactor}
+	confirm := func(code string) error {
+		_, err := f.usecase.ConfirmPhoneVerification(f.self, &dto.ConfirmPhoneVerificationRequest{UserID: f.user.ID, Purpose: constant.PhonePurposeSecondFactor, Code: code})
+		return err
+	}
+
+	if _, err := f.usecase.StartPhoneVerification(f.self, req); err != nil {
+		t.Fatalf("StartPhoneVerification: %v", err)
+	}
+	f.redis.expire("phone_challenge:")
+	if err := confirm(f.code(t)); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("code after its key expired: %v, want InvalidArgument", err)
+	}
+
+	// The stored expiry is checked too, in case the key outlives it.
+	f.redis.expire("phone_cooldown:")
+	if _, err := f.usecase.StartPhoneVerification(f.self, req); err != nil {
+		t.Fatalf("StartPhoneVerification: %v", err)
+	}
+	ctx := context.Background()
+	challenge, err := f.usecase.phoneChallengeRepo.Get(ctx, f.user.ID, constant.PhonePurposeSecondFactor)
+	if err != nil || challenge == nil {
+		t.Fatalf("stored challenge = %v, %v", challenge, err)
+	}
+	challenge.ExpiresAt = time.Now().Add(-time.Second)
+	if err := f.usecase.phoneChallengeRepo.Save(ctx, f.user.ID, constant.PhonePurposeSecondFactor, challenge, time.Minute); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := confirm(f.code(t)); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("code past its stored expiry: %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,361 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/audit"
+	"github.com/hailsayan/achilles/internal/pkg/events"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/user/client"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+func (u *userUseCaseImpl) ExportUserData(ctx context.Context, req *dto.ExportUserDataRequest) (*dto.ExportUserDataResponse, error) {
+	if err := authorizeSelfOrPermission(ctx, req.ID, constant.PermissionExportUserData); err != nil {
+		return nil, err
+	}
+
+	format, err := exportFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+	return u.exportUserData(interceptor.ForwardAuthorization(ctx), req.ID, format)
+}
+
+// StartExportUserData queues an export of the user's data. The result of
+// the operation is the ExportUserDataResponse ExportUserData would return.
+func (u *userUseCaseImpl) StartExportUserData(ctx context.Context, req *dto.StartExportUserDataRequest) (*dto.OperationResponse, error) {
+	if err := authorizeSelfOrPermission(ctx, req.ID, constant.PermissionExportUserData); err != nil {
+		return nil, err
+	}
+
+	format, err := exportFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := json.Marshal(&exportOperationParams{
+		UserID: req.ID,
+		Format: format,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.createOperation(ctx, constant.OperationTypeExportUserData, req.ActorID, params)
+}
+
+func exportFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = constant.ExportFormatJSON
+	}
+	if format != constant.ExportFormatJSON && format != constant.ExportFormatZIP {
+		return "", grpcerror.NewInvalidExportFormatError()
+	}
+	return format, nil
+}
+
+// exportUserData builds the export of userID. The auth service is called
+// with whatever authorization ctx carries; without any, the user service
+// calls it with its own service credential.
+func (u *userUseCaseImpl) exportUserData(ctx context.Context, userID, format string) (*dto.ExportUserDataResponse, error) {
+	var user *entity.User
+	var auditEvents []*audit.Event
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		user, err = u.getUserIncludingDeleted(ctx, ds.UserRepository(), userID)
+		if err != nil || user == nil {
+			return err
+		}
+		auditEvents, err = ds.AuditRepository().ListByUser(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, grpcerror.NewUserNotFoundError()
+	}
+
+	authData, err := u.authClient.ExportUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	filename := fmt.Sprintf("user-data-%s-%s.%s", userID, now.Format("20060102"), format)
+	document := &userDataDocument{
+		ExportedAt:  now,
+		Profile:     user,
+		AuditEvents: auditEvents,
+		Auth:        authData,
+	}
+
+	if format == constant.ExportFormatZIP {
+		data, err := document.zip()
+		if err != nil {
+			return nil, err
+		}
+		return &dto.ExportUserDataResponse{Filename: filename, ContentType: "application/zip", Data: data}, nil
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ExportUserDataResponse{Filename: filename, ContentType: "application/json", Data: data}, nil
+}
+
+// RequestErasure anonymizes the user's own data straight away and asks the
+// other services to do the same. A second request while one is running
+// returns the running one.
+func (u *userUseCaseImpl) RequestErasure(ctx context.Context, req *dto.RequestErasureRequest) (*dto.OperationResponse, error) {
+	if err := authorizeSelfOrPermission(ctx, req.ID, constant.PermissionEraseUsers); err != nil {
+		return nil, err
+	}
+
+	res := new(dto.OperationResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		erasureRepository := ds.ErasureRepository()
+		userRepository := ds.UserRepository()
+
+		running, err := erasureRepository.GetRunningByUserID(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if running != nil {
+			res = dto.ToErasureOperation(running)
+			return nil
+		}
+
+		user, err := u.getUserIncludingDeleted(ctx, userRepository, req.ID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return grpcerror.NewUserNotFoundError()
+		}
+		if err := checkOwnsNoOrganizations(ctx, ds, user.ID); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if err := userRepository.AnonymizeUser(ctx, user.ID, now); err != nil {
+			return err
+		}
+		if err := ds.AuditRepository().AnonymizeByUser(ctx, user.ID); err != nil {
+			return err
+		}
+
+		// Past revisions hold the very values being erased. History restarts
+		// from the anonymized row.
+		historyRepository := ds.UserHistoryRepository()
+		if err := historyRepository.DeleteByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := historyRepository.RecordRevision(ctx, user.ID, now); err != nil {
+			return err
+		}
+		if err := ds.UsernameRepository().DeleteByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := ds.MembershipRepository().DeleteByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := ds.AvatarDeletionRepository().Add(ctx, user.ID, now); err != nil {
+			return err
+		}
+
+		request := &entity.ErasureRequest{
+			ID:                uuid.New().String(),
+			UserID:            user.ID,
+			RequestedBy:       req.ActorID,
+			State:             constant.ErasureStateRunning,
+			Services:          constant.ErasureServices,
+			ConfirmedServices: []string{events.ErasureServiceUser},
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		}
+		if err := erasureRepository.Create(ctx, request); err != nil {
+			return err
+		}
+
+		// Recorded after the anonymization so the event itself survives it.
+		// No snapshot: a diff would put the erased values back.
+		if err := recordAudit(ctx, ds, constant.AuditOperationRequestErasure, user.ID, nil, nil, map[string]any{
+			"erasure_request_id": request.ID,
+		}); err != nil {
+			return err
+		}
+
+		if err := u.erasureProducer.Send(ctx, &events.UserErasureRequestedEvent{
+			RequestID:  request.ID,
+			UserID:     user.ID,
+			TenantID:   tenant.FromContext(ctx),
+			OccurredAt: now,
+		}); err != nil {
+			return err
+		}
+
+		cacheKey := userCacheKey(ctx, user.ID)
+		u.redisRepo.Delete(ctx, cacheKey)
+
+		res = dto.ToErasureOperation(request)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Best effort: whatever fails stays queued for retryAvatarDeletions.
+	u.deleteAvatars(ctx, req.ID)
+
+	return res, nil
+}
+
+// ConfirmErasure records a service's confirmation and completes the request
+// once every service has confirmed. Repeated confirmations are ignored.
+func (u *userUseCaseImpl) ConfirmErasure(ctx context.Context, event *events.UserErasureConfirmedEvent) error {
+	return u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		erasureRepository := ds.ErasureRepository()
+
+		request, err := erasureRepository.GetByIDForUpdate(ctx, event.RequestID)
+		if err != nil {
+			return err
+		}
+		if request == nil || slices.Contains(request.ConfirmedServices, event.Service) {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		request.ConfirmedServices = append(request.ConfirmedServices, event.Service)
+		request.UpdatedAt = now
+
+		done := true
+		for _, service := range request.Services {
+			if !slices.Contains(request.ConfirmedServices, service) {
+				done = false
+				break
+			}
+		}
+		if done {
+			request.State = constant.ErasureStateSucceeded
+			request.CompletedAt = &now
+		}
+
+		return erasureRepository.Update(ctx, request)
+	})
+}
+
+func (u *userUseCaseImpl) getErasureOperation(ctx context.Context, name string) (*dto.OperationResponse, error) {
+	id, ok := strings.CutPrefix(name, constant.ErasureOperationPrefix)
+	if !ok {
+		return nil, grpcerror.NewOperationNotFoundError()
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, grpcerror.NewOperationNotFoundError()
+	}
+
+	var request *entity.ErasureRequest
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		request, err = ds.ErasureRepository().GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, grpcerror.NewOperationNotFoundError()
+	}
+
+	if err := authorizeSelfOrPermission(ctx, request.UserID, constant.PermissionEraseUsers); err != nil {
+		return nil, err
+	}
+
+	return dto.ToErasureOperation(request), nil
+}
+
+func (u *userUseCaseImpl) getUserIncludingDeleted(ctx context.Context, userRepository repository.UserRepository, id string) (*entity.User, error) {
+	user, err := userRepository.GetByUserID(ctx, id)
+	if err != nil || user != nil {
+		return user, err
+	}
+	return userRepository.GetDeletedByID(ctx, id)
+}
+
+// authorizeSelfOrPermission lets users act on their own data and otherwise
+// requires permission. Impersonation tokens are refused either way: personal
+// data leaves the system only at the request of its owner or an operator.
+func authorizeSelfOrPermission(ctx context.Context, userID, permission string) error {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return grpcerror.NewUnauthenticatedError()
+	}
+	if claims.IsImpersonation() {
+		return grpcerror.NewPermissionDeniedError()
+	}
+	if claims.UserID != userID && !claims.HasPermission(permission) {
+		return grpcerror.NewPermissionDeniedError()
+	}
+	return nil
+}
+
+type userDataDocument struct {
+	ExportedAt  time.Time        `json:"exported_at"`
+	Profile     *entity.User     `json:"profile"`
+	AuditEvents []*audit.Event   `json:"audit_events"`
+	Auth        *client.AuthData `json:"auth"`
+}
+
+// zip lays the export out as one JSON file per source so the archive can
+// be read without tooling.
+func (d *userDataDocument) zip() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	archive := zip.NewWriter(buf)
+
+	files := []struct {
+		name  string
+		value any
+	}{
+		{"profile.json", d.Profile},
+		{"audit_events.json", d.AuditEvents},
+		{"auth.json", d.Auth},
+	}
+	for _, file := range files {
+		w, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: d.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(file.value, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
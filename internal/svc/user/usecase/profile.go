@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	_ "time/tzdata" // timezones must validate the same on hosts without zoneinfo
+
+	"github.com/hailsayan/achilles/internal/pkg/jsonschema"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/pkg/utils/phoneutils"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+	"golang.org/x/text/language"
+)
+
+var avatarKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+func (u *userUseCaseImpl) GetAttributeSchema(ctx context.Context) (*dto.AttributeSchemaResponse, error) {
+	var schema *entity.AttributeSchema
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		schema, err = ds.AttributeSchemaRepository().GetByTenantID(ctx, tenant.FromContext(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, grpcerror.NewAttributeSchemaNotFoundError()
+	}
+
+	return dto.ToAttributeSchemaResponse(schema), nil
+}
+
+// SetAttributeSchema replaces the tenant's attribute schema. Stored
+// attributes are not revalidated; the new schema applies to later writes.
+func (u *userUseCaseImpl) SetAttributeSchema(ctx context.Context, req *dto.SetAttributeSchemaRequest) (*dto.AttributeSchemaResponse, error) {
+	compiled, err := jsonschema.Compile(req.Schema)
+	if err != nil {
+		return nil, grpcerror.NewInvalidAttributeSchemaError(err.Error())
+	}
+	if !compiled.AllowsType("object") {
+		return nil, grpcerror.NewInvalidAttributeSchemaError("schema must accept a JSON object")
+	}
+
+	schema := &entity.AttributeSchema{
+		TenantID:  tenant.FromContext(ctx),
+		Schema:    req.Schema,
+		UpdatedBy: req.ActorID,
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		if err := ds.AttributeSchemaRepository().Upsert(ctx, schema); err != nil {
+			return err
+		}
+		return recordAudit(ctx, ds, constant.AuditOperationSetAttributeSchema, schema.TenantID, nil, nil, map[string]any{
+			"schema": json.RawMessage(req.Schema),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToAttributeSchemaResponse(schema), nil
+}
+
+// validateAttributes checks attributes against the tenant's schema. Empty
+// attributes are always accepted; any others need a schema to exist.
+func validateAttributes(ctx context.Context, ds repository.DataStore, attributes map[string]any) error {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	schema, err := loadAttributeSchema(ctx, ds)
+	if err != nil {
+		return err
+	}
+	return checkAttributes(schema, attributes)
+}
+
+// loadAttributeSchema compiles the tenant's attribute schema, or returns nil
+// when the tenant has none.
+func loadAttributeSchema(ctx context.Context, ds repository.DataStore) (*jsonschema.Schema, error) {
+	schema, err := ds.AttributeSchemaRepository().GetByTenantID(ctx, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, nil
+	}
+	return jsonschema.Compile(schema.Schema)
+}
+
+// checkAttributes validates attributes against schema. Without a schema no
+// attributes are accepted.
+func checkAttributes(schema *jsonschema.Schema, attributes map[string]any) error {
+	if len(attributes) == 0 {
+		return nil
+	}
+	if schema == nil {
+		return grpcerror.NewAttributeSchemaMissingError()
+	}
+
+	err := schema.Validate(attributes)
+	var validationErr *jsonschema.ValidationError
+	if errors.As(err, &validationErr) {
+		return grpcerror.NewInvalidAttributesError(validationErr.Problems)
+	}
+	return err
+}
+
+func normalizePhone(phone string) (string, error) {
+	if strings.TrimSpace(phone) == "" {
+		return "", nil
+	}
+	normalized, err := phoneutils.NormalizeE164(phone)
+	if err != nil {
+		return "", grpcerror.NewInvalidPhoneError()
+	}
+	return normalized, nil
+}
+
+// normalizeLocale returns the canonical form of a BCP 47 tag, so en_us and
+// EN-us are both stored as en-US.
+func normalizeLocale(locale string) (string, error) {
+	locale = strings.TrimSpace(locale)
+	if locale == "" {
+		return "", nil
+	}
+	tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
+	if err != nil || tag == language.Und {
+		return "", grpcerror.NewInvalidLocaleError(locale)
+	}
+	return tag.String(), nil
+}
+
+func normalizeTimezone(timezone string) (string, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		return "", nil
+	}
+	if timezone == "Local" {
+		return "", grpcerror.NewInvalidTimezoneError(timezone)
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", grpcerror.NewInvalidTimezoneError(timezone)
+	}
+	return location.String(), nil
+}
+
+// normalizeAvatarRef accepts an https URL or an object storage key.
+func normalizeAvatarRef(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", nil
+	}
+	if len(ref) > constant.MaxAvatarRefLength {
+		return "", grpcerror.NewInvalidAvatarRefError()
+	}
+
+	if strings.Contains(ref, "://") {
+		parsed, err := url.Parse(ref)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return "", grpcerror.NewInvalidAvatarRefError()
+		}
+		return ref, nil
+	}
+
+	if !avatarKeyPattern.MatchString(ref) || strings.Contains(ref, "..") {
+		return "", grpcerror.NewInvalidAvatarRefError()
+	}
+	return ref, nil
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+func (u *userUseCaseImpl) SearchUsers(ctx context.Context, req *dto.SearchUsersRequest) (*dto.SearchUsersResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if utf8.RuneCountInString(query) < constant.MinSearchQueryLength {
+		return nil, grpcerror.NewSearchQueryTooShortError(constant.MinSearchQueryLength)
+	}
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = constant.DefaultSearchPageSize
+	}
+	if pageSize > constant.MaxSearchPageSize {
+		pageSize = constant.MaxSearchPageSize
+	}
+
+	var results []*entity.UserSearchResult
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		results, err = ds.UserRepository().SearchUsers(ctx, &repository.SearchUsersParams{
+			Term:       query,
+			Limit:      pageSize,
+			MatchEmail: req.IncludePII,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.SearchUsersResponse{
+		Results: make([]*dto.UserSearchResult, 0, len(results)),
+	}
+	for _, result := range results {
+		res.Results = append(res.Results, dto.ToUserSearchResult(result, req.IncludePII))
+	}
+
+	return res, nil
+}
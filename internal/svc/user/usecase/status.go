@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/events"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+func (u *userUseCaseImpl) SuspendUser(ctx context.Context, req *dto.ChangeUserStatusRequest) (*dto.UserResponse, error) {
+	return u.changeStatus(ctx, req, constant.UserStatusSuspended)
+}
+
+func (u *userUseCaseImpl) ReinstateUser(ctx context.Context, req *dto.ChangeUserStatusRequest) (*dto.UserResponse, error) {
+	return u.changeStatus(ctx, req, constant.UserStatusActive)
+}
+
+func (u *userUseCaseImpl) changeStatus(ctx context.Context, req *dto.ChangeUserStatusRequest, status string) (*dto.UserResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, grpcerror.NewStatusReasonRequiredError()
+	}
+
+	res := new(dto.UserResponse)
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		userRepository := ds.UserRepository()
+
+		user, err := userRepository.GetByUserID(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return grpcerror.NewUserNotFoundError()
+		}
+		if !slices.Contains(constant.UserStatusTransitions[user.Status], status) {
+			return grpcerror.NewInvalidStatusTransitionError(user.Status, status)
+		}
+
+		before := *user
+		oldStatus := user.Status
+		now := time.Now().UTC()
+		user.Status = status
+		user.StatusReason = reason
+		user.StatusChangedBy = req.ActorID
+		user.StatusChangedAt = &now
+		user.UpdatedAt = now
+
+		updated, err := userRepository.UpdateStatus(ctx, user)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return currentVersionError(ctx, userRepository, user.ID)
+		}
+
+		operation := constant.AuditOperationReinstateUser
+		switch status {
+		case constant.UserStatusSuspended:
+			operation = constant.AuditOperationSuspendUser
+		case constant.UserStatusBanned:
+			operation = constant.AuditOperationBanUser
+		}
+		if err := ds.UserHistoryRepository().RecordRevision(ctx, user.ID, now); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, ds, operation, user.ID, &before, user, map[string]any{
+			"reason": reason,
+		}); err != nil {
+			return err
+		}
+
+		// Publishing inside the transaction means a failed send rolls the
+		// status back, so the auth service never misses a suspension.
+		if err := u.statusProducer.Send(ctx, &events.UserStatusChangedEvent{
+			EventID:    uuid.New().String(),
+			UserID:     user.ID,
+			OldStatus:  oldStatus,
+			NewStatus:  status,
+			Reason:     reason,
+			ActorID:    req.ActorID,
+			TenantID:   tenant.FromContext(ctx),
+			OccurredAt: now,
+		}); err != nil {
+			return err
+		}
+
+		cacheKey := userCacheKey(ctx, user.ID)
+		u.redisRepo.Delete(ctx, cacheKey)
+
+		res = dto.ToUserResponse(user)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/quota"
+	"github.com/hailsayan/achilles/internal/pkg/tenant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+// QuotaConfig holds the per-tenant limits and the meter counting calls.
+// Without a meter, call counts read as zero and roll-ups do nothing.
+type QuotaConfig struct {
+	Limits *quota.Config
+	Meter  quota.Meter
+}
+
+// GetUsage reports the caller's tenant's consumption against its limits.
+// Calls are counted from the start of the calendar month: the days already
+// rolled up come from Postgres and today's running count from Redis.
+func (u *userUseCaseImpl) GetUsage(ctx context.Context) (*dto.UsageResponse, error) {
+	tenantID := tenant.FromContext(ctx)
+	limits := u.quotas.Limits.For(tenantID)
+
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	periodStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var users, calls int64
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		usageRepository := ds.UsageRepository()
+
+		var err error
+		users, err = usageRepository.CountLiveUsers(ctx)
+		if err != nil {
+			return err
+		}
+		calls, err = usageRepository.Sum(ctx, quota.MetricRPCCalls, periodStart, today)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var callsToday, callsThisMinute int64
+	if u.quotas.Meter != nil {
+		if callsToday, err = u.quotas.Meter.Calls(ctx, tenantID, now); err != nil {
+			return nil, err
+		}
+		if callsThisMinute, err = u.quotas.Meter.CallsThisMinute(ctx, tenantID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.UsageResponse{
+		TenantID:    tenantID,
+		PeriodStart: periodStart,
+		Metrics: []*dto.UsageMetric{
+			{Metric: quota.MetricUsers, Used: users, Limit: limits.MaxUsers},
+			{Metric: quota.MetricRPCCalls, Used: calls + callsToday},
+			{Metric: quota.MetricRPCRate, Used: callsThisMinute, Limit: limits.RPCPerMinute},
+		},
+	}, nil
+}
+
+// RollupUsage writes the Redis call counters of yesterday and today into
+// Postgres, along with today's user count, for every tenant that made
+// calls. Yesterday is included so calls made just before midnight are not
+// lost between runs. Rewriting a day is harmless, as the stored value is
+// the day's running total. It returns how many records were written.
+func (u *userUseCaseImpl) RollupUsage(ctx context.Context) (int, error) {
+	if u.quotas.Meter == nil {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	written := 0
+	for _, day := range []time.Time{today.AddDate(0, 0, -1), today} {
+		tenantIDs, err := u.quotas.Meter.Tenants(ctx, day)
+		if err != nil {
+			return written, err
+		}
+
+		for _, tenantID := range tenantIDs {
+			if !tenant.Valid(tenantID) {
+				continue
+			}
+			tenantCtx := tenant.WithTenant(ctx, tenantID)
+
+			calls, err := u.quotas.Meter.Calls(tenantCtx, tenantID, day)
+			if err != nil {
+				return written, err
+			}
+
+			err = u.dataStore.Atomic(tenantCtx, func(ds repository.DataStore) error {
+				usageRepository := ds.UsageRepository()
+				if err := usageRepository.Upsert(tenantCtx, &entity.UsageRecord{
+					Metric:    quota.MetricRPCCalls,
+					Day:       day,
+					Value:     calls,
+					UpdatedAt: now,
+				}); err != nil {
+					return err
+				}
+				written++
+
+				if !day.Equal(today) {
+					return nil
+				}
+				users, err := usageRepository.CountLiveUsers(tenantCtx)
+				if err != nil {
+					return err
+				}
+				if err := usageRepository.Upsert(tenantCtx, &entity.UsageRecord{
+					Metric:    quota.MetricUsers,
+					Day:       day,
+					Value:     users,
+					UpdatedAt: now,
+				}); err != nil {
+					return err
+				}
+				written++
+				return nil
+			})
+			if err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// checkUserQuota rejects adding a live user to a tenant at its limit. It
+// holds the tenant's quota lock until ds commits.
+func (u *userUseCaseImpl) checkUserQuota(ctx context.Context, ds repository.DataStore) error {
+	limit := u.quotas.Limits.For(tenant.FromContext(ctx)).MaxUsers
+	if limit <= 0 {
+		return nil
+	}
+
+	usageRepository := ds.UsageRepository()
+	if err := usageRepository.LockTenant(ctx); err != nil {
+		return err
+	}
+	users, err := usageRepository.CountLiveUsers(ctx)
+	if err != nil {
+		return err
+	}
+	if users >= limit {
+		return grpcerror.NewQuotaExceededError(quota.MetricUsers, limit)
+	}
+	return nil
+}
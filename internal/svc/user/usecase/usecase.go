@@ -2,12 +2,15 @@ package usecase
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/events"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/mq"
+	"github.com/hailsayan/achilles/internal/pkg/sms"
+	"github.com/hailsayan/achilles/internal/svc/user/client"
 	"github.com/hailsayan/achilles/internal/svc/user/constant"
 	"github.com/hailsayan/achilles/internal/svc/user/dto"
 	"github.com/hailsayan/achilles/internal/svc/user/entity"
@@ -18,23 +21,101 @@ import (
 type UserUseCase interface {
 	CreateUser(ctx context.Context, req *dto.CreateUserRequest) (*dto.CreateUserResponse, error)
 	GetUser(ctx context.Context, req *dto.GetUserRequest) (*dto.GetUserResponse, error)
+	GetUserByEmail(ctx context.Context, req *dto.GetUserByEmailRequest) (*dto.GetUserResponse, error)
+	GetUserByUsername(ctx context.Context, req *dto.GetUserByUsernameRequest) (*dto.GetUserResponse, error)
+	CheckUsernameAvailability(ctx context.Context, req *dto.CheckUsernameAvailabilityRequest) (*dto.CheckUsernameAvailabilityResponse, error)
+	BatchGetUsers(ctx context.Context, req *dto.BatchGetUsersRequest) (*dto.BatchGetUsersResponse, error)
 	UpdateUser(ctx context.Context, req *dto.UpdateUserRequest) (*dto.UpdateUserResponse, error)
 	DeleteUser(ctx context.Context, req *dto.DeleteUserRequest) (*dto.DeleteUserResponse, error)
+	RestoreUser(ctx context.Context, req *dto.RestoreUserRequest) (*dto.UserResponse, error)
+	PurgeDeletedUsers(ctx context.Context) (int, error)
+	SuspendUser(ctx context.Context, req *dto.ChangeUserStatusRequest) (*dto.UserResponse, error)
+	ReinstateUser(ctx context.Context, req *dto.ChangeUserStatusRequest) (*dto.UserResponse, error)
+	ListUsers(ctx context.Context, req *dto.ListUsersRequest) (*dto.ListUsersResponse, error)
+	SearchUsers(ctx context.Context, req *dto.SearchUsersRequest) (*dto.SearchUsersResponse, error)
+	ExportUserData(ctx context.Context, req *dto.ExportUserDataRequest) (*dto.ExportUserDataResponse, error)
+	StartExportUserData(ctx context.Context, req *dto.StartExportUserDataRequest) (*dto.OperationResponse, error)
+	RequestErasure(ctx context.Context, req *dto.RequestErasureRequest) (*dto.OperationResponse, error)
+	ConfirmErasure(ctx context.Context, event *events.UserErasureConfirmedEvent) error
+	GetOperation(ctx context.Context, req *dto.GetOperationRequest) (*dto.OperationResponse, error)
+	ListAuditEvents(ctx context.Context, req *dto.ListAuditEventsRequest) (*dto.ListAuditEventsResponse, error)
+	ListUserRevisions(ctx context.Context, req *dto.ListUserRevisionsRequest) (*dto.ListUserRevisionsResponse, error)
+	GetAttributeSchema(ctx context.Context) (*dto.AttributeSchemaResponse, error)
+	SetAttributeSchema(ctx context.Context, req *dto.SetAttributeSchemaRequest) (*dto.AttributeSchemaResponse, error)
+	UploadAvatar(ctx context.Context, req *dto.UploadAvatarRequest) (*dto.UploadAvatarResponse, error)
+	StartPhoneVerification(ctx context.Context, req *dto.StartPhoneVerificationRequest) (*dto.StartPhoneVerificationResponse, error)
+	ConfirmPhoneVerification(ctx context.Context, req *dto.ConfirmPhoneVerificationRequest) (*dto.ConfirmPhoneVerificationResponse, error)
+	GetUsage(ctx context.Context) (*dto.UsageResponse, error)
+	RollupUsage(ctx context.Context) (int, error)
+	ForceEmailChange(ctx context.Context, req *dto.ForceEmailChangeRequest) (*dto.UserResponse, error)
+	SetUserStatus(ctx context.Context, req *dto.SetUserStatusRequest) (*dto.UserResponse, error)
+	BulkSetUserStatus(ctx context.Context, req *dto.BulkSetUserStatusRequest) (*dto.BulkOperationResponse, error)
+	BulkDeleteUsers(ctx context.Context, req *dto.BulkDeleteUsersRequest) (*dto.BulkOperationResponse, error)
+	ImportUsers(ctx context.Context, req *dto.ImportUsersRequest) (*dto.ImportUsersResponse, error)
+	ExportUsers(ctx context.Context, req *dto.ExportUsersRequest, send func(user *dto.UserResponse) error) error
+	CreateOperation(ctx context.Context, req *dto.CreateOperationRequest) (*dto.OperationResponse, error)
+	StartImportUsers(ctx context.Context, req *dto.StartImportUsersRequest) (*dto.OperationResponse, error)
+	ListOperations(ctx context.Context, req *dto.ListOperationsRequest) (*dto.ListOperationsResponse, error)
+	CancelOperation(ctx context.Context, req *dto.CancelOperationRequest) (*dto.OperationResponse, error)
+	WaitOperation(ctx context.Context, req *dto.WaitOperationRequest) (*dto.OperationResponse, error)
+	RunNextOperation(ctx context.Context, workerID string) (bool, error)
 }
 
 type userUseCaseImpl struct {
-	dataStore repository.DataStore
-	redisRepo repository.RedisRepository
+	dataStore          repository.DataStore
+	redisRepo          repository.RedisRepository
+	phoneChallengeRepo repository.PhoneChallengeRepository
+	smsSender          sms.SMSSender
+	authClient         client.AuthClient
+	statusProducer     mq.KafkaProducer
+	lifecycleProducer  mq.KafkaProducer
+	erasureProducer    mq.KafkaProducer
+	deletion           DeletionConfig
+	avatars            AvatarConfig
+	quotas             QuotaConfig
+	operations         OperationConfig
+	userLoader         *userLoader
 }
 
 func NewUserUseCase(
 	dataStore repository.DataStore,
 	redisRepo repository.RedisRepository,
+	authClient client.AuthClient,
+	statusProducer mq.KafkaProducer,
+	lifecycleProducer mq.KafkaProducer,
+	erasureProducer mq.KafkaProducer,
+	deletion DeletionConfig,
+	avatars AvatarConfig,
+	smsSender sms.SMSSender,
+	quotas QuotaConfig,
+	operations OperationConfig,
 ) UserUseCase {
-	return &userUseCaseImpl{
-		dataStore: dataStore,
-		redisRepo: redisRepo,
+	if deletion.GracePeriod <= 0 {
+		deletion.GracePeriod = constant.DefaultDeletionGracePeriod
 	}
+	if deletion.EmailPolicy == "" {
+		deletion.EmailPolicy = constant.EmailPolicyReserve
+	}
+	if avatars.MaxBytes <= 0 {
+		avatars.MaxBytes = constant.DefaultAvatarMaxBytes
+	}
+
+	uc := &userUseCaseImpl{
+		dataStore:          dataStore,
+		redisRepo:          redisRepo,
+		phoneChallengeRepo: repository.NewPhoneChallengeRepository(redisRepo),
+		smsSender:          smsSender,
+		authClient:         authClient,
+		statusProducer:     statusProducer,
+		lifecycleProducer:  lifecycleProducer,
+		erasureProducer:    erasureProducer,
+		deletion:           deletion,
+		avatars:            avatars,
+		quotas:             quotas,
+		operations:         operations,
+	}
+	uc.userLoader = newUserLoader(uc.fetchUsers, constant.UserLoaderWait, constant.UserLoaderMaxBatch, constant.UserLoaderFetchTimeout)
+	return uc
 }
 
 func (u *userUseCaseImpl) CreateUser(ctx context.Context, req *dto.CreateUserRequest) (*dto.CreateUserResponse, error) {
@@ -44,30 +125,83 @@ func (u *userUseCaseImpl) CreateUser(ctx context.Context, req *dto.CreateUserReq
 
 		normalizedEmail := strings.ToLower(strings.TrimSpace(req.Email))
 
-		existingUser, err := userRepository.GetByEmail(ctx, normalizedEmail)
+		if err := u.checkEmailAvailable(ctx, userRepository, normalizedEmail, ""); err != nil {
+			return err
+		}
+		if err := u.checkUserQuota(ctx, ds); err != nil {
+			return err
+		}
+
+		phone, err := normalizePhone(req.Phone)
 		if err != nil {
 			return err
 		}
-		if existingUser != nil {
-			return grpcerror.NewEmailExistsError()
+		locale, err := normalizeLocale(req.Locale)
+		if err != nil {
+			return err
+		}
+		timezone, err := normalizeTimezone(req.Timezone)
+		if err != nil {
+			return err
+		}
+		avatarRef, err := normalizeAvatarRef(req.AvatarRef)
+		if err != nil {
+			return err
+		}
+		if err := validateAttributes(ctx, ds, req.Attributes); err != nil {
+			return err
+		}
+		attributes := entity.Attributes(req.Attributes)
+		if attributes == nil {
+			attributes = entity.Attributes{}
 		}
 
 		userID := uuid.New().String()
 		now := time.Now().UTC()
 
+		var username string
+		var usernameChangedAt *time.Time
+		if strings.TrimSpace(req.Username) != "" {
+			username, err = normalizeUsername(req.Username)
+			if err != nil {
+				return err
+			}
+			if err := checkUsernameAvailable(ctx, ds, username, ""); err != nil {
+				return err
+			}
+			usernameChangedAt = &now
+		}
+
 		user := &entity.User{
-			ID:        userID,
-			Email:     normalizedEmail,
-			FirstName: strings.TrimSpace(req.FirstName),
-			LastName:  strings.TrimSpace(req.LastName),
-			CreatedAt: now,
-			UpdatedAt: now,
+			ID:         userID,
+			Email:      normalizedEmail,
+			FirstName:  strings.TrimSpace(req.FirstName),
+			LastName:   strings.TrimSpace(req.LastName),
+			Status:     constant.UserStatusActive,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			Version:    1,
+			Phone:      phone,
+			Locale:     locale,
+			Timezone:   timezone,
+			AvatarRef:  avatarRef,
+			Attributes: attributes,
+
+			Username:          username,
+			UsernameChangedAt: usernameChangedAt,
 		}
 
 		if err := userRepository.CreateUser(ctx, user); err != nil {
 			return err
 		}
 
+		if err := ds.UserHistoryRepository().RecordRevision(ctx, user.ID, now); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, ds, constant.AuditOperationCreateUser, user.ID, nil, user, nil); err != nil {
+			return err
+		}
+
 		res = dto.ToCreateUserResponse(user)
 		return nil
 	})
@@ -80,20 +214,37 @@ func (u *userUseCaseImpl) CreateUser(ctx context.Context, req *dto.CreateUserReq
 }
 
 func (u *userUseCaseImpl) GetUser(ctx context.Context, req *dto.GetUserRequest) (*dto.GetUserResponse, error) {
-	cacheKey := fmt.Sprintf(constant.UserCachePrefix, req.ID)
+	if err := validateReadMask(req.ReadMask); err != nil {
+		return nil, err
+	}
 
-	if cachedData, err := u.redisRepo.Get(ctx, cacheKey); err == nil && cachedData != "" {
-		var user entity.User
-		if err := json.Unmarshal([]byte(cachedData), &user); err == nil {
-			return dto.ToGetUserResponse(&user), nil
-		}
+	if req.AsOf != nil {
+		return u.getUserAsOf(ctx, req)
+	}
+
+	user, err := u.userLoader.Load(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, grpcerror.NewUserNotFoundError()
+	}
+
+	return dto.MaskUserResponse(dto.ToGetUserResponse(user), req.ReadMask), nil
+}
+
+func (u *userUseCaseImpl) GetUserByEmail(ctx context.Context, req *dto.GetUserByEmailRequest) (*dto.GetUserResponse, error) {
+	if err := validateReadMask(req.ReadMask); err != nil {
+		return nil, err
 	}
 
 	res := new(dto.GetUserResponse)
 	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
 		userRepository := ds.UserRepository()
 
-		user, err := userRepository.GetByUserID(ctx, req.ID)
+		normalizedEmail := strings.ToLower(strings.TrimSpace(req.Email))
+		user, err := userRepository.GetByEmail(ctx, normalizedEmail)
 		if err != nil {
 			return err
 		}
@@ -102,11 +253,7 @@ func (u *userUseCaseImpl) GetUser(ctx context.Context, req *dto.GetUserRequest)
 			return grpcerror.NewUserNotFoundError()
 		}
 
-		if userData, err := json.Marshal(user); err == nil {
-			u.redisRepo.Set(ctx, cacheKey, string(userData), constant.UserCacheTTL)
-		}
-
-		res = dto.ToGetUserResponse(user)
+		res = dto.MaskUserResponse(dto.ToGetUserResponse(user), req.ReadMask)
 		return nil
 	})
 
@@ -117,9 +264,20 @@ func (u *userUseCaseImpl) GetUser(ctx context.Context, req *dto.GetUserRequest)
 	return res, nil
 }
 
+// UpdateUser edits the caller's own profile. Anyone else's needs
+// constant.PermissionWriteUsers.
 func (u *userUseCaseImpl) UpdateUser(ctx context.Context, req *dto.UpdateUserRequest) (*dto.UpdateUserResponse, error) {
+	if err := authorizeUserUpdate(ctx, req.ID); err != nil {
+		return nil, err
+	}
+
+	paths, err := updateMaskPaths(req)
+	if err != nil {
+		return nil, err
+	}
+
 	res := new(dto.UpdateUserResponse)
-	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
 		userRepository := ds.UserRepository()
 
 		existingUser, err := userRepository.GetByUserID(ctx, req.ID)
@@ -129,41 +287,85 @@ func (u *userUseCaseImpl) UpdateUser(ctx context.Context, req *dto.UpdateUserReq
 		if existingUser == nil {
 			return grpcerror.NewUserNotFoundError()
 		}
+		if req.ExpectedVersion != nil && *req.ExpectedVersion != existingUser.Version {
+			return grpcerror.NewVersionMismatchError(existingUser.Version)
+		}
 
-		updatedUser := &entity.User{
+		if len(paths) == 0 {
+			res = dto.ToUpdateUserResponse(existingUser)
+			return nil
+		}
+
+		changes := &entity.User{
 			ID:        existingUser.ID,
-			Email:     existingUser.Email,
-			FirstName: existingUser.FirstName,
-			LastName:  existingUser.LastName,
-			CreatedAt: existingUser.CreatedAt,
 			UpdatedAt: time.Now().UTC(),
 		}
 
-		if req.Email != nil {
-			normalizedEmail := strings.ToLower(strings.TrimSpace(*req.Email))
-			existingEmailUser, err := userRepository.GetByEmail(ctx, normalizedEmail)
+		for _, path := range paths {
+			switch path {
+			case "email":
+				normalizedEmail := strings.ToLower(strings.TrimSpace(valueOrEmpty(req.Email)))
+				if normalizedEmail == "" {
+					return grpcerror.NewEmailRequiredError()
+				}
+				if err := u.checkEmailAvailable(ctx, userRepository, normalizedEmail, req.ID); err != nil {
+					return err
+				}
+				changes.Email = normalizedEmail
+			case "first_name":
+				changes.FirstName = strings.TrimSpace(valueOrEmpty(req.FirstName))
+			case "last_name":
+				changes.LastName = strings.TrimSpace(valueOrEmpty(req.LastName))
+			case "phone":
+				// A new number starts unverified; changes.PhoneVerifiedAt
+				// stays nil.
+				changes.Phone, err = normalizePhone(valueOrEmpty(req.Phone))
+				if err == nil && changes.Phone != existingUser.Phone {
+					paths = append(paths, "phone_verified_at")
+				}
+			case "locale":
+				changes.Locale, err = normalizeLocale(valueOrEmpty(req.Locale))
+			case "timezone":
+				changes.Timezone, err = normalizeTimezone(valueOrEmpty(req.Timezone))
+			case "avatar_ref":
+				changes.AvatarRef, err = normalizeAvatarRef(valueOrEmpty(req.AvatarRef))
+			case "attributes":
+				// Attributes are replaced as a whole; clearing them needs no schema.
+				err = validateAttributes(ctx, ds, req.Attributes)
+				changes.Attributes = entity.Attributes(req.Attributes)
+				if changes.Attributes == nil {
+					changes.Attributes = entity.Attributes{}
+				}
+			case "username":
+				changes.Username, err = changeUsername(ctx, ds, existingUser, valueOrEmpty(req.Username), changes.UpdatedAt)
+				if err == nil && !strings.EqualFold(changes.Username, existingUser.Username) {
+					changes.UsernameChangedAt = &changes.UpdatedAt
+					paths = append(paths, "username_changed_at")
+				}
+			}
 			if err != nil {
 				return err
 			}
-			if existingEmailUser != nil && existingEmailUser.ID != req.ID {
-				return grpcerror.NewEmailExistsError()
-			}
-			updatedUser.Email = normalizedEmail
 		}
 
-		if req.FirstName != nil {
-			updatedUser.FirstName = strings.TrimSpace(*req.FirstName)
+		updatedUser, err := userRepository.UpdateUserFields(ctx, changes, paths, req.ExpectedVersion)
+		if err != nil {
+			return err
 		}
-
-		if req.LastName != nil {
-			updatedUser.LastName = strings.TrimSpace(*req.LastName)
+		if updatedUser == nil {
+			return currentVersionError(ctx, userRepository, req.ID)
 		}
 
-		if err := userRepository.UpdateUser(ctx, updatedUser); err != nil {
+		if err := ds.UserHistoryRepository().RecordRevision(ctx, req.ID, changes.UpdatedAt); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, ds, constant.AuditOperationUpdateUser, req.ID, existingUser, updatedUser, map[string]any{
+			"paths": paths,
+		}); err != nil {
 			return err
 		}
 
-		cacheKey := fmt.Sprintf(constant.UserCachePrefix, req.ID)
+		cacheKey := userCacheKey(ctx, req.ID)
 		u.redisRepo.Delete(ctx, cacheKey)
 
 		res = dto.ToUpdateUserResponse(updatedUser)
@@ -177,33 +379,34 @@ func (u *userUseCaseImpl) UpdateUser(ctx context.Context, req *dto.UpdateUserReq
 	return res, nil
 }
 
-func (u *userUseCaseImpl) DeleteUser(ctx context.Context, req *dto.DeleteUserRequest) (*dto.DeleteUserResponse, error) {
-	res := new(dto.DeleteUserResponse)
-	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
-		userRepository := ds.UserRepository()
-
-		existingUser, err := userRepository.GetByUserID(ctx, req.ID)
-		if err != nil {
-			return err
-		}
-		if existingUser == nil {
-			return grpcerror.NewUserNotFoundError()
-		}
-
-		if err := userRepository.DeleteUserByID(ctx, req.ID); err != nil {
-			return err
-		}
-
-		cacheKey := fmt.Sprintf(constant.UserCachePrefix, req.ID)
-		u.redisRepo.Delete(ctx, cacheKey)
-
-		res = dto.ToDeleteUserResponse(true, constant.UserDeletedSuccessfully)
+// authorizeUserUpdate lets users edit their own profile, also through an
+// impersonation session so that support can fix it on their behalf; such
+// writes are audited by the interceptor. Editing someone else's profile
+// needs constant.PermissionWriteUsers, which impersonation tokens never
+// carry.
+func authorizeUserUpdate(ctx context.Context, userID string) error {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return grpcerror.NewUnauthenticatedError()
+	}
+	if claims.UserID == userID {
 		return nil
-	})
+	}
+	if claims.IsImpersonation() || !claims.HasPermission(constant.PermissionWriteUsers) {
+		return grpcerror.NewPermissionDeniedError()
+	}
+	return nil
+}
 
+// currentVersionError explains why a conditional write matched no row: the
+// user is gone, or it moved past the version the write was conditioned on.
+func currentVersionError(ctx context.Context, userRepository repository.UserRepository, id string) error {
+	current, err := userRepository.GetByUserID(ctx, id)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return res, nil
+	if current == nil {
+		return grpcerror.NewUserNotFoundError()
+	}
+	return grpcerror.NewVersionMismatchError(current.Version)
 }
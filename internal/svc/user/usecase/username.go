@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/grpcerror"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// usernamePattern starts with a letter and allows single dots or
+// underscores between letters and digits, so a username can never be
+// mistaken for an email address or an ID.
+var usernamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(?:[._][A-Za-z0-9]+)*$`)
+
+// CheckUsernameAvailability reports whether username could be taken now.
+// A signed-in caller sees their own username and the names reserved for
+// them as available.
+func (u *userUseCaseImpl) CheckUsernameAvailability(ctx context.Context, req *dto.CheckUsernameAvailabilityRequest) (*dto.CheckUsernameAvailabilityResponse, error) {
+	res := &dto.CheckUsernameAvailabilityResponse{
+		Username: strings.TrimSpace(req.Username),
+	}
+
+	username, err := normalizeUsername(req.Username)
+	if err != nil {
+		res.Reason = constant.UsernameUnavailableInvalid
+		if _, ok := constant.ReservedUsernames[strings.ToLower(res.Username)]; ok {
+			res.Reason = constant.UsernameUnavailableReserved
+		}
+		return res, nil
+	}
+
+	userID := ""
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok && !claims.IsImpersonation() {
+		userID = claims.UserID
+	}
+	err = u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		return checkUsernameAvailable(ctx, ds, username, userID)
+	})
+	if err != nil {
+		if status.Code(err) != codes.AlreadyExists {
+			return nil, err
+		}
+		res.Reason = constant.UsernameUnavailableTaken
+		return res, nil
+	}
+
+	res.Available = true
+	return res, nil
+}
+
+func (u *userUseCaseImpl) GetUserByUsername(ctx context.Context, req *dto.GetUserByUsernameRequest) (*dto.GetUserResponse, error) {
+	if err := validateReadMask(req.ReadMask); err != nil {
+		return nil, err
+	}
+
+	var user *entity.User
+	err := u.dataStore.Atomic(ctx, func(ds repository.DataStore) error {
+		var err error
+		user, err = ds.UserRepository().GetByUsername(ctx, strings.TrimSpace(req.Username))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, grpcerror.NewUserNotFoundError()
+	}
+
+	return dto.MaskUserResponse(redactForCaller(ctx, dto.ToGetUserResponse(user)), req.ReadMask), nil
+}
+
+// redactForCaller clears the PII in res unless the caller is that user or
+// holds constant.PermissionReadPII.
+func redactForCaller(ctx context.Context, res *dto.UserResponse) *dto.UserResponse {
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		if claims.UserID == res.ID || claims.HasPermission(constant.PermissionReadPII) {
+			return res
+		}
+	}
+	dto.RedactPII(res)
+	return res
+}
+
+// changeUsername validates a rename of user and returns the username to
+// store. A change of case only is always allowed. A real rename waits out
+// the cooldown since the last one and reserves the old name for the user,
+// who may take it back while the reservation lasts.
+func changeUsername(ctx context.Context, ds repository.DataStore, user *entity.User, username string, now time.Time) (string, error) {
+	if strings.TrimSpace(username) == "" {
+		if user.Username != "" {
+			return "", grpcerror.NewUsernameRequiredError()
+		}
+		return "", nil
+	}
+
+	username, err := normalizeUsername(username)
+	if err != nil {
+		return "", err
+	}
+	if strings.EqualFold(username, user.Username) {
+		return username, nil
+	}
+
+	if user.Username != "" && user.UsernameChangedAt != nil {
+		next := user.UsernameChangedAt.Add(constant.UsernameChangeCooldown)
+		if now.Before(next) {
+			return "", grpcerror.NewUsernameChangeCooldownError(next)
+		}
+	}
+	if err := checkUsernameAvailable(ctx, ds, username, user.ID); err != nil {
+		return "", err
+	}
+
+	usernameRepository := ds.UsernameRepository()
+	if err := usernameRepository.DeleteReservation(ctx, username); err != nil {
+		return "", err
+	}
+	if user.Username != "" {
+		if err := usernameRepository.Reserve(ctx, &entity.UsernameReservation{
+			Username:      user.Username,
+			UserID:        user.ID,
+			ReservedUntil: now.Add(constant.UsernameReservationPeriod),
+		}); err != nil {
+			return "", err
+		}
+	}
+	return username, nil
+}
+
+// normalizeUsername trims username and checks it against the format and
+// the reserved words. The case is kept; uniqueness ignores it.
+func normalizeUsername(username string) (string, error) {
+	username = strings.TrimSpace(username)
+	if len(username) < constant.UsernameMinLength || len(username) > constant.UsernameMaxLength || !usernamePattern.MatchString(username) {
+		return "", grpcerror.NewInvalidUsernameError()
+	}
+	if _, ok := constant.ReservedUsernames[strings.ToLower(username)]; ok {
+		return "", grpcerror.NewUsernameReservedError()
+	}
+	return username, nil
+}
+
+// checkUsernameAvailable rejects a username held by another user, live or
+// soft-deleted, or still reserved for another user after a rename.
+func checkUsernameAvailable(ctx context.Context, ds repository.DataStore, username, userID string) error {
+	taken, err := ds.UserRepository().UsernameTaken(ctx, username, userID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return grpcerror.NewUsernameExistsError()
+	}
+
+	reservation, err := ds.UsernameRepository().GetReservation(ctx, username)
+	if err != nil {
+		return err
+	}
+	if reservation != nil && reservation.UserID != userID {
+		return grpcerror.NewUsernameExistsError()
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/interceptor"
+	"github.com/hailsayan/achilles/internal/pkg/utils/jwtutils"
+	"github.com/hailsayan/achilles/internal/svc/user/constant"
+	"github.com/hailsayan/achilles/internal/svc/user/dto"
+	"github.com/hailsayan/achilles/internal/svc/user/entity"
+	"github.com/hailsayan/achilles/internal/svc/user/repository"
+)
+
+type usernameDataStore struct {
+	repository.DataStore
+
+	users *usernameUserRepository
+}
+
+func (s *usernameDataStore) Atomic(ctx context.Context, fn func(repository.DataStore) error) error {
+	return fn(s)
+}
+
+func (s *usernameDataStore) UserRepository() repository.UserRepository {
+	return s.users
+}
+
+type usernameUserRepository struct {
+	repository.UserRepository
+
+	user *entity.User
+}
+
+func (r *usernameUserRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	if username != r.user.Username {
+		return nil, nil
+	}
+	return r.user, nil
+}
+
+func TestGetUserByUsernameRedactsPIIForOtherCallers(t *testing.T) {
+	user := &entity.User{
+		ID:         uuid.NewString(),
+		Username:   "ada",
+		Email:      "ada@example.com",
+		FirstName:  "Ada",
+		Phone:      "+15550100",
+		Attributes: entity.Attributes{"employee_id": "42"},
+	}
+	usecase := &userUseCaseImpl{dataStore: &usernameDataStore{users: &usernameUserRepository{user: user}}}
+
+	tests := map[string]struct {
+		claims  *jwtutils.JWTClaims
+		wantPII bool
+	}{
+		"other user":      {claims: &jwtutils.JWTClaims{UserID: uuid.NewString(), TokenType: "access"}},
+		"self":            {claims: &jwtutils.JWTClaims{UserID: user.ID, TokenType: "access"}, wantPII: true},
+		"read_pii holder": {claims: &jwtutils.JWTClaims{UserID: uuid.NewString(), TokenType: "access", Permissions: []string{constant.PermissionReadPII}}, wantPII: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := interceptor.ContextWithClaims(context.Background(), tt.claims)
+			res, err := usecase.GetUserByUsername(ctx, &dto.GetUserByUsernameRequest{Username: "ada"})
+			if err != nil {
+				t.Fatalf("GetUserByUsername: %v", err)
+			}
+			if hasPII := res.Email != "" || res.Phone != "" || res.Attributes != nil; hasPII != tt.wantPII {
+				t.Errorf("GetUserByUsername = %+v, want PII %v", res, tt.wantPII)
+			}
+			if res.FirstName != "Ada" {
+				t.Errorf("FirstName = %q, want Ada", res.FirstName)
+			}
+		})
+	}
+}
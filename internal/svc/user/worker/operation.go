@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hailsayan/achilles/internal/pkg/logger"
+	"github.com/hailsayan/achilles/internal/svc/user/usecase"
+)
+
+// OperationWorker runs background operations on a pool of goroutines. Each
+// runs one operation at a time and looks for the next one as soon as it is
+// done, or after interval when there was none.
+type OperationWorker struct {
+	userUseCase usecase.UserUseCase
+	workers     int
+	interval    time.Duration
+	log         logger.Logger
+}
+
+func NewOperationWorker(userUseCase usecase.UserUseCase, workers int, interval time.Duration, log logger.Logger) *OperationWorker {
+	return &OperationWorker{
+		userUseCase: userUseCase,
+		workers:     workers,
+		interval:    interval,
+		log:         log,
+	}
+}
+
+// Run blocks until ctx is done and every goroutine has returned. Operations
+// still running then are released for another instance to resume.
+func (w *OperationWorker) Run(ctx context.Context) {
+	instance := uuid.New().String()
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.workers; i++ {
+		wg.Add(1)
+		go func(workerID string) {
+			defer wg.Done()
+			w.loop(ctx, workerID)
+		}(fmt.Sprintf("%s/%d", instance, i))
+	}
+	wg.Wait()
+}
+
+func (w *OperationWorker) loop(ctx context.Context, workerID string) {
+	for ctx.Err() == nil {
+		ran, err := w.userUseCase.RunNextOperation(ctx, workerID)
+		if err != nil {
+			w.log.Errorf("run operation: %v", err)
+		}
+		if ran && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.interval):
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/logger"
+	"github.com/hailsayan/achilles/internal/svc/user/usecase"
+)
+
+// PurgeWorker periodically hard-deletes users whose restore grace period
+// has ended.
+type PurgeWorker struct {
+	userUseCase usecase.UserUseCase
+	interval    time.Duration
+	log         logger.Logger
+}
+
+func NewPurgeWorker(userUseCase usecase.UserUseCase, interval time.Duration, log logger.Logger) *PurgeWorker {
+	return &PurgeWorker{
+		userUseCase: userUseCase,
+		interval:    interval,
+		log:         log,
+	}
+}
+
+// Run purges once straight away and then on every tick until ctx is done.
+// A failed run is logged and retried on the next tick.
+func (w *PurgeWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := w.userUseCase.PurgeDeletedUsers(ctx)
+		if err != nil {
+			w.log.Errorf("purge deleted users: %v", err)
+		} else if purged > 0 {
+			w.log.Infof("purged %d deleted users", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
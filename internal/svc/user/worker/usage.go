@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/hailsayan/achilles/internal/pkg/logger"
+	"github.com/hailsayan/achilles/internal/svc/user/usecase"
+)
+
+// UsageRollupWorker periodically copies the Redis usage counters into
+// Postgres for billing.
+type UsageRollupWorker struct {
+	userUseCase usecase.UserUseCase
+	interval    time.Duration
+	log         logger.Logger
+}
+
+func NewUsageRollupWorker(userUseCase usecase.UserUseCase, interval time.Duration, log logger.Logger) *UsageRollupWorker {
+	return &UsageRollupWorker{
+		userUseCase: userUseCase,
+		interval:    interval,
+		log:         log,
+	}
+}
+
+// Run rolls up once straight away and then on every tick until ctx is done.
+// A final roll-up on shutdown is not needed: the counters stay in Redis and
+// the next run picks them up.
+func (w *UsageRollupWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		written, err := w.userUseCase.RollupUsage(ctx)
+		if err != nil {
+			w.log.Errorf("roll up usage: %v", err)
+		} else if written > 0 {
+			w.log.Debugf("rolled up %d usage records", written)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
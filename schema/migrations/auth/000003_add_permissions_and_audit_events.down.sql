@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS audit_events;
+ALTER TABLE user_auth DROP COLUMN IF EXISTS permissions;
@@ -0,0 +1,16 @@
+ALTER TABLE user_auth ADD COLUMN IF NOT EXISTS permissions TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY,
+    actor_id VARCHAR(64) NOT NULL,
+    impersonator_id VARCHAR(64),
+    target_id VARCHAR(64) NOT NULL,
+    operation VARCHAR(64) NOT NULL,
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    source_ip VARCHAR(64) NOT NULL DEFAULT '',
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_target_id ON audit_events (target_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_impersonator_id ON audit_events (impersonator_id, created_at) WHERE impersonator_id IS NOT NULL;
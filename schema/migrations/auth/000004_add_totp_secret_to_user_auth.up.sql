@@ -0,0 +1 @@
+ALTER TABLE user_auth ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(64) NOT NULL DEFAULT '';
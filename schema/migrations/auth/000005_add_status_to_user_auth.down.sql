@@ -0,0 +1 @@
+ALTER TABLE user_auth DROP COLUMN IF EXISTS status;
\ No newline at end of file
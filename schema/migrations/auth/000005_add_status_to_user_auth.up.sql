@@ -0,0 +1 @@
+ALTER TABLE user_auth ADD COLUMN IF NOT EXISTS status VARCHAR(32) NOT NULL DEFAULT 'active';
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS audit_events_no_delete ON audit_events;
+DROP FUNCTION IF EXISTS prevent_audit_events_delete();
+
+DROP INDEX IF EXISTS idx_audit_events_operation;
+DROP INDEX IF EXISTS idx_audit_events_actor_id;
+DROP INDEX IF EXISTS idx_audit_events_created_at;
+
+ALTER TABLE audit_events DROP COLUMN IF EXISTS changes;
@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS idx_invitations_open_email;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invitations_open_email ON invitations (email) WHERE accepted_at IS NULL AND revoked_at IS NULL;
+
+ALTER TABLE user_identities DROP CONSTRAINT IF EXISTS user_identities_tenant_provider_subject_key;
+ALTER TABLE user_identities ADD CONSTRAINT user_identities_provider_subject_key UNIQUE (provider, subject);
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY['user_auth', 'user_identities', 'audit_events', 'invitations'] LOOP
+        EXECUTE format('DROP POLICY IF EXISTS tenant_sweep ON %I', t);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DROP COLUMN IF EXISTS tenant_id', t);
+    END LOOP;
+END
+$$;
+
+DROP FUNCTION IF EXISTS current_tenant();
@@ -0,0 +1,31 @@
+-- Every table carries the tenant its rows belong to. Row-level security
+-- confines a transaction to the tenant DataStore.Atomic sets in
+-- app.tenant_id; existing rows move to the default tenant.
+CREATE OR REPLACE FUNCTION current_tenant() RETURNS TEXT AS $$
+    SELECT NULLIF(current_setting('app.tenant_id', true), '')
+$$ LANGUAGE SQL STABLE;
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY['user_auth', 'user_identities', 'audit_events', 'invitations'] LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT ''default''', t);
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id SET DEFAULT current_tenant()', t);
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant() AND tenant_id <> ''*'')', t);
+        -- Background sweeps read across tenants with app.tenant_id = '*'.
+        EXECUTE format('DROP POLICY IF EXISTS tenant_sweep ON %I', t);
+        EXECUTE format('CREATE POLICY tenant_sweep ON %I FOR SELECT USING (current_tenant() = ''*'')', t);
+    END LOOP;
+END
+$$;
+
+-- A provider account and an open invitation are only unique within a tenant.
+ALTER TABLE user_identities DROP CONSTRAINT IF EXISTS user_identities_provider_subject_key;
+ALTER TABLE user_identities ADD CONSTRAINT user_identities_tenant_provider_subject_key UNIQUE (tenant_id, provider, subject);
+
+DROP INDEX IF EXISTS idx_invitations_open_email;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invitations_open_email ON invitations (tenant_id, email) WHERE accepted_at IS NULL AND revoked_at IS NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_last_name_lower;
+DROP INDEX IF EXISTS idx_users_first_name_lower;
+DROP INDEX IF EXISTS idx_users_email_pattern;
+DROP INDEX IF EXISTS idx_users_created_at_id;
@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_users_email_pattern ON users (email text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_users_first_name_lower ON users (lower(first_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_users_last_name_lower ON users (lower(last_name) text_pattern_ops);
@@ -0,0 +1,15 @@
+-- Soft-deleted users can still be restored, so dropping the column would
+-- silently destroy them. Purge or restore them before rolling back.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM users WHERE deleted_at IS NOT NULL) THEN
+        RAISE EXCEPTION 'cannot roll back soft delete while soft-deleted users exist; purge or restore them first';
+    END IF;
+END
+$$;
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS idx_users_email_live;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS erasure_requests (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    requested_by VARCHAR(64) NOT NULL DEFAULT '',
+    state VARCHAR(16) NOT NULL DEFAULT 'running',
+    services TEXT[] NOT NULL,
+    confirmed_services TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    CONSTRAINT erasure_requests_state_check CHECK (state IN ('running', 'succeeded'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_erasure_requests_running_user ON erasure_requests (user_id) WHERE state = 'running';
@@ -0,0 +1,17 @@
+ALTER TABLE audit_events ADD COLUMN IF NOT EXISTS changes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events (actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_operation ON audit_events (operation, created_at);
+
+-- The trail is append-only. Erasure may blank values in place, but rows are
+-- never removed.
+CREATE OR REPLACE FUNCTION prevent_audit_events_delete() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_no_delete
+    BEFORE DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION prevent_audit_events_delete();
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS users_history (
+    user_id UUID NOT NULL,
+    version BIGINT NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    first_name VARCHAR(64) NOT NULL,
+    last_name VARCHAR(64) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    status_reason TEXT NOT NULL DEFAULT '',
+    status_changed_by VARCHAR(64) NOT NULL DEFAULT '',
+    status_changed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP,
+    valid_from TIMESTAMP NOT NULL,
+    valid_to TIMESTAMP,
+    PRIMARY KEY (user_id, version)
+);
+
+-- At most one open revision per user; it is the one valid_to is set on when
+-- the next revision is written.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_history_open ON users_history (user_id) WHERE valid_to IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_history_validity ON users_history (user_id, valid_from, valid_to);
+
+-- Earlier states of existing users are unknown, so their history starts at
+-- their last change.
+INSERT INTO users_history (user_id, version, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, deleted_at, valid_from)
+SELECT id, version, email, first_name, last_name, status, status_reason, status_changed_by, status_changed_at, created_at, updated_at, deleted_at, updated_at
+FROM users
+ON CONFLICT DO NOTHING;
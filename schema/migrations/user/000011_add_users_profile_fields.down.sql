@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS tenant_attribute_schemas;
+
+ALTER TABLE users_history
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS avatar_ref,
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS locale,
+    DROP COLUMN IF EXISTS phone;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS avatar_ref,
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS locale,
+    DROP COLUMN IF EXISTS phone;
@@ -0,0 +1,22 @@
+-- Every column has a default so existing rows and older service versions
+-- keep working while the new fields roll out.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS phone VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(35) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS avatar_ref VARCHAR(512) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE users_history
+    ADD COLUMN IF NOT EXISTS phone VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(35) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS avatar_ref VARCHAR(512) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS tenant_attribute_schemas (
+    tenant_id VARCHAR(64) PRIMARY KEY,
+    schema JSONB NOT NULL,
+    updated_by VARCHAR(64) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
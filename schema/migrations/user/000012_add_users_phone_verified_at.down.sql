@@ -0,0 +1,3 @@
+ALTER TABLE users_history DROP COLUMN IF EXISTS phone_verified_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS phone_verified_at;
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMP;
+
+ALTER TABLE users_history ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMP;
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS username_reservations;
+
+ALTER TABLE users_history
+    DROP COLUMN IF EXISTS username_changed_at,
+    DROP COLUMN IF EXISTS username;
+
+DROP INDEX IF EXISTS idx_users_username_lower;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS username_changed_at,
+    DROP COLUMN IF EXISTS username;
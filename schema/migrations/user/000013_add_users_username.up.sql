@@ -0,0 +1,19 @@
+-- Usernames keep the case they were chosen in but are unique regardless of
+-- it. Soft-deleted users keep theirs so a restore cannot collide.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS username VARCHAR(30) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS username_changed_at TIMESTAMP;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_lower ON users (LOWER(username)) WHERE username <> '';
+
+ALTER TABLE users_history
+    ADD COLUMN IF NOT EXISTS username VARCHAR(30) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS username_changed_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS username_reservations (
+    username VARCHAR(30) PRIMARY KEY,
+    user_id UUID NOT NULL,
+    reserved_until TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_username_reservations_user_id ON username_reservations (user_id);
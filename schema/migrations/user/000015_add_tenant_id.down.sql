@@ -0,0 +1,38 @@
+DROP INDEX IF EXISTS idx_users_tenant_id;
+
+DROP INDEX IF EXISTS idx_organizations_tenant_slug;
+ALTER TABLE organizations ADD CONSTRAINT organizations_slug_key UNIQUE (slug);
+
+ALTER TABLE username_reservations DROP CONSTRAINT IF EXISTS username_reservations_pkey;
+ALTER TABLE username_reservations ADD PRIMARY KEY (username);
+
+DROP INDEX IF EXISTS idx_users_username_lower;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_lower ON users (LOWER(username)) WHERE username <> '';
+
+DROP INDEX IF EXISTS idx_users_email_live;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_live ON users (email) WHERE deleted_at IS NULL;
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'users', 'users_history', 'audit_events', 'erasure_requests',
+        'username_reservations', 'organizations', 'organization_memberships',
+        'organization_invitations', 'tenant_attribute_schemas'
+    ] LOOP
+        EXECUTE format('DROP POLICY IF EXISTS tenant_sweep ON %I', t);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', t);
+        -- tenant_attribute_schemas was keyed by tenant before this migration.
+        IF t = 'tenant_attribute_schemas' THEN
+            EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id DROP DEFAULT', t);
+        ELSE
+            EXECUTE format('ALTER TABLE %I DROP COLUMN IF EXISTS tenant_id', t);
+        END IF;
+    END LOOP;
+END
+$$;
+
+DROP FUNCTION IF EXISTS current_tenant();
@@ -0,0 +1,43 @@
+-- Every table carries the tenant its rows belong to. Row-level security
+-- confines a transaction to the tenant DataStore.Atomic sets in
+-- app.tenant_id; existing rows move to the default tenant.
+CREATE OR REPLACE FUNCTION current_tenant() RETURNS TEXT AS $$
+    SELECT NULLIF(current_setting('app.tenant_id', true), '')
+$$ LANGUAGE SQL STABLE;
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'users', 'users_history', 'audit_events', 'erasure_requests',
+        'username_reservations', 'organizations', 'organization_memberships',
+        'organization_invitations', 'tenant_attribute_schemas'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT ''default''', t);
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id SET DEFAULT current_tenant()', t);
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant() AND tenant_id <> ''*'')', t);
+        -- Background sweeps read across tenants with app.tenant_id = '*'.
+        EXECUTE format('DROP POLICY IF EXISTS tenant_sweep ON %I', t);
+        EXECUTE format('CREATE POLICY tenant_sweep ON %I FOR SELECT USING (current_tenant() = ''*'')', t);
+    END LOOP;
+END
+$$;
+
+-- Emails, usernames and slugs are only unique within a tenant.
+DROP INDEX IF EXISTS idx_users_email_live;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_live ON users (tenant_id, email) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_users_username_lower;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_lower ON users (tenant_id, LOWER(username)) WHERE username <> '';
+
+ALTER TABLE username_reservations DROP CONSTRAINT IF EXISTS username_reservations_pkey;
+ALTER TABLE username_reservations ADD PRIMARY KEY (tenant_id, username);
+
+ALTER TABLE organizations DROP CONSTRAINT IF EXISTS organizations_slug_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organizations_tenant_slug ON organizations (tenant_id, slug);
+
+CREATE INDEX IF NOT EXISTS idx_users_tenant_id ON users (tenant_id);
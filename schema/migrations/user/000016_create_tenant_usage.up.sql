@@ -0,0 +1,15 @@
+-- Daily usage per tenant, rolled up from the Redis counters. Rows are
+-- rewritten with the running total until the day is over.
+CREATE TABLE IF NOT EXISTS tenant_usage (
+    tenant_id VARCHAR(64) NOT NULL DEFAULT current_tenant(),
+    metric VARCHAR(32) NOT NULL,
+    day DATE NOT NULL,
+    value BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, metric, day)
+);
+
+ALTER TABLE tenant_usage ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tenant_usage FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON tenant_usage USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant() AND tenant_id <> '*');
+CREATE POLICY tenant_sweep ON tenant_usage FOR SELECT USING (current_tenant() = '*');
@@ -0,0 +1,31 @@
+-- Long-running operations. A worker holds a running operation for as long
+-- as its lease lasts and renews it while working; an operation whose lease
+-- ran out, because its worker stopped, is picked up by another worker.
+CREATE TABLE IF NOT EXISTS operations (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(64) NOT NULL DEFAULT current_tenant(),
+    type VARCHAR(64) NOT NULL,
+    state VARCHAR(16) NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}',
+    progress JSONB NOT NULL DEFAULT '{}',
+    result JSONB NOT NULL DEFAULT '{}',
+    error_code INT NOT NULL DEFAULT 0,
+    error_message TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(64) NOT NULL DEFAULT '',
+    cancel_requested BOOLEAN NOT NULL DEFAULT FALSE,
+    attempts INT NOT NULL DEFAULT 0,
+    lease_owner VARCHAR(128) NOT NULL DEFAULT '',
+    lease_expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_operations_runnable ON operations (created_at) WHERE state IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_operations_tenant_created ON operations (tenant_id, created_at DESC, id DESC);
+
+ALTER TABLE operations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE operations FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON operations USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant() AND tenant_id <> '*');
+CREATE POLICY tenant_sweep ON operations FOR SELECT USING (current_tenant() = '*');
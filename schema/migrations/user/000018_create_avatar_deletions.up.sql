@@ -0,0 +1,16 @@
+-- Avatars of purged and erased users that the blob store has yet to delete.
+-- An entry is added in the transaction that removes the user and dropped
+-- once the store confirms, so a failed deletion is retried later.
+CREATE TABLE IF NOT EXISTS avatar_deletions (
+    tenant_id VARCHAR(64) NOT NULL DEFAULT current_tenant(),
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_avatar_deletions_created ON avatar_deletions (created_at);
+
+ALTER TABLE avatar_deletions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE avatar_deletions FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON avatar_deletions USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant() AND tenant_id <> '*');
+CREATE POLICY tenant_sweep ON avatar_deletions FOR SELECT USING (current_tenant() = '*');